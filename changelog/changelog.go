@@ -0,0 +1,170 @@
+package changelog
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/k1LoW/errors"
+	"github.com/k1LoW/tbls/config"
+	"github.com/k1LoW/tbls/schema"
+)
+
+const (
+	// FileName is the file name of the changelog document.
+	FileName = "CHANGELOG.md"
+	// DataFileName is the file name of the structured changelog entries.
+	DataFileName = "changelog.json"
+	// DateFormat is the date format of changelog entries.
+	DateFormat = "2006-01-02"
+)
+
+// Changelog is the list of schema changes.
+type Changelog struct {
+	// Entries are sorted from oldest to newest
+	Entries []*Entry `json:"entries"`
+}
+
+// Entry is the schema changes detected at a time.
+type Entry struct {
+	Date    time.Time        `json:"date"`
+	Changes []*schema.Change `json:"changes"`
+}
+
+// History is a change of a table.
+type History struct {
+	Date   time.Time
+	Change *schema.Change
+}
+
+// Load changelog from the data file path. It returns empty Changelog if the data file does not exist.
+func Load(path string) (_ *Changelog, err error) {
+	defer func() {
+		err = errors.WithStack(err)
+	}()
+	cl := &Changelog{
+		Entries: []*Entry{},
+	}
+	b, err := os.ReadFile(filepath.Clean(path))
+	if err != nil {
+		if os.IsNotExist(err) {
+			return cl, nil
+		}
+		return nil, err
+	}
+	if err := json.Unmarshal(b, cl); err != nil {
+		return nil, fmt.Errorf("failed to load %s: %w", DataFileName, err)
+	}
+	return cl, nil
+}
+
+// Build loads changelog from the doc path and appends the changes between schema.json in the doc path and s.
+// It appends nothing if schema.json does not exist yet or there are no changes.
+func Build(docPath string, s *schema.Schema, opt *schema.DiffOption, now time.Time) (_ *Changelog, err error) {
+	defer func() {
+		err = errors.WithStack(err)
+	}()
+	cl, err := Load(filepath.Join(docPath, DataFileName))
+	if err != nil {
+		return nil, err
+	}
+	f, err := os.Open(filepath.Clean(filepath.Join(docPath, config.SchemaFileName)))
+	if err != nil {
+		if os.IsNotExist(err) {
+			return cl, nil
+		}
+		return nil, err
+	}
+	defer func() {
+		_ = f.Close()
+	}()
+	prev := &schema.Schema{}
+	if err := json.NewDecoder(f).Decode(prev); err != nil {
+		return nil, err
+	}
+	if err := prev.Repair(); err != nil {
+		return nil, err
+	}
+	cl.Append(now, schema.DiffWithOption(prev, s, opt))
+	return cl, nil
+}
+
+// Append appends the diff as a new entry. Before and after objects are dropped to keep the changelog small.
+func (cl *Changelog) Append(date time.Time, d *schema.SchemaDiff) {
+	if !d.HasChanges() {
+		return
+	}
+	e := &Entry{
+		Date:    date,
+		Changes: []*schema.Change{},
+	}
+	for _, c := range d.Changes {
+		e.Changes = append(e.Changes, &schema.Change{
+			Type:       c.Type,
+			ObjectType: c.ObjectType,
+			Table:      c.Table,
+			Name:       c.Name,
+			From:       c.From,
+			Severity:   c.Severity,
+			Fields:     c.Fields,
+		})
+	}
+	cl.Entries = append(cl.Entries, e)
+}
+
+// Write writes the changelog to the data file path.
+func (cl *Changelog) Write(path string) (err error) {
+	defer func() {
+		err = errors.WithStack(err)
+	}()
+	b, err := json.MarshalIndent(cl, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(filepath.Clean(path), append(b, '\n'), 0644) // #nosec
+}
+
+// TableHistory returns the changes of the table from newest to oldest. The changes before renaming the table are included.
+func (cl *Changelog) TableHistory(table string) []*History {
+	history := []*History{}
+	names := map[string]struct{}{table: {}}
+	for i := len(cl.Entries) - 1; i >= 0; i-- {
+		e := cl.Entries[i]
+		renamed := []string{}
+		for _, c := range e.Changes {
+			if _, ok := names[c.Table]; !ok {
+				continue
+			}
+			history = append(history, &History{Date: e.Date, Change: c})
+			if c.ObjectType == schema.ObjectTypeTable && c.Type == schema.DiffRenamed {
+				renamed = append(renamed, c.From)
+			}
+		}
+		for _, n := range renamed {
+			names[n] = struct{}{}
+		}
+	}
+	return history
+}
+
+// Describe returns the Markdown description of the change.
+func Describe(c *schema.Change) string {
+	var d string
+	switch c.Type {
+	case schema.DiffRenamed:
+		d = fmt.Sprintf("%s `%s` renamed from `%s`", c.ObjectType, c.Path(), c.From)
+	default:
+		d = fmt.Sprintf("%s `%s` %s", c.ObjectType, c.Path(), c.Type)
+	}
+	if len(c.Fields) == 0 {
+		return d
+	}
+	fields := []string{}
+	for _, f := range c.Fields {
+		fields = append(fields, f.String())
+	}
+	return fmt.Sprintf("%s ( %s )", d, strings.Join(fields, ", "))
+}
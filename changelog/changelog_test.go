@@ -0,0 +1,116 @@
+package changelog
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/k1LoW/tbls/schema"
+	"github.com/k1LoW/tbls/testutil"
+)
+
+func TestBuild(t *testing.T) {
+	docPath := t.TempDir()
+	now := time.Date(2024, 4, 1, 0, 0, 0, 0, time.UTC)
+	s := testutil.NewSchema(t)
+
+	// no schema.json
+	cl, err := Build(docPath, s, schema.DefaultDiffOption(), now)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(cl.Entries) != 0 {
+		t.Errorf("got %v\nwant %v", len(cl.Entries), 0)
+	}
+
+	b, err := json.Marshal(s)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(docPath, "schema.json"), b, 0600); err != nil {
+		t.Fatal(err)
+	}
+	ta, err := s.FindTableByName("a")
+	if err != nil {
+		t.Fatal(err)
+	}
+	ta.Columns = append(ta.Columns, &schema.Column{
+		Name:     "added",
+		Type:     "text",
+		Nullable: true,
+	})
+	cl, err = Build(docPath, s, schema.DefaultDiffOption(), now)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := cl.Write(filepath.Join(docPath, DataFileName)); err != nil {
+		t.Fatal(err)
+	}
+
+	got, err := Load(filepath.Join(docPath, DataFileName))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(got.Entries) != 1 {
+		t.Fatalf("got %v\nwant %v", len(got.Entries), 1)
+	}
+	if !got.Entries[0].Date.Equal(now) {
+		t.Errorf("got %v\nwant %v", got.Entries[0].Date, now)
+	}
+	h := got.TableHistory("a")
+	if len(h) != 1 {
+		t.Fatalf("got %v\nwant %v", len(h), 1)
+	}
+	if want := "column `a.added` added"; Describe(h[0].Change) != want {
+		t.Errorf("got %v\nwant %v", Describe(h[0].Change), want)
+	}
+	if h := got.TableHistory("b"); len(h) != 0 {
+		t.Errorf("got %v\nwant %v", len(h), 0)
+	}
+}
+
+func TestTableHistory(t *testing.T) {
+	cl := &Changelog{
+		Entries: []*Entry{
+			{
+				Date: time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC),
+				Changes: []*schema.Change{
+					{Type: schema.DiffAdded, ObjectType: schema.ObjectTypeColumn, Table: "user", Name: "email"},
+				},
+			},
+			{
+				Date: time.Date(2024, 2, 1, 0, 0, 0, 0, time.UTC),
+				Changes: []*schema.Change{
+					{Type: schema.DiffRenamed, ObjectType: schema.ObjectTypeTable, Table: "users", Name: "users", From: "user"},
+				},
+			},
+			{
+				Date: time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC),
+				Changes: []*schema.Change{
+					{Type: schema.DiffModified, ObjectType: schema.ObjectTypeColumn, Table: "users", Name: "email", Fields: []*schema.FieldChange{
+						{Field: "nullable", Before: true, After: false},
+					}},
+				},
+			},
+		},
+	}
+	got := []string{}
+	for _, h := range cl.TableHistory("users") {
+		got = append(got, h.Date.Format(DateFormat)+" "+Describe(h.Change))
+	}
+	want := []string{
+		"2024-03-01 column `users.email` modified ( nullable: true -> false )",
+		"2024-02-01 table `users` renamed from `user`",
+		"2024-01-01 column `user.email` added",
+	}
+	if len(got) != len(want) {
+		t.Fatalf("got %v\nwant %v", got, want)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Errorf("got %v\nwant %v", got[i], want[i])
+		}
+	}
+}
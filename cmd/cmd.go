@@ -1,6 +1,8 @@
 package cmd
 
 import (
+	"context"
+	"fmt"
 	"os"
 
 	"github.com/k1LoW/tbls/config"
@@ -8,7 +10,7 @@ import (
 	"github.com/k1LoW/tbls/schema"
 )
 
-func getSchemaFromJSONorDSN(c *config.Config) (*schema.Schema, error) {
+func getSchemaFromJSONorDSN(ctx context.Context, c *config.Config) (*schema.Schema, error) {
 	if _, err := os.Stat(c.SchemaFilePath()); err == nil {
 		s, err := datasource.AnalyzeJSONStringOrFile(c.SchemaFilePath())
 		if err != nil {
@@ -19,12 +21,23 @@ func getSchemaFromJSONorDSN(c *config.Config) (*schema.Schema, error) {
 		}
 		return s, nil
 	}
-	s, err := datasource.Analyze(c.DSN)
+	s, err := datasource.AnalyzeWithConfigContext(ctx, c.DSN, c)
 	if err != nil {
 		return nil, err
 	}
-	if err := c.ModifySchema(s); err != nil {
+	if err := modifySchema(c, s); err != nil {
 		return nil, err
 	}
 	return s, nil
 }
+
+// modifySchema modifies the schema with the config and prints the warnings found on the way.
+func modifySchema(c *config.Config, s *schema.Schema) error {
+	if err := c.ModifySchema(s); err != nil {
+		return err
+	}
+	for _, w := range c.Warnings() {
+		_, _ = fmt.Fprintf(os.Stderr, "Warning: %s\n", w)
+	}
+	return nil
+}
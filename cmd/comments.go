@@ -0,0 +1,245 @@
+/*
+Copyright © 2023 Ken'ichiro Oyama <k1lowxb@gmail.com>
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in
+all copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+THE SOFTWARE.
+*/
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"slices"
+	"strings"
+
+	"github.com/k1LoW/errors"
+	"github.com/k1LoW/tbls/config"
+	"github.com/k1LoW/tbls/output/commentcheck"
+	"github.com/k1LoW/tbls/schema"
+	"github.com/spf13/cobra"
+)
+
+// commentsFrom is a option that format of the source comments.
+var commentsFrom string
+
+// commentsTo is a option that format of the converted comments.
+var commentsTo string
+
+// commentsDriver is a option that driver of the comment migration script.
+var commentsDriver string
+
+// commentsFormat is a option that format of the comments written back to the database.
+var commentsFormat string
+
+// commentsCheckFormat is a option that format of the comment check report.
+var commentsCheckFormat string
+
+// commentsFailOn is a option that minimum severity to exit with non-zero status.
+var commentsFailOn string
+
+// commentsCmd represents the comments command.
+var commentsCmd = &cobra.Command{
+	Use:   "comments",
+	Short: "manage structured comments of database objects",
+	Long:  `'tbls comments' manages structured ( legacy, JSON and YAML ) comments of database objects.`,
+}
+
+// commentsConvertCmd represents the comments convert command.
+var commentsConvertCmd = &cobra.Command{
+	Use:   "convert [DSN]",
+	Short: "convert comments to another format",
+	Long:  `'tbls comments convert' prints the comment of every object in the schema converted to another format ( legacy, json or yaml ).`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if !slices.Contains(schema.SupportCommentFormats, commentsTo) {
+			return errors.WithStack(fmt.Errorf("unsupported format: %s (%s)", commentsTo, strings.Join(schema.SupportCommentFormats, ", ")))
+		}
+
+		c, err := config.New()
+		if err != nil {
+			return err
+		}
+
+		options, err := loadCommentsArgs(args)
+		if err != nil {
+			return err
+		}
+
+		if err := c.Load(configPath, options...); err != nil {
+			return err
+		}
+
+		s, err := getSchemaFromJSONorDSN(cmd.Context(), c)
+		if err != nil {
+			return err
+		}
+
+		failed := 0
+		for _, o := range s.CommentObjects(c.LogicalNameDelimiter(), c.LogicalNameFallbackToName()) {
+			converted, err := schema.ConvertComment(o.Comment, commentsFrom, commentsTo, c.LogicalNameDelimiter())
+			if err != nil {
+				_, _ = fmt.Fprintf(os.Stderr, "%s %s: %v\n", o.Type, o.FullName(), err)
+				failed++
+				continue
+			}
+			fmt.Printf("# %s %s\n%s\n\n", o.Type, o.FullName(), converted)
+		}
+		if failed > 0 {
+			return errors.WithStack(fmt.Errorf("failed to convert %d comments", failed))
+		}
+		return nil
+	},
+}
+
+// commentsMigrateCmd represents the comments migrate command.
+var commentsMigrateCmd = &cobra.Command{
+	Use:   "migrate [DSN]",
+	Short: "generate SQL that writes comments back to the database",
+	Long:  `'tbls comments migrate' generates SQL ( COMMENT ON, ALTER TABLE ... COMMENT or sp_addextendedproperty ) that writes the comments of the schema, including comments defined in .tbls.yml, back to the database.`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if commentsFormat != "" && !slices.Contains(schema.SupportCommentFormats, commentsFormat) {
+			return errors.WithStack(fmt.Errorf("unsupported format: %s (%s)", commentsFormat, strings.Join(schema.SupportCommentFormats, ", ")))
+		}
+
+		c, err := config.New()
+		if err != nil {
+			return err
+		}
+
+		options, err := loadCommentsArgs(args)
+		if err != nil {
+			return err
+		}
+
+		if err := c.Load(configPath, options...); err != nil {
+			return err
+		}
+
+		s, err := getSchemaFromJSONorDSN(cmd.Context(), c)
+		if err != nil {
+			return err
+		}
+
+		driverName := commentsDriver
+		if driverName == "" {
+			if s.Driver == nil {
+				return errors.WithStack(errors.New("no driver specified. use --driver"))
+			}
+			driverName = s.Driver.Name
+		}
+
+		script, err := schema.CommentMigrationScript(s, driverName, commentsFormat, c.LogicalNameDelimiter(), c.LogicalNameFallbackToName())
+		if err != nil {
+			return err
+		}
+		fmt.Print(script)
+		return nil
+	},
+}
+
+// commentsCheckCmd represents the comments check command.
+var commentsCheckCmd = &cobra.Command{
+	Use:   "check [DSN]",
+	Short: "check structured comments",
+	Long:  `'tbls comments check' parses and validates the structured comments of every object in the schema, and reports parse errors, validation errors and recoveries from them with the object, error code and suggestions.`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		o, err := commentcheck.New(commentsCheckFormat)
+		if err != nil {
+			return err
+		}
+		failOn, err := schema.ParseErrorSeverity(commentsFailOn)
+		if err != nil {
+			return err
+		}
+
+		c, err := config.New()
+		if err != nil {
+			return err
+		}
+
+		options, err := loadCommentsArgs(args)
+		if err != nil {
+			return err
+		}
+
+		if err := c.Load(configPath, options...); err != nil {
+			return err
+		}
+
+		// Load the schema without processing structured comments, so that the check reports every problem instead of failing on the first one.
+		enabled := c.EnhancedComment.Enabled
+		c.EnhancedComment.Enabled = false
+		s, err := getSchemaFromJSONorDSN(cmd.Context(), c)
+		c.EnhancedComment.Enabled = enabled
+		if err != nil {
+			return err
+		}
+
+		reporter := schema.NewDefaultErrorReporter()
+		if err := c.CheckComments(s, reporter); err != nil {
+			return err
+		}
+		if err := o.OutputReport(os.Stdout, reporter.Errors()); err != nil {
+			return err
+		}
+		for _, e := range reporter.Errors() {
+			if e.Severity >= failOn {
+				os.Exit(1)
+			}
+		}
+		return nil
+	},
+}
+
+func loadCommentsArgs(args []string) ([]config.Option, error) {
+	options := []config.Option{}
+	if len(args) > 1 {
+		return options, errors.WithStack(errors.New("too many arguments"))
+	}
+	if len(args) == 1 {
+		options = append(options, config.DSNURL(args[0]))
+	}
+	if dsn != "" {
+		options = append(options, config.DSNURL(dsn))
+	}
+	options = append(options, config.Timeout(timeout))
+	return options, nil
+}
+
+func init() {
+	rootCmd.AddCommand(commentsCmd)
+	commentsCmd.AddCommand(commentsConvertCmd)
+	commentsConvertCmd.Flags().StringVarP(&dsn, "dsn", "", "", "data source name")
+	commentsConvertCmd.Flags().StringVarP(&configPath, "config", "c", "", "config file path")
+	commentsConvertCmd.Flags().StringVarP(&commentsFrom, "from", "", schema.CommentFormatAuto, fmt.Sprintf("format of the source comments (%s, %s)", schema.CommentFormatAuto, strings.Join(schema.SupportCommentFormats, ", ")))
+	commentsConvertCmd.Flags().StringVarP(&commentsTo, "to", "", schema.CommentFormatJSON, fmt.Sprintf("format of the converted comments (%s)", strings.Join(schema.SupportCommentFormats, ", ")))
+	commentsConvertCmd.Flags().DurationVarP(&timeout, "timeout", "", 0, "timeout of database analysis ( ex. 5m ). default: no timeout")
+
+	commentsCmd.AddCommand(commentsMigrateCmd)
+	commentsMigrateCmd.Flags().StringVarP(&dsn, "dsn", "", "", "data source name")
+	commentsMigrateCmd.Flags().StringVarP(&configPath, "config", "c", "", "config file path")
+	commentsMigrateCmd.Flags().StringVarP(&commentsDriver, "driver", "", "", "driver of the generated SQL (postgres, mysql, mssql, sqlite). default: driver of the schema")
+	commentsMigrateCmd.Flags().StringVarP(&commentsFormat, "format", "", "", fmt.Sprintf("format of the comments written back (%s). default: as it is", strings.Join(schema.SupportCommentFormats, ", ")))
+	commentsMigrateCmd.Flags().DurationVarP(&timeout, "timeout", "", 0, "timeout of database analysis ( ex. 5m ). default: no timeout")
+
+	commentsCmd.AddCommand(commentsCheckCmd)
+	commentsCheckCmd.Flags().StringVarP(&dsn, "dsn", "", "", "data source name")
+	commentsCheckCmd.Flags().StringVarP(&configPath, "config", "c", "", "config file path")
+	commentsCheckCmd.Flags().StringVarP(&commentsCheckFormat, "format", "", "text", fmt.Sprintf("format of the report (%s)", strings.Join(commentcheck.SupportFormats, ", ")))
+	commentsCheckCmd.Flags().StringVarP(&commentsFailOn, "fail-on", "", "error", "exit with non-zero status when a problem of the severity or higher exists (info, warning, error, critical)")
+	commentsCheckCmd.Flags().DurationVarP(&timeout, "timeout", "", 0, "timeout of database analysis ( ex. 5m ). default: no timeout")
+}
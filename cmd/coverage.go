@@ -42,7 +42,7 @@ var coverageCmd = &cobra.Command{
 	Use:   "coverage [DSN]",
 	Short: "measure document coverage",
 	Long:  `'tbls coverage' measure document coverage.`,
-	RunE: func(_ *cobra.Command, args []string) error {
+	RunE: func(cmd *cobra.Command, args []string) error {
 		if allow, err := cmdutil.IsAllowedToExecute(when); !allow || err != nil {
 			if err != nil {
 				return err
@@ -64,12 +64,12 @@ var coverageCmd = &cobra.Command{
 			return err
 		}
 
-		s, err := getSchemaFromJSONorDSN(c)
+		s, err := getSchemaFromJSONorDSN(cmd.Context(), c)
 		if err != nil {
 			return err
 		}
 
-		if err := c.ModifySchema(s); err != nil {
+		if err := modifySchema(c, s); err != nil {
 			return err
 		}
 
@@ -93,10 +93,19 @@ var coverageCmd = &cobra.Command{
 			}
 		default:
 			fmtName := fmt.Sprintf("%%-%ds", maxWidth)
-			fmt.Printf("%s  %s\n", color.White(fmt.Sprintf(fmtName, "Table"), color.B), color.White("Coverage", color.B))
-			fmt.Printf("%s  %g%%\n", fmt.Sprintf(fmtName, "All tables"), cover.Coverage)
+			if cover.Inherited == 0 {
+				fmt.Printf("%s  %s\n", color.White(fmt.Sprintf(fmtName, "Table"), color.B), color.White("Coverage", color.B))
+				fmt.Printf("%s  %g%%\n", fmt.Sprintf(fmtName, "All tables"), cover.Coverage)
+				for _, t := range cover.Tables {
+					fmt.Printf(" %s %g%%\n", fmt.Sprintf(fmtName, t.Name), t.Coverage)
+				}
+				break
+			}
+			// show the number of column comments inherited from parent columns, which are not counted as covered
+			fmt.Printf("%s  %s  %s\n", color.White(fmt.Sprintf(fmtName, "Table"), color.B), color.White("Coverage", color.B), color.White("Inherited", color.B))
+			fmt.Printf("%s  %-8s  %d\n", fmt.Sprintf(fmtName, "All tables"), fmt.Sprintf("%g%%", cover.Coverage), cover.Inherited)
 			for _, t := range cover.Tables {
-				fmt.Printf(" %s %g%%\n", fmt.Sprintf(fmtName, t.Name), t.Coverage)
+				fmt.Printf(" %s %-8s  %d\n", fmt.Sprintf(fmtName, t.Name), fmt.Sprintf("%g%%", t.Coverage), t.Inherited)
 			}
 		}
 		return nil
@@ -23,23 +23,35 @@ package cmd
 import (
 	"fmt"
 	"os"
+	"path/filepath"
 	"strings"
 
+	"github.com/k1LoW/errors"
 	"github.com/k1LoW/tbls/cmdutil"
 	"github.com/k1LoW/tbls/config"
 	"github.com/k1LoW/tbls/datasource"
+	"github.com/k1LoW/tbls/output/diff"
 	"github.com/k1LoW/tbls/output/md"
 	"github.com/k1LoW/tbls/schema"
 	"github.com/spf13/cobra"
 )
 
+// diffFormat is a option that diff output format.
+var diffFormat string
+
+// diffFailOn is a option that minimum severity to exit with non-zero status.
+var diffFailOn string
+
+// diffGitRef is a option that git ref of schema.json to compare with.
+var diffGitRef string
+
 // diffCmd represents the diff command.
 var diffCmd = &cobra.Command{
 	Use:   "diff [DSN] [DSN_OR_DOC_PATH]",
 	Short: "diff database and ( document or database )",
 	Long:  `'tbls diff' shows the difference between database schema and ( generated document or other database schema ).`,
 	Args:  cobra.MaximumNArgs(2),
-	RunE: func(_ *cobra.Command, args []string) error {
+	RunE: func(cmd *cobra.Command, args []string) error {
 		if allow, err := cmdutil.IsAllowedToExecute(when); !allow || err != nil {
 			if err != nil {
 				return err
@@ -116,24 +128,31 @@ var diffCmd = &cobra.Command{
 			docPath = ""
 		}
 
-		s, err = datasource.AnalyzeWithConfig(c.DSN, c)
+		s, err = datasource.AnalyzeWithConfigContext(cmd.Context(), c.DSN, c)
 		if err != nil {
 			return err
 		}
-		if err := c.ModifySchema(s); err != nil {
+		if err := modifySchema(c, s); err != nil {
 			return err
 		}
 
 		if c2 != nil {
-			s2, err = datasource.AnalyzeWithConfig(c2.DSN, c2)
+			s2, err = datasource.AnalyzeWithConfigContext(cmd.Context(), c2.DSN, c2)
 			if err != nil {
 				return err
 			}
-			if err := c2.ModifySchema(s2); err != nil {
+			if err := modifySchema(c2, s2); err != nil {
 				return err
 			}
 		}
 
+		if (diffFailOn != "" || diffGitRef != "") && diffFormat == "" {
+			diffFormat = "text"
+		}
+		if diffFormat != "" {
+			return outputSchemaDiff(s, s2, docPath, c)
+		}
+
 		switch {
 		case docPath != "":
 			diff, err = md.DiffSchemaAndDocs(docPath, s, c)
@@ -154,6 +173,63 @@ var diffCmd = &cobra.Command{
 	},
 }
 
+// outputSchemaDiff output typed changes between ( document or database ) and database.
+func outputSchemaDiff(s, s2 *schema.Schema, docPath string, c *config.Config) error {
+	o, err := diff.New(diffFormat)
+	if err != nil {
+		return err
+	}
+	var failOn schema.Severity
+	if diffFailOn != "" {
+		failOn, err = schema.ToSeverity(diffFailOn)
+		if err != nil {
+			return err
+		}
+	}
+	var from, to *schema.Schema
+	switch {
+	case diffGitRef != "":
+		if s2 != nil {
+			return errors.New("--git-ref can not be used with two DSNs")
+		}
+		if docPath == "" {
+			docPath = c.DocPath
+		}
+		from, err = datasource.AnalyzeGitRevision(diffGitRef, filepath.Join(docPath, config.SchemaFileName))
+		if err != nil {
+			return err
+		}
+		to = s
+	case s2 != nil:
+		from = s
+		to = s2
+	default:
+		if docPath == "" {
+			docPath = c.DocPath
+		}
+		sf := filepath.Join(docPath, config.SchemaFileName)
+		if _, err := os.Stat(sf); err != nil {
+			return fmt.Errorf("%s is required to output diff in '%s' format: %w", sf, diffFormat, err)
+		}
+		from, err = datasource.AnalyzeJSONStringOrFile(sf)
+		if err != nil {
+			return err
+		}
+		to = s
+	}
+	d := schema.DiffWithOption(from, to, c.DiffOption())
+	if err := o.OutputDiff(os.Stdout, d); err != nil {
+		return err
+	}
+	if !d.HasChanges() {
+		return nil
+	}
+	if failOn == "" || d.MaxSeverity().AtLeast(failOn) {
+		os.Exit(1)
+	}
+	return nil
+}
+
 func loadDiffOpts() []config.Option {
 	options := []config.Option{}
 	if adjust {
@@ -163,6 +239,7 @@ func loadDiffOpts() []config.Option {
 		options = append(options, config.Sort(sort))
 	}
 	options = append(options, config.ERFormat(erFormat))
+	options = append(options, config.Timeout(timeout))
 	return options
 }
 
@@ -173,6 +250,10 @@ func init() {
 	diffCmd.Flags().StringVarP(&erFormat, "er-format", "t", "", fmt.Sprintf("ER diagrams output format (png, svg, jpg, ...). default: %s", config.DefaultERFormat))
 	diffCmd.Flags().BoolVarP(&adjust, "adjust-table", "j", false, "adjust column width of table")
 	diffCmd.Flags().StringVarP(&when, "when", "", "", "command execute condition")
+	diffCmd.Flags().DurationVarP(&timeout, "timeout", "", 0, "timeout of database analysis ( ex. 5m ). default: no timeout")
+	diffCmd.Flags().StringVarP(&diffFormat, "format", "", "", fmt.Sprintf("structured diff output format (%s)", strings.Join(diff.SupportFormats, ", ")))
+	diffCmd.Flags().StringVarP(&diffGitRef, "git-ref", "", "", "compare database with schema.json in the document directory at the git ref ( ex. main )")
+	diffCmd.Flags().StringVarP(&diffFailOn, "fail-on", "", "", "exit with non-zero status only when a change of the severity or higher exists (breaking, risky, safe)")
 	if err := diffCmd.MarkZshCompPositionalArgumentFile(2); err != nil {
 		_, _ = fmt.Fprintf(os.Stderr, "%s\n", err)
 		os.Exit(1)
@@ -25,12 +25,15 @@ import (
 	"os"
 	"path/filepath"
 	"strings"
+	"time"
 
 	"github.com/k1LoW/errors"
+	"github.com/k1LoW/tbls/changelog"
 	"github.com/k1LoW/tbls/cmdutil"
 	"github.com/k1LoW/tbls/config"
 	"github.com/k1LoW/tbls/datasource"
 	"github.com/k1LoW/tbls/output/gviz"
+	"github.com/k1LoW/tbls/output/html"
 	"github.com/k1LoW/tbls/output/json"
 	"github.com/k1LoW/tbls/output/md"
 	"github.com/k1LoW/tbls/schema"
@@ -40,6 +43,7 @@ import (
 var (
 	withoutER bool
 	rmDist    bool
+	withHTML  bool
 )
 
 // docCmd represents the doc command.
@@ -47,7 +51,7 @@ var docCmd = &cobra.Command{
 	Use:   "doc [DSN] [DOC_PATH]",
 	Short: "document a database",
 	Long:  `'tbls doc' analyzes a database and generate document in GitHub Friendly Markdown format.`,
-	RunE: func(_ *cobra.Command, args []string) error {
+	RunE: func(cmd *cobra.Command, args []string) error {
 		if allow, err := cmdutil.IsAllowedToExecute(when); !allow || err != nil {
 			if err != nil {
 				return err
@@ -69,12 +73,12 @@ var docCmd = &cobra.Command{
 			return err
 		}
 
-		s, err := datasource.AnalyzeWithConfig(c.DSN, c)
+		s, err := datasource.AnalyzeWithConfigContext(cmd.Context(), c.DSN, c)
 		if err != nil {
 			return err
 		}
 
-		if err := c.ModifySchema(s); err != nil {
+		if err := modifySchema(c, s); err != nil {
 			return err
 		}
 
@@ -85,6 +89,10 @@ var docCmd = &cobra.Command{
 					return errors.WithStack(err)
 				}
 				for _, f := range docs {
+					if c.Changelog.Enabled && (f.Name() == changelog.DataFileName || f.Name() == config.SchemaFileName) {
+						// keep the sources of changelog
+						continue
+					}
 					if err := os.RemoveAll(filepath.Join(c.DocPath, f.Name())); err != nil {
 						return errors.WithStack(err)
 					}
@@ -92,6 +100,13 @@ var docCmd = &cobra.Command{
 			}
 		}
 
+		// append the changes since schema.json generated last time to changelog.json
+		if c.Changelog.Enabled && (force || rmDist) {
+			if err := withChangelog(s, c); err != nil {
+				return err
+			}
+		}
+
 		if c.NeedToGenerateERImages() {
 			if err := gviz.Output(s, c, force); err != nil {
 				return err
@@ -102,6 +117,12 @@ var docCmd = &cobra.Command{
 			return err
 		}
 
+		if c.HTML.Enabled {
+			if err := html.Output(s, c, force); err != nil {
+				return err
+			}
+		}
+
 		// output schema.json
 		if !c.DisableOutputSchema {
 			if err := withSchemaFile(s, c); err != nil {
@@ -113,6 +134,17 @@ var docCmd = &cobra.Command{
 	},
 }
 
+func withChangelog(s *schema.Schema, c *config.Config) error {
+	cl, err := changelog.Build(c.DocPath, s, c.DiffOption(), time.Now())
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(c.DocPath, 0755); err != nil { // #nosec
+		return errors.WithStack(err)
+	}
+	return cl.Write(filepath.Join(c.DocPath, changelog.DataFileName))
+}
+
 func withSchemaFile(s *schema.Schema, c *config.Config) (e error) {
 	sf, err := os.Create(c.SchemaFilePath())
 	if err != nil {
@@ -144,10 +176,17 @@ func loadDocArgs(args []string) ([]config.Option, error) {
 	if withoutER {
 		options = append(options, config.ERSkip(withoutER))
 	}
+	if withHTML {
+		options = append(options, config.HTMLEnabled(withHTML))
+	}
 	options = append(options, config.BaseURL(baseURL))
 	options = append(options, config.Include(append(tables, includes...)))
 	options = append(options, config.Exclude(excludes))
 	options = append(options, config.IncludeLabels(labels))
+	options = append(options, config.IncludeTags(tags))
+	options = append(options, config.ExcludeTags(excludeTags))
+	options = append(options, config.Lang(lang))
+	options = append(options, config.Timeout(timeout))
 	if len(args) == 2 {
 		options = append(options, config.DSNURL(args[0]))
 		options = append(options, config.DocPath(args[1]))
@@ -171,12 +210,17 @@ func init() {
 	docCmd.Flags().BoolVarP(&withoutER, "without-er", "", false, "no generate ER diagrams")
 	docCmd.Flags().BoolVarP(&adjust, "adjust-table", "j", false, "adjust column width of table")
 	docCmd.Flags().StringVarP(&when, "when", "", "", "command execute condition")
+	docCmd.Flags().DurationVarP(&timeout, "timeout", "", 0, "timeout of database analysis ( ex. 5m ). default: no timeout")
 	docCmd.Flags().StringVarP(&baseURL, "base-url", "b", "", "base url for links")
 	docCmd.Flags().BoolVarP(&rmDist, "rm-dist", "", false, "remove files in docPath before generating documents")
+	docCmd.Flags().BoolVarP(&withHTML, "html", "", false, "generate static HTML documentation site with Markdown documents")
 	docCmd.Flags().StringSliceVarP(&tables, "table", "", []string{}, "target table (tables to include)")
 	docCmd.Flags().StringSliceVarP(&includes, "include", "", []string{}, "tables to include")
 	docCmd.Flags().StringSliceVarP(&excludes, "exclude", "", []string{}, "tables to exclude")
 	docCmd.Flags().StringSliceVarP(&labels, "label", "", []string{}, "table labels to be included")
+	docCmd.Flags().StringSliceVarP(&tags, "tag", "", []string{}, "tags of enhanced comments of tables or columns to be included")
+	docCmd.Flags().StringSliceVarP(&excludeTags, "exclude-tag", "", []string{}, "tags of enhanced comments of tables to be excluded")
+	docCmd.Flags().StringVarP(&lang, "lang", "", "", "locale of logical names and descriptions of enhanced comments ( ex. ja, en )")
 
 	if err := docCmd.MarkZshCompPositionalArgumentFile(2); err != nil {
 		_, _ = fmt.Fprintf(os.Stderr, "%s\n", err)
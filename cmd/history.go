@@ -0,0 +1,84 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+
+	"github.com/k1LoW/errors"
+	"github.com/k1LoW/tbls/cmdutil"
+	"github.com/k1LoW/tbls/config"
+	"github.com/k1LoW/tbls/output/diff"
+	"github.com/k1LoW/tbls/snapshot"
+	"github.com/spf13/cobra"
+)
+
+// historyFormat is a option that history output format.
+var historyFormat string
+
+// historyCmd represents the history command.
+var historyCmd = &cobra.Command{
+	Use:   "history [TABLE|TABLE.COLUMN]",
+	Short: "show history of a table or column across snapshots",
+	Long:  `'tbls history' shows when a table or column first appeared, changed and was dropped across snapshots saved by 'tbls snapshot'.`,
+	Args:  cobra.ExactArgs(1),
+	RunE: func(_ *cobra.Command, args []string) error {
+		if allow, err := cmdutil.IsAllowedToExecute(when); !allow || err != nil {
+			if err != nil {
+				return err
+			}
+			return nil
+		}
+
+		c, err := config.New()
+		if err != nil {
+			return err
+		}
+
+		if err := c.Load(configPath, config.SnapshotDir(snapshotDir)); err != nil {
+			return err
+		}
+
+		st := snapshot.NewStore(c.SnapshotDir())
+		snapshots, err := st.List()
+		if err != nil {
+			return err
+		}
+		if len(snapshots) == 0 {
+			return fmt.Errorf("no snapshots in %s. run 'tbls snapshot' first", c.SnapshotDir())
+		}
+		events, err := snapshot.History(snapshots, args[0], c.DiffOption())
+		if err != nil {
+			return err
+		}
+		if len(events) == 0 {
+			return fmt.Errorf("'%s' not found in snapshots", args[0])
+		}
+
+		switch historyFormat {
+		case "json":
+			encoder := json.NewEncoder(os.Stdout)
+			encoder.SetIndent("", "  ")
+			if err := encoder.Encode(events); err != nil {
+				return errors.WithStack(err)
+			}
+		default:
+			for _, e := range events {
+				line := diff.TextLine(e.Change)
+				if e.Initial {
+					line = fmt.Sprintf("%s (first snapshot)", line)
+				}
+				fmt.Printf("%s  %s  %s\n", e.Time.Local().Format("2006-01-02 15:04:05"), e.Hash, line)
+			}
+		}
+		return nil
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(historyCmd)
+	historyCmd.Flags().StringVarP(&configPath, "config", "c", "", "config file path")
+	historyCmd.Flags().StringVarP(&snapshotDir, "dir", "", "", fmt.Sprintf("snapshot directory. default: %s", config.DefaultSnapshotDir))
+	historyCmd.Flags().StringVarP(&historyFormat, "format", "t", "", "output format (json)")
+	historyCmd.Flags().StringVarP(&when, "when", "", "", "command execute condition")
+}
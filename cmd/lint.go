@@ -38,7 +38,7 @@ var lintCmd = &cobra.Command{
 	Use:   "lint [DSN] [DOC_PATH]",
 	Short: "check database document",
 	Long:  `'tbls lint' check database document.`,
-	RunE: func(_ *cobra.Command, args []string) error {
+	RunE: func(cmd *cobra.Command, args []string) error {
 		if allow, err := cmdutil.IsAllowedToExecute(when); !allow || err != nil {
 			if err != nil {
 				return err
@@ -60,12 +60,12 @@ var lintCmd = &cobra.Command{
 			return err
 		}
 
-		s, err := datasource.AnalyzeWithConfig(c.DSN, c)
+		s, err := datasource.AnalyzeWithConfigContext(cmd.Context(), c.DSN, c)
 		if err != nil {
 			return err
 		}
 
-		if err := c.ModifySchema(s); err != nil {
+		if err := modifySchema(c, s); err != nil {
 			return err
 		}
 
@@ -108,6 +108,7 @@ func loadLintArgs(args []string) ([]config.Option, error) {
 	if dsn != "" {
 		options = append(options, config.DSNURL(dsn))
 	}
+	options = append(options, config.Timeout(timeout))
 	return options, nil
 }
 
@@ -116,6 +117,7 @@ func init() {
 	lintCmd.Flags().StringVarP(&dsn, "dsn", "", "", "data source name")
 	lintCmd.Flags().StringVarP(&configPath, "config", "c", "", "config file path")
 	lintCmd.Flags().StringVarP(&when, "when", "", "", "command execute condition")
+	lintCmd.Flags().DurationVarP(&timeout, "timeout", "", 0, "timeout of database analysis ( ex. 5m ). default: no timeout")
 	err := lintCmd.MarkZshCompPositionalArgumentFile(2)
 	if err != nil {
 		_, _ = fmt.Fprintf(os.Stderr, "%s\n", err)
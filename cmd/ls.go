@@ -40,7 +40,7 @@ var lsCmd = &cobra.Command{
 	Use:   "ls",
 	Short: "list schema resources",
 	Long:  `list schema resources.`,
-	RunE: func(_ *cobra.Command, args []string) error {
+	RunE: func(cmd *cobra.Command, args []string) error {
 		c, err := config.New()
 		if err != nil {
 			return err
@@ -55,7 +55,7 @@ var lsCmd = &cobra.Command{
 			return err
 		}
 
-		s, err := getSchemaFromJSONorDSN(c)
+		s, err := getSchemaFromJSONorDSN(cmd.Context(), c)
 		if err != nil {
 			return err
 		}
@@ -122,6 +122,8 @@ func loadLsArgs(args []string) ([]config.Option, []string, error) {
 	options = append(options, config.Include(append(tables, includes...)))
 	options = append(options, config.Exclude(excludes))
 	options = append(options, config.IncludeLabels(labels))
+	options = append(options, config.IncludeTags(tags))
+	options = append(options, config.ExcludeTags(excludeTags))
 	options = append(options, config.Distance(distance))
 	return options, pattern, nil
 }
@@ -134,6 +136,8 @@ func init() {
 	lsCmd.Flags().StringSliceVarP(&includes, "include", "", []string{}, "tables to include")
 	lsCmd.Flags().StringSliceVarP(&excludes, "exclude", "", []string{}, "tables to exclude")
 	lsCmd.Flags().StringSliceVarP(&labels, "label", "", []string{}, "table labels to be included")
+	lsCmd.Flags().StringSliceVarP(&tags, "tag", "", []string{}, "tags of enhanced comments of tables or columns to be included")
+	lsCmd.Flags().StringSliceVarP(&excludeTags, "exclude-tag", "", []string{}, "tags of enhanced comments of tables to be excluded")
 	lsCmd.Flags().IntVarP(&distance, "distance", "", 0, "distance between related tables to be displayed")
 	lsCmd.Flags().BoolVarP(&long, "long", "l", false, "list in the long format")
 }
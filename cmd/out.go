@@ -35,6 +35,7 @@ import (
 	"github.com/k1LoW/tbls/output/json"
 	"github.com/k1LoW/tbls/output/md"
 	"github.com/k1LoW/tbls/output/mermaid"
+	"github.com/k1LoW/tbls/output/pii"
 	"github.com/k1LoW/tbls/output/plantuml"
 	"github.com/k1LoW/tbls/output/xlsx"
 	"github.com/k1LoW/tbls/output/yaml"
@@ -52,7 +53,7 @@ var outCmd = &cobra.Command{
 	Use:   "out [DSN]",
 	Short: "analyzes a database and output",
 	Long:  `'tbls out' analyzes a database and output.`,
-	RunE: func(_ *cobra.Command, args []string) error {
+	RunE: func(cmd *cobra.Command, args []string) error {
 		if allow, err := cmdutil.IsAllowedToExecute(when); !allow || err != nil {
 			if err != nil {
 				return err
@@ -74,7 +75,7 @@ var outCmd = &cobra.Command{
 			return err
 		}
 
-		s, err := getSchemaFromJSONorDSN(c)
+		s, err := getSchemaFromJSONorDSN(cmd.Context(), c)
 		if err != nil {
 			return err
 		}
@@ -102,6 +103,8 @@ var outCmd = &cobra.Command{
 			o = gviz.New(c)
 		case "config":
 			o = tbls_config.New(c)
+		case "pii":
+			o = pii.New(c)
 		default:
 			return fmt.Errorf("unsupported format '%s'", format)
 		}
@@ -148,6 +151,9 @@ func loadOutArgs(args []string) ([]config.Option, error) {
 	options = append(options, config.Include(append(tables, includes...)))
 	options = append(options, config.Exclude(excludes))
 	options = append(options, config.IncludeLabels(labels))
+	options = append(options, config.IncludeTags(tags))
+	options = append(options, config.ExcludeTags(excludeTags))
+	options = append(options, config.Lang(lang))
 
 	if len(args) == 1 {
 		options = append(options, config.DSNURL(args[0]))
@@ -166,6 +172,9 @@ func init() {
 	outCmd.Flags().StringSliceVarP(&includes, "include", "", []string{}, "tables to include")
 	outCmd.Flags().StringSliceVarP(&excludes, "exclude", "", []string{}, "tables to exclude")
 	outCmd.Flags().StringSliceVarP(&labels, "label", "", []string{}, "table labels to be included")
+	outCmd.Flags().StringSliceVarP(&tags, "tag", "", []string{}, "tags of enhanced comments of tables or columns to be included")
+	outCmd.Flags().StringSliceVarP(&excludeTags, "exclude-tag", "", []string{}, "tags of enhanced comments of tables to be excluded")
+	outCmd.Flags().StringVarP(&lang, "lang", "", "", "locale of logical names and descriptions of enhanced comments ( ex. ja, en )")
 	outCmd.Flags().IntVarP(&distance, "distance", "", 0, "distance between related tables to be displayed")
 	outCmd.Flags().StringVarP(&when, "when", "", "", "command execute condition")
 }
@@ -21,13 +21,16 @@
 package cmd
 
 import (
+	"context"
 	"fmt"
 	"os"
 	"os/exec"
+	"os/signal"
 	"path/filepath"
 	sortpkg "sort"
 	"strconv"
 	"strings"
+	"time"
 
 	"github.com/cli/safeexec"
 	"github.com/k1LoW/errors"
@@ -70,9 +73,21 @@ var excludes []string
 // table labels to be included.
 var labels []string
 
+// tags of enhanced comments to be included.
+var tags []string
+
+// tags of enhanced comments to be excluded.
+var excludeTags []string
+
+// locale of logical names and descriptions of enhanced comments.
+var lang string
+
 // dsn.
 var dsn string
 
+// timeout of database analysis.
+var timeout time.Duration
+
 const rootUsageTemplate = `Usage:{{if .Runnable}}{{if ne .UseLine "tbls [flags]" }}
   {{.UseLine}}{{end}}{{end}}{{if .HasAvailableSubCommands}}
   {{.CommandPath}} [command]{{end}}{{if gt (len .Aliases) 0}}
@@ -153,7 +168,7 @@ var rootCmd = &cobra.Command{
 			return err
 		}
 
-		s, err := getSchemaFromJSONorDSN(cfg)
+		s, err := getSchemaFromJSONorDSN(cmd.Context(), cfg)
 		if err == nil {
 			envs = append(envs, fmt.Sprintf("TBLS_DSN=%s", cfg.DSN.URL))
 			envs = append(envs, fmt.Sprintf("TBLS_CONFIG_PATH=%s", cfg.Path))
@@ -189,10 +204,13 @@ func Execute() {
 		os.Exit(1)
 	}
 
-	if err := rootCmd.Execute(); err != nil {
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	if err := rootCmd.ExecuteContext(ctx); err != nil {
+		stop()
 		printError(err)
 		os.Exit(1)
 	}
+	stop()
 }
 
 func init() {
@@ -0,0 +1,91 @@
+package cmd
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/k1LoW/errors"
+	"github.com/k1LoW/tbls/cmdutil"
+	"github.com/k1LoW/tbls/config"
+	"github.com/k1LoW/tbls/datasource"
+	"github.com/k1LoW/tbls/snapshot"
+	"github.com/spf13/cobra"
+)
+
+// snapshotDir is a option that directory of schema snapshots.
+var snapshotDir string
+
+// snapshotCmd represents the snapshot command.
+var snapshotCmd = &cobra.Command{
+	Use:   "snapshot [DSN]",
+	Short: "save a snapshot of the database schema",
+	Long:  `'tbls snapshot' saves a timestamped, content-addressed JSON snapshot of the database schema into the snapshot directory.`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if allow, err := cmdutil.IsAllowedToExecute(when); !allow || err != nil {
+			if err != nil {
+				return err
+			}
+			return nil
+		}
+
+		c, err := config.New()
+		if err != nil {
+			return err
+		}
+
+		options, err := loadSnapshotArgs(args)
+		if err != nil {
+			return err
+		}
+
+		if err := c.Load(configPath, options...); err != nil {
+			return err
+		}
+
+		s, err := datasource.AnalyzeWithConfigContext(cmd.Context(), c.DSN, c)
+		if err != nil {
+			return err
+		}
+
+		if err := modifySchema(c, s); err != nil {
+			return err
+		}
+
+		st := snapshot.NewStore(c.SnapshotDir())
+		sn, saved, err := st.Save(s, time.Now())
+		if err != nil {
+			return err
+		}
+		if !saved {
+			fmt.Printf("%s (not changed)\n", sn.Path)
+			return nil
+		}
+		fmt.Println(sn.Path)
+		return nil
+	},
+}
+
+func loadSnapshotArgs(args []string) ([]config.Option, error) {
+	options := []config.Option{}
+	if len(args) > 1 {
+		return options, errors.WithStack(errors.New("too many arguments"))
+	}
+	if len(args) == 1 {
+		options = append(options, config.DSNURL(args[0]))
+	}
+	if dsn != "" {
+		options = append(options, config.DSNURL(dsn))
+	}
+	options = append(options, config.SnapshotDir(snapshotDir))
+	options = append(options, config.Timeout(timeout))
+	return options, nil
+}
+
+func init() {
+	rootCmd.AddCommand(snapshotCmd)
+	snapshotCmd.Flags().StringVarP(&dsn, "dsn", "", "", "data source name")
+	snapshotCmd.Flags().StringVarP(&configPath, "config", "c", "", "config file path")
+	snapshotCmd.Flags().StringVarP(&snapshotDir, "dir", "", "", fmt.Sprintf("snapshot directory. default: %s", config.DefaultSnapshotDir))
+	snapshotCmd.Flags().StringVarP(&when, "when", "", "", "command execute condition")
+	snapshotCmd.Flags().DurationVarP(&timeout, "timeout", "", 0, "timeout of database analysis ( ex. 5m ). default: no timeout")
+}
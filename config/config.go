@@ -6,12 +6,14 @@ import (
 	"os"
 	"path/filepath"
 	"strings"
+	"time"
 
 	"github.com/aquasecurity/go-version/pkg/version"
 	"github.com/goccy/go-yaml"
 	"github.com/k1LoW/errors"
 	"github.com/k1LoW/expand"
 	"github.com/k1LoW/tbls/dict"
+	"github.com/k1LoW/tbls/glossary"
 	"github.com/k1LoW/tbls/schema"
 	ver "github.com/k1LoW/tbls/version"
 	"github.com/minio/pkg/wildcard"
@@ -29,6 +31,9 @@ var SupportERFormat = []string{"png", "jpg", "svg", "mermaid"}
 
 const SchemaFileName = "schema.json"
 
+// DefaultSnapshotDir is the default directory of schema snapshots.
+const DefaultSnapshotDir = ".tbls/snapshots"
+
 // DefaultERDistance is the default distance between tables that display relations in the ER.
 var DefaultERDistance = 1
 
@@ -50,6 +55,10 @@ type Config struct {
 	ER                     ER                     `yaml:"er,omitempty"`
 	Include                []string               `yaml:"include,omitempty"`
 	Exclude                []string               `yaml:"exclude,omitempty"`
+	// IncludeTags and ExcludeTags are not nested in include: because include: is a list of table names
+	IncludeTags            []string               `yaml:"includeTags,omitempty"`
+	ExcludeTags            []string               `yaml:"excludeTags,omitempty"`
+	ExcludeColumnTags      []string               `yaml:"excludeColumnTags,omitempty"`
 	Distance               int                    `yaml:"distance,omitempty"`
 	Lint                   Lint                   `yaml:"lint,omitempty"`
 	LintExclude            []string               `yaml:"lintExclude,omitempty"`
@@ -59,9 +68,17 @@ type Config struct {
 	Dict                   dict.Dict              `yaml:"dict,omitempty"`
 	Templates              Templates              `yaml:"templates,omitempty"`
 	DetectVirtualRelations DetectVirtualRelations `yaml:"detectVirtualRelations,omitempty"`
+	InheritComments        InheritComments        `yaml:"inheritComments,omitempty"`
 	BaseURL                string                 `yaml:"baseUrl,omitempty"`
 	RequiredVersion        string                 `yaml:"requiredVersion,omitempty"`
 	DisableOutputSchema    bool                   `yaml:"disableOutputSchema,omitempty"`
+	Diff                   Diff                   `yaml:"diff,omitempty"`
+	Changelog              Changelog              `yaml:"changelog,omitempty"`
+	Glossary               Glossary               `yaml:"glossary,omitempty"`
+	Classification         Classification         `yaml:"classification,omitempty"`
+	HTML                   HTML                   `yaml:"html,omitempty"`
+	Snapshot               Snapshot               `yaml:"snapshot,omitempty"`
+	Analyze                Analyze                `yaml:"analyze,omitempty"`
 	// EnhancedComment 拡張コメント処理設定
 	EnhancedComment        EnhancedCommentConfig  `yaml:"enhancedComment,omitempty"`
 	MergedDict             dict.Dict              `yaml:"-"`
@@ -69,6 +86,9 @@ type Config struct {
 	// Table labels to be included
 	includeLabels []string
 
+	// Problems found by ModifySchema that do not fail it
+	warnings []error
+
 	// Path of config file
 	Path string `yaml:"-"`
 	root string `yaml:"-"`
@@ -87,6 +107,7 @@ type Format struct {
 	ShowOnlyFirstParagraph   bool        `yaml:"showOnlyFirstParagraph,omitempty"`
 	HideColumnsWithoutValues []string    `yaml:"hideColumnsWithoutValues,omitempty"`
 	LogicalName              LogicalName `yaml:"logicalName,omitempty"`
+	Lang                     string      `yaml:"lang,omitempty"`
 }
 
 // ER is er setting.
@@ -95,6 +116,7 @@ type ER struct {
 	Format          string           `yaml:"format,omitempty"`
 	Comment         bool             `yaml:"comment,omitempty"`
 	HideDef         bool             `yaml:"hideDef,omitempty"`
+	HideDeprecated  bool             `yaml:"hideDeprecated,omitempty"`
 	ShowColumnTypes *ShowColumnTypes `yaml:"showColumnTypes,omitempty"`
 	Distance        *int             `yaml:"distance,omitempty"`
 	Font            string           `yaml:"font,omitempty"`
@@ -130,6 +152,22 @@ type EnhancedCommentConfig struct {
 	Validation EnhancedCommentValidationConfig `yaml:"validation,omitempty"`
 	// Processing 処理設定
 	Processing EnhancedCommentProcessingConfig `yaml:"processing,omitempty"`
+	// MetadataSchema オブジェクトタイプ（table, view, column, index, constraint, trigger）ごとのメタデータ定義
+	MetadataSchema map[string][]EnhancedCommentMetadataField `yaml:"metadataSchema,omitempty"`
+}
+
+// EnhancedCommentMetadataField メタデータキーの定義
+type EnhancedCommentMetadataField struct {
+	// Key メタデータキー
+	Key string `yaml:"key"`
+	// Required 必須キーかどうか
+	Required bool `yaml:"required,omitempty"`
+	// Type 値の型 ("string", "int", "bool", "date")
+	Type string `yaml:"type,omitempty"`
+	// Enum 許可される値
+	Enum []string `yaml:"enum,omitempty"`
+	// Pattern 値が一致すべき正規表現
+	Pattern string `yaml:"pattern,omitempty"`
 }
 
 // EnhancedCommentParserConfig パーサー設定
@@ -138,7 +176,7 @@ type EnhancedCommentParserConfig struct {
 	EnableJSON bool `yaml:"enableJSON"`
 	// EnableYAML YAML形式のコメント解析を有効にするか
 	EnableYAML bool `yaml:"enableYAML"`
-	// PreferredFormat 優先パーサー形式 ("auto", "json", "yaml", "legacy")
+	// PreferredFormat 優先パーサー形式 ("auto", "json", "yaml", "annotation", "legacy")
 	PreferredFormat string `yaml:"preferredFormat,omitempty"`
 	// FallbackToLegacy 他のパーサーが失敗した場合にlegacyパーサーにフォールバックするか
 	FallbackToLegacy bool `yaml:"fallbackToLegacy"`
@@ -178,10 +216,72 @@ type EnhancedCommentProcessingConfig struct {
 	ProcessingTimeout int `yaml:"processingTimeout,omitempty"`
 	// EnableBatchProcessing バッチ処理を有効にするか
 	EnableBatchProcessing bool `yaml:"enableBatchProcessing"`
+	// MaxWorkers バッチ処理のワーカー数（0以下の場合はGOMAXPROCS）
+	MaxWorkers int `yaml:"maxWorkers,omitempty"`
 	// ObjectTypes 処理対象のオブジェクトタイプ
 	ObjectTypes []string `yaml:"objectTypes,omitempty"`
 }
 
+// Diff is structured diff setting.
+type Diff struct {
+	DisableRenameDetection bool         `yaml:"disableRenameDetection,omitempty"`
+	RenameThreshold        *float64     `yaml:"renameThreshold,omitempty"`
+	Renames                []DiffRename `yaml:"renames,omitempty"`
+}
+
+// DiffRename is the struct for known rename of table or column from yaml.
+type DiffRename struct {
+	Table string `yaml:"table,omitempty"`
+	From  string `yaml:"from"`
+	To    string `yaml:"to"`
+}
+
+// Changelog is schema changelog setting.
+type Changelog struct {
+	Enabled bool `yaml:"enabled,omitempty"`
+}
+
+// Glossary is business glossary setting.
+type Glossary struct {
+	// Path of the glossary file. Relative path is resolved from the directory of the config file
+	Path  string           `yaml:"path,omitempty"`
+	Terms []*glossary.Term `yaml:"terms,omitempty"`
+}
+
+// HTML is static HTML documentation site setting.
+type HTML struct {
+	Enabled bool `yaml:"enabled,omitempty"`
+}
+
+// Classification is data classification setting of columns.
+type Classification struct {
+	// Heuristics classifies columns that are not classified by rules or comments by their names and types
+	Heuristics bool                 `yaml:"heuristics,omitempty"`
+	Rules      []ClassificationRule `yaml:"rules,omitempty"`
+}
+
+// ClassificationRule is the classification of columns matching patterns ( ex. users.email, *.phone ).
+type ClassificationRule struct {
+	Level    string   `yaml:"level"`
+	Category string   `yaml:"category,omitempty"`
+	Columns  []string `yaml:"columns"`
+}
+
+// Snapshot is schema snapshot setting.
+type Snapshot struct {
+	Dir string `yaml:"dir,omitempty"`
+}
+
+// Analyze is database analysis setting.
+type Analyze struct {
+	// Timeout of the whole analysis ( ex. 5m )
+	Timeout string `yaml:"timeout,omitempty"`
+	// QueryTimeout of each catalog query ( ex. 30s ). It is set to the DSN as statement_timeout ( PostgreSQL ), max_execution_time ( MySQL ) or max_statement_time ( MariaDB )
+	QueryTimeout string `yaml:"queryTimeout,omitempty"`
+	// Concurrency is the number of tables analyzed concurrently ( PostgreSQL, Redshift, MySQL, MariaDB and SQL Server )
+	Concurrency int `yaml:"concurrency,omitempty"`
+}
+
 // AdditionalRelation is the struct for table relation from yaml.
 type AdditionalRelation struct {
 	Table             string   `yaml:"table"`
@@ -211,6 +311,11 @@ type DetectVirtualRelations struct {
 	Strategy string `yaml:"strategy,omitempty"`
 }
 
+// InheritComments is the setting to fill missing logical names, descriptions and tags of foreign key columns from their parent columns.
+type InheritComments struct {
+	Enabled bool `yaml:"enabled,omitempty"`
+}
+
 // Option function change Config.
 type Option func(*Config) error
 
@@ -240,6 +345,16 @@ func Adjust(adjust bool) Option {
 	}
 }
 
+// Lang return Option set Config.Format.Lang.
+func Lang(lang string) Option {
+	return func(c *Config) error {
+		if lang != "" {
+			c.Format.Lang = lang
+		}
+		return nil
+	}
+}
+
 // Sort return Option set Config.Format.Sort.
 func Sort(sort bool) Option {
 	return func(c *Config) error {
@@ -306,6 +421,46 @@ func Exclude(e []string) Option {
 	}
 }
 
+// IncludeTags return Option set Config.IncludeTags.
+func IncludeTags(t []string) Option {
+	return func(c *Config) error {
+		if len(t) > 0 {
+			c.IncludeTags = t
+		}
+		return nil
+	}
+}
+
+// ExcludeTags return Option set Config.ExcludeTags.
+func ExcludeTags(t []string) Option {
+	return func(c *Config) error {
+		if len(t) > 0 {
+			c.ExcludeTags = t
+		}
+		return nil
+	}
+}
+
+// SnapshotDir return Option set Config.Snapshot.Dir.
+func SnapshotDir(dir string) Option {
+	return func(c *Config) error {
+		if dir != "" {
+			c.Snapshot.Dir = dir
+		}
+		return nil
+	}
+}
+
+// Timeout return Option set Config.Analyze.Timeout.
+func Timeout(timeout time.Duration) Option {
+	return func(c *Config) error {
+		if timeout > 0 {
+			c.Analyze.Timeout = timeout.String()
+		}
+		return nil
+	}
+}
+
 // IncludeLabels return Option set Config.includeLabels.
 func IncludeLabels(l []string) Option {
 	return func(c *Config) error {
@@ -316,6 +471,16 @@ func IncludeLabels(l []string) Option {
 	}
 }
 
+// HTMLEnabled return Option set Config.HTML.Enabled.
+func HTMLEnabled(enabled bool) Option {
+	return func(c *Config) error {
+		if enabled {
+			c.HTML.Enabled = enabled
+		}
+		return nil
+	}
+}
+
 // New return Config.
 func New() (*Config, error) {
 	c := Config{}
@@ -332,6 +497,10 @@ func (c *Config) Load(configPath string, options ...Option) error {
 		return err
 	}
 
+	if err := c.loadGlossaryFile(); err != nil {
+		return err
+	}
+
 	if err := c.LoadEnviron(); err != nil {
 		return err
 	}
@@ -492,6 +661,182 @@ func (c *Config) IsEnhancedCommentObjectTypeEnabled(objectType string) bool {
 	return false
 }
 
+// EnhancedCommentMetadataSchema メタデータ定義を返す（未設定の場合はnil）
+func (c *Config) EnhancedCommentMetadataSchema() (*schema.MetadataSchema, error) {
+	if len(c.EnhancedComment.MetadataSchema) == 0 {
+		return nil, nil
+	}
+	fields := map[schema.ObjectType][]*schema.MetadataField{}
+	for objectType, fs := range c.EnhancedComment.MetadataSchema {
+		switch schema.ObjectType(objectType) {
+		case schema.ObjectTypeTable, schema.ObjectTypeView, schema.ObjectTypeColumn, schema.ObjectTypeIndex, schema.ObjectTypeConstraint, schema.ObjectTypeTrigger:
+		default:
+			return nil, fmt.Errorf("enhancedComment.metadataSchema: unsupported object type: %s", objectType)
+		}
+		for _, f := range fs {
+			fields[schema.ObjectType(objectType)] = append(fields[schema.ObjectType(objectType)], &schema.MetadataField{
+				Key:      f.Key,
+				Required: f.Required,
+				Type:     f.Type,
+				Enum:     f.Enum,
+				Pattern:  f.Pattern,
+			})
+		}
+	}
+	ms, err := schema.NewMetadataSchema(fields)
+	if err != nil {
+		return nil, fmt.Errorf("enhancedComment.metadataSchema: %w", err)
+	}
+	return ms, nil
+}
+
+// processEnhancedComments 構造化コメント（JSON/YAML/アノテーション）を解析して拡張コメントデータを設定
+// 従来形式のコメントはドライバーの論理名処理で分割済みのため対象外（メタデータ定義の必須キーのみ検証する）
+func (c *Config) processEnhancedComments(s *schema.Schema) error {
+	c.splitStructuredComments(s)
+	if !c.IsEnhancedCommentEnabled() {
+		return nil
+	}
+	processor, err := c.newEnhancedCommentProcessor()
+	if err != nil {
+		return err
+	}
+	ms, err := c.EnhancedCommentMetadataSchema()
+	if err != nil {
+		return err
+	}
+	delimiter := c.LogicalNameDelimiter()
+	fallbackToName := c.LogicalNameFallbackToName()
+	strict := c.IsEnhancedCommentStrictMode()
+	tasks := []schema.CommentTask{}
+	add := func(objectType schema.ObjectType, comment, name string, fn func() error) {
+		if !c.IsEnhancedCommentObjectTypeEnabled(string(objectType)) {
+			return
+		}
+		if !schema.IsStructuredComment(comment) {
+			if ms == nil {
+				return
+			}
+			// 構造化コメントを持たないオブジェクトもメタデータ定義の必須キーを検証する
+			fn = func() error {
+				return ms.Validate(objectType, nil)
+			}
+		}
+		tasks = append(tasks, schema.CommentTask{ObjectType: objectType, Name: name, Process: fn})
+	}
+	for _, t := range s.Tables {
+		tableType := schema.ObjectTypeTable
+		if strings.Contains(strings.ToUpper(t.Type), "VIEW") {
+			tableType = schema.ObjectTypeView
+		}
+		add(tableType, t.Comment, t.Name, func() error {
+			return t.ProcessEnhancedComment(processor, delimiter, fallbackToName)
+		})
+		for _, col := range t.Columns {
+			add(schema.ObjectTypeColumn, col.Comment, t.Name+"."+col.Name, func() error {
+				return col.ProcessEnhancedComment(processor, delimiter, fallbackToName)
+			})
+		}
+		for _, i := range t.Indexes {
+			add(schema.ObjectTypeIndex, i.Comment, t.Name+"."+i.Name, func() error {
+				return i.ProcessEnhancedComment(processor, delimiter)
+			})
+		}
+		for _, con := range t.Constraints {
+			add(schema.ObjectTypeConstraint, con.Comment, t.Name+"."+con.Name, func() error {
+				return con.ProcessEnhancedComment(processor, delimiter)
+			})
+		}
+		for _, tr := range t.Triggers {
+			add(schema.ObjectTypeTrigger, tr.Comment, t.Name+"."+tr.Name, func() error {
+				return tr.ProcessEnhancedComment(processor, delimiter)
+			})
+		}
+	}
+
+	// バッチ処理が有効な場合は並列に処理（エラーはスキーマ上のオブジェクト順）
+	workers := 1
+	if c.EnhancedComment.Processing.EnableBatchProcessing {
+		workers = c.EnhancedComment.Processing.MaxWorkers
+	}
+	// メタデータ定義への違反はすべて集め、厳格モードではエラー、それ以外では警告とする
+	violations := []error{}
+	for i, err := range schema.RunCommentTasks(tasks, workers) {
+		task := tasks[i]
+		switch {
+		case err == nil:
+		case errors.Is(err, schema.ErrMetadataSchemaViolation):
+			violations = append(violations, fmt.Errorf("%s %s: %w", task.ObjectType, task.Name, err))
+		case strict:
+			return fmt.Errorf("failed to process the comment of %s %s: %w", task.ObjectType, task.Name, err)
+		}
+	}
+	if strict {
+		return errors.Join(violations...)
+	}
+	c.warnings = append(c.warnings, violations...)
+	return nil
+}
+
+// Warnings returns the problems found by ModifySchema that do not fail it ( ex. metadata schema violations outside strict mode ).
+func (c *Config) Warnings() []error {
+	return c.warnings
+}
+
+// splitStructuredComments 拡張コメント処理の対象外のテーブル/カラムについて、ドライバーが分割しなかった構造化コメントを従来形式として論理名を分割
+// 拡張コメント処理を有効にしない限り、論理名はこれまでどおりドライバーの分割結果と同じになる
+func (c *Config) splitStructuredComments(s *schema.Schema) {
+	enabled := func(objectType schema.ObjectType) bool {
+		return c.IsEnhancedCommentEnabled() && c.IsEnhancedCommentObjectTypeEnabled(string(objectType))
+	}
+	for _, t := range s.Tables {
+		tableType := schema.ObjectTypeTable
+		if strings.Contains(strings.ToUpper(t.Type), "VIEW") {
+			tableType = schema.ObjectTypeView
+		}
+		if !enabled(tableType) {
+			t.SetLogicalNameFromStructuredComment(c.TableLogicalNameDelimiter(), c.TableLogicalNameFallbackToName())
+		}
+		if enabled(schema.ObjectTypeColumn) {
+			continue
+		}
+		for _, col := range t.Columns {
+			col.SetLogicalNameFromStructuredComment(c.LogicalNameDelimiter(), c.LogicalNameFallbackToName())
+		}
+	}
+}
+
+// CheckComments スキーマ内のコメントを拡張コメント処理の設定で検査し、問題をreporterに報告する
+// 拡張コメント処理が無効な場合はデフォルト設定で全オブジェクトを検査する
+func (c *Config) CheckComments(s *schema.Schema, reporter schema.ErrorReporter) error {
+	processor, err := c.newEnhancedCommentProcessor()
+	if err != nil {
+		return err
+	}
+	objects := []*schema.CommentObject{}
+	// メタデータ定義の必須キーを検査するため、コメントが空のオブジェクトも含める
+	for _, o := range s.AllCommentObjects(c.LogicalNameDelimiter(), c.LogicalNameFallbackToName()) {
+		if c.IsEnhancedCommentEnabled() && !c.IsEnhancedCommentObjectTypeEnabled(string(o.Type)) {
+			continue
+		}
+		objects = append(objects, o)
+	}
+	processor.CheckComments(objects, c.LogicalNameDelimiter(), reporter)
+	return nil
+}
+
+// newEnhancedCommentProcessor 設定（メタデータ定義と表示ロケールを含む）からコメントプロセッサーを作成
+func (c *Config) newEnhancedCommentProcessor() (*schema.EnhancedCommentProcessor, error) {
+	processor := schema.NewEnhancedCommentProcessorFromConfig(c)
+	ms, err := c.EnhancedCommentMetadataSchema()
+	if err != nil {
+		return nil, err
+	}
+	processor.SetMetadataSchema(ms)
+	processor.SetLang(c.Format.Lang)
+	return processor, nil
+}
+
 func (c *Config) checkVersion(sv string) error {
 	if sv == "dev" {
 		return nil
@@ -537,6 +882,50 @@ func (c *Config) validate() error {
 			}
 		}
 	}
+	if c.Changelog.Enabled && c.DisableOutputSchema {
+		return errors.New("changelog requires schema.json. disableOutputSchema can not be used with changelog")
+	}
+	names := map[string]string{}
+	for i, t := range c.Glossary.Terms {
+		if t.Name == "" {
+			return fmt.Errorf("glossary.terms[%d] name is required", i)
+		}
+		for _, w := range append([]string{t.Name}, t.Synonyms...) {
+			if n, ok := names[strings.ToLower(w)]; ok {
+				return fmt.Errorf("glossary.terms[%d] %s is already defined by term %s", i, w, n)
+			}
+			names[strings.ToLower(w)] = t.Name
+		}
+	}
+	for i, r := range c.Classification.Rules {
+		if _, err := schema.ParseClassificationLevel(r.Level); err != nil {
+			return fmt.Errorf("classification.rules[%d]: %w", i, err)
+		}
+		if len(r.Columns) == 0 {
+			return fmt.Errorf("classification.rules[%d] columns are required", i)
+		}
+	}
+	if t := c.Diff.RenameThreshold; t != nil && (*t < 0 || *t > 1) {
+		return fmt.Errorf("diff.renameThreshold must be between 0 and 1: %v", *t)
+	}
+	for i, r := range c.Diff.Renames {
+		if r.From == "" || r.To == "" {
+			return fmt.Errorf("diff.renames[%d] from and to are required", i)
+		}
+	}
+	if c.Analyze.Timeout != "" {
+		if _, err := time.ParseDuration(c.Analyze.Timeout); err != nil {
+			return fmt.Errorf("invalid analyze.timeout: %w", err)
+		}
+	}
+	if c.Analyze.Concurrency < 0 {
+		return fmt.Errorf("analyze.concurrency must be greater than or equal to 0: %d", c.Analyze.Concurrency)
+	}
+	if c.Analyze.QueryTimeout != "" {
+		if _, err := time.ParseDuration(c.Analyze.QueryTimeout); err != nil {
+			return fmt.Errorf("invalid analyze.queryTimeout: %w", err)
+		}
+	}
 
 	return nil
 }
@@ -593,6 +982,23 @@ func (c *Config) LoadConfigFile(path string) (err error) {
 	return c.LoadConfig(buf)
 }
 
+// loadGlossaryFile appends terms in the glossary file to Glossary.Terms.
+func (c *Config) loadGlossaryFile() error {
+	if c.Glossary.Path == "" {
+		return nil
+	}
+	p := c.Glossary.Path
+	if !filepath.IsAbs(p) && c.Path != "" {
+		p = filepath.Join(filepath.Dir(c.Path), p)
+	}
+	terms, err := glossary.LoadFile(p)
+	if err != nil {
+		return err
+	}
+	c.Glossary.Terms = append(c.Glossary.Terms, terms...)
+	return nil
+}
+
 // LoadConfig load config from []byte.
 func (c *Config) LoadConfig(in []byte) (err error) {
 	defer func() {
@@ -607,6 +1013,7 @@ func (c *Config) LoadConfig(in []byte) (err error) {
 
 // ModifySchema modify schema.Schema by config.
 func (c *Config) ModifySchema(s *schema.Schema) error {
+	c.warnings = nil
 	if c.Name != "" {
 		s.Name = c.Name
 	}
@@ -623,6 +1030,9 @@ func (c *Config) ModifySchema(s *schema.Schema) error {
 	if err := c.MergeAdditionalData(s); err != nil {
 		return err
 	}
+	if err := c.processEnhancedComments(s); err != nil {
+		return err
+	}
 	if err := c.FilterTables(s); err != nil {
 		return err
 	}
@@ -638,6 +1048,13 @@ func (c *Config) ModifySchema(s *schema.Schema) error {
 		}
 		mergeDetectedRelations(s, strategy)
 	}
+	// inherit after all relations are added, so that relations of .tbls.yml and detected relations are also followed
+	if c.InheritComments.Enabled {
+		s.InheritCommentsFromParents()
+	}
+	if err := c.classifyColumns(s); err != nil {
+		return err
+	}
 	c.mergeDictFromSchema(s)
 	if err := detectCardinality(s); err != nil {
 		return err
@@ -645,6 +1062,7 @@ func (c *Config) ModifySchema(s *schema.Schema) error {
 	if err := c.detectShowColumnsForER(s); err != nil {
 		return err
 	}
+	c.detectDeprecatedForER(s)
 
 	// set Viewpoints
 	// viewpoints should be created using as complete a schema as possible
@@ -660,9 +1078,11 @@ func (c *Config) ModifySchema(s *schema.Schema) error {
 		}); err != nil {
 			return err
 		}
+		copyEnhancedCommentData(cs, s)
 		if err := c.detectShowColumnsForER(cs); err != nil {
 			return err
 		}
+		c.detectDeprecatedForER(cs)
 		groups := []*schema.ViewpointGroup{}
 		tables := lo.Map(cs.Tables, func(t *schema.Table, _ int) string {
 			return t.Name
@@ -748,13 +1168,16 @@ func (c *Config) MergeAdditionalData(s *schema.Schema) error {
 	return nil
 }
 
-// FilterTables filter tables from schema.Schema using include: and exclude: and includeLabels.
+// FilterTables filter tables from schema.Schema using include:, exclude:, includeTags:, excludeTags:, excludeColumnTags: and includeLabels.
 func (c *Config) FilterTables(s *schema.Schema) error {
 	return s.Filter(&schema.FilterOption{
-		Include:       c.Include,
-		Exclude:       c.Exclude,
-		IncludeLabels: c.includeLabels,
-		Distance:      c.Distance,
+		Include:           c.Include,
+		Exclude:           c.Exclude,
+		IncludeLabels:     c.includeLabels,
+		IncludeTags:       c.IncludeTags,
+		ExcludeTags:       c.ExcludeTags,
+		ExcludeColumnTags: c.ExcludeColumnTags,
+		Distance:          c.Distance,
 	})
 }
 
@@ -783,6 +1206,43 @@ func (c *Config) SchemaFilePath() string {
 	return filepath.Join(c.DocPath, SchemaFileName)
 }
 
+// DiffOption returns schema.DiffOption for structured diff.
+func (c *Config) DiffOption() *schema.DiffOption {
+	opt := schema.DefaultDiffOption()
+	opt.DetectRenames = !c.Diff.DisableRenameDetection
+	if c.Diff.RenameThreshold != nil {
+		opt.RenameThreshold = *c.Diff.RenameThreshold
+	}
+	for _, r := range c.Diff.Renames {
+		opt.Renames = append(opt.Renames, &schema.Rename{
+			Table: r.Table,
+			From:  r.From,
+			To:    r.To,
+		})
+	}
+	return opt
+}
+
+// SnapshotDir returns the directory of schema snapshots.
+func (c *Config) SnapshotDir() string {
+	if c.Snapshot.Dir != "" {
+		return c.Snapshot.Dir
+	}
+	return DefaultSnapshotDir
+}
+
+// AnalyzeTimeout returns the timeout of the whole analysis. 0 means no timeout.
+func (c *Config) AnalyzeTimeout() time.Duration {
+	d, _ := time.ParseDuration(c.Analyze.Timeout)
+	return d
+}
+
+// QueryTimeout returns the timeout of each catalog query. 0 means no timeout.
+func (c *Config) QueryTimeout() time.Duration {
+	d, _ := time.ParseDuration(c.Analyze.QueryTimeout)
+	return d
+}
+
 func (c *Config) NeedToGenerateERImages() bool {
 	if c.ER.Skip {
 		return false
@@ -862,6 +1322,98 @@ func (c *Config) detectShowColumnsForER(s *schema.Schema) error {
 	return nil
 }
 
+// detectDeprecatedForER hides deprecated tables and columns and their relations in ER diagrams.
+func (c *Config) detectDeprecatedForER(s *schema.Schema) {
+	if !c.ER.HideDeprecated {
+		return
+	}
+	for _, t := range s.Tables {
+		if t.IsDeprecated() {
+			t.HideForER = true
+		}
+		for _, cc := range t.Columns {
+			if cc.IsDeprecated() {
+				cc.HideForER = true
+			}
+		}
+	}
+	for _, r := range s.Relations {
+		if r.Table.HideForER || r.ParentTable.HideForER {
+			r.HideForER = true
+			continue
+		}
+		for _, cc := range r.Columns {
+			if cc.IsDeprecated() {
+				r.HideForER = true
+			}
+		}
+		for _, cc := range r.ParentColumns {
+			if cc.IsDeprecated() {
+				r.HideForER = true
+			}
+		}
+	}
+}
+
+// copyEnhancedCommentData 複製したスキーマにテーブルとカラムの拡張コメントデータを引き継ぐ（JSONによる複製では失われるため）
+func copyEnhancedCommentData(dst, src *schema.Schema) {
+	for _, t := range dst.Tables {
+		st, err := src.FindTableByName(t.Name)
+		if err != nil {
+			continue
+		}
+		t.EnhancedCommentData = st.EnhancedCommentData
+		for _, cc := range t.Columns {
+			sc, err := st.FindColumnByName(cc.Name)
+			if err != nil {
+				continue
+			}
+			cc.EnhancedCommentData = sc.EnhancedCommentData
+			cc.Inherited = sc.Inherited
+			cc.Classification = sc.Classification
+		}
+	}
+}
+
+// classifyColumns sets the classification of columns.
+// The rules of config take precedence over the metadata of comments, and the heuristics are used for the rest.
+func (c *Config) classifyColumns(s *schema.Schema) error {
+	for _, t := range s.Tables {
+		for _, cc := range t.Columns {
+			cl, err := c.classificationFromRules(t.Name, cc.Name)
+			if err != nil {
+				return err
+			}
+			if cl == nil {
+				cl, err = cc.ClassificationFromComment()
+				if err != nil {
+					return fmt.Errorf("invalid classification of %s.%s: %w", t.Name, cc.Name, err)
+				}
+			}
+			if cl == nil && c.Classification.Heuristics {
+				cl = schema.GuessClassification(cc)
+			}
+			cc.Classification = cl
+		}
+	}
+	return nil
+}
+
+func (c *Config) classificationFromRules(table, column string) (*schema.Classification, error) {
+	target := fmt.Sprintf("%s.%s", table, column)
+	for _, r := range c.Classification.Rules {
+		if !match(r.Columns, target) {
+			continue
+		}
+		l, err := schema.ParseClassificationLevel(r.Level)
+		if err != nil {
+			return nil, err
+		}
+		return &schema.Classification{Level: l, Category: r.Category, Source: schema.ClassificationSourceConfig}, nil
+	}
+	return nil, nil
+}
+
 func mergeAdditionalRelations(s *schema.Schema, relations []AdditionalRelation) (err error) {
 	defer func() {
 		err = errors.WithStack(err)
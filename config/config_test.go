@@ -8,6 +8,7 @@ import (
 	"path/filepath"
 	"reflect"
 	"testing"
+	"time"
 
 	"github.com/k1LoW/tbls/schema"
 	"github.com/tenntenn/golden"
@@ -611,6 +612,83 @@ func TestValidate(t *testing.T) {
 	}
 }
 
+func TestDiffOption(t *testing.T) {
+	half := 0.5
+	zero := 0.0
+	tests := []struct {
+		diff          Diff
+		wantDetect    bool
+		wantThreshold float64
+		wantRenames   int
+	}{
+		{Diff{}, true, schema.DefaultRenameThreshold, 0},
+		{Diff{DisableRenameDetection: true}, false, schema.DefaultRenameThreshold, 0},
+		{Diff{RenameThreshold: &half, Renames: []DiffRename{{Table: "users", From: "user_name", To: "username"}}}, true, 0.5, 1},
+		{Diff{RenameThreshold: &zero}, true, 0, 0},
+	}
+	for i, tt := range tests {
+		t.Run(fmt.Sprintf("%d", i), func(t *testing.T) {
+			c := &Config{Diff: tt.diff}
+			got := c.DiffOption()
+			if got.DetectRenames != tt.wantDetect {
+				t.Errorf("got %v\nwant %v", got.DetectRenames, tt.wantDetect)
+			}
+			if got.RenameThreshold != tt.wantThreshold {
+				t.Errorf("got %v\nwant %v", got.RenameThreshold, tt.wantThreshold)
+			}
+			if len(got.Renames) != tt.wantRenames {
+				t.Errorf("got %v\nwant %v", len(got.Renames), tt.wantRenames)
+			}
+		})
+	}
+}
+
+func TestAnalyzeTimeout(t *testing.T) {
+	tests := []struct {
+		analyze          Analyze
+		options          []Option
+		wantTimeout      time.Duration
+		wantQueryTimeout time.Duration
+		wantErr          bool
+	}{
+		{Analyze{}, nil, 0, 0, false},
+		{Analyze{Timeout: "5m", QueryTimeout: "30s"}, nil, 5 * time.Minute, 30 * time.Second, false},
+		{Analyze{Timeout: "5m"}, []Option{Timeout(time.Minute)}, time.Minute, 0, false},
+		{Analyze{Timeout: "5m"}, []Option{Timeout(0)}, 5 * time.Minute, 0, false},
+		{Analyze{QueryTimeout: "30"}, nil, 0, 0, true},
+		{Analyze{Concurrency: -1}, nil, 0, 0, true},
+	}
+	for i, tt := range tests {
+		t.Run(fmt.Sprintf("%d", i), func(t *testing.T) {
+			c, err := New()
+			if err != nil {
+				t.Fatal(err)
+			}
+			c.Analyze = tt.analyze
+			for _, opt := range tt.options {
+				if err := opt(c); err != nil {
+					t.Fatal(err)
+				}
+			}
+			if err := c.validate(); err != nil {
+				if !tt.wantErr {
+					t.Error(err)
+				}
+				return
+			}
+			if tt.wantErr {
+				t.Error("want error")
+			}
+			if got := c.AnalyzeTimeout(); got != tt.wantTimeout {
+				t.Errorf("got %v\nwant %v", got, tt.wantTimeout)
+			}
+			if got := c.QueryTimeout(); got != tt.wantQueryTimeout {
+				t.Errorf("got %v\nwant %v", got, tt.wantQueryTimeout)
+			}
+		})
+	}
+}
+
 func TestCheckVersion(t *testing.T) {
 	tests := []struct {
 		v    string
@@ -703,6 +781,134 @@ func TestDetectShowColumnsForER(t *testing.T) {
 	}
 }
 
+func TestFilterTablesByTags(t *testing.T) {
+	tests := []struct {
+		includeTags       []string
+		excludeTags       []string
+		excludeColumnTags []string
+		wantTables        int
+		wantColumns       int
+		wantRelations     int
+	}{
+		{nil, nil, nil, 5, 13, 3},
+		{[]string{"core"}, nil, nil, 2, 4, 0},
+		{nil, []string{"core"}, nil, 3, 9, 0},
+		{nil, nil, []string{"internal"}, 5, 12, 2},
+	}
+	for i, tt := range tests {
+		t.Run(fmt.Sprintf("%d.%v%v%v", i, tt.includeTags, tt.excludeTags, tt.excludeColumnTags), func(t *testing.T) {
+			c, err := New()
+			if err != nil {
+				t.Fatal(err)
+			}
+			c.IncludeTags = tt.includeTags
+			c.ExcludeTags = tt.excludeTags
+			c.ExcludeColumnTags = tt.excludeColumnTags
+			s := newTestSchemaViaJSON(t)
+			for _, name := range []string{"users", "categories"} {
+				tbl, err := s.FindTableByName(name)
+				if err != nil {
+					t.Fatal(err)
+				}
+				tbl.EnhancedCommentData = &schema.CommentData{Tags: []string{"core"}}
+			}
+			posts, err := s.FindTableByName("posts")
+			if err != nil {
+				t.Fatal(err)
+			}
+			userID, err := posts.FindColumnByName("user_id")
+			if err != nil {
+				t.Fatal(err)
+			}
+			userID.EnhancedCommentData = &schema.CommentData{Tags: []string{"internal"}}
+			if err := c.FilterTables(s); err != nil {
+				t.Fatal(err)
+			}
+			gotColumns := 0
+			for _, tbl := range s.Tables {
+				gotColumns += len(tbl.Columns)
+			}
+			if got := len(s.Tables); got != tt.wantTables {
+				t.Errorf("got %v\nwant %v", got, tt.wantTables)
+			}
+			if gotColumns != tt.wantColumns {
+				t.Errorf("got %v\nwant %v", gotColumns, tt.wantColumns)
+			}
+			if got := len(s.Relations); got != tt.wantRelations {
+				t.Errorf("got %v\nwant %v", got, tt.wantRelations)
+			}
+		})
+	}
+}
+
+func TestDetectDeprecatedForER(t *testing.T) {
+	tests := []struct {
+		hideDeprecated    bool
+		wantTableCount    int
+		wantColumnCount   int
+		wantRelationCount int
+	}{
+		{false, 5, 13, 3},
+		{true, 4, 12, 1},
+	}
+	for _, tt := range tests {
+		t.Run(fmt.Sprintf("%v", tt.hideDeprecated), func(t *testing.T) {
+			c, err := New()
+			if err != nil {
+				t.Fatal(err)
+			}
+			c.ER.HideDeprecated = tt.hideDeprecated
+			s := newTestSchemaViaJSON(t)
+			categories, err := s.FindTableByName("categories")
+			if err != nil {
+				t.Fatal(err)
+			}
+			categories.EnhancedCommentData = &schema.CommentData{Deprecated: true}
+			userOptions, err := s.FindTableByName("user_options")
+			if err != nil {
+				t.Fatal(err)
+			}
+			userID, err := userOptions.FindColumnByName("user_id")
+			if err != nil {
+				t.Fatal(err)
+			}
+			userID.EnhancedCommentData = &schema.CommentData{Deprecated: true}
+			if err := c.ModifySchema(s); err != nil {
+				t.Fatal(err)
+			}
+			var (
+				gotTableCount    int
+				gotColumnCount   int
+				gotRelationCount int
+			)
+			for _, tt := range s.Tables {
+				if !tt.HideForER {
+					gotTableCount++
+				}
+				for _, cc := range tt.Columns {
+					if !cc.HideForER {
+						gotColumnCount++
+					}
+				}
+			}
+			for _, r := range s.Relations {
+				if !r.HideForER {
+					gotRelationCount++
+				}
+			}
+			if gotTableCount != tt.wantTableCount {
+				t.Errorf("got %v\nwant %v", gotTableCount, tt.wantTableCount)
+			}
+			if gotColumnCount != tt.wantColumnCount {
+				t.Errorf("got %v\nwant %v", gotColumnCount, tt.wantColumnCount)
+			}
+			if gotRelationCount != tt.wantRelationCount {
+				t.Errorf("got %v\nwant %v", gotRelationCount, tt.wantRelationCount)
+			}
+		})
+	}
+}
+
 func TestLogicalNameConfigStructure(t *testing.T) {
 	// Test basic structure and default values only (scope of #2)
 	config, err := New()
@@ -834,3 +1040,185 @@ func newTestSchemaViaJSON(t *testing.T) *schema.Schema {
 	}
 	return s
 }
+
+func TestGlossary(t *testing.T) {
+	dir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(dir, "glossary.yml"), []byte("terms:\n  - name: 会員\n    synonyms: [メンバー]\n"), 0600); err != nil {
+		t.Fatal(err)
+	}
+	tests := []struct {
+		config    string
+		wantTerms []string
+		wantErr   bool
+	}{
+		{"glossary:\n  terms:\n    - name: SKU\n", []string{"SKU"}, false},
+		{"glossary:\n  path: glossary.yml\n  terms:\n    - name: SKU\n", []string{"SKU", "会員"}, false},
+		{"glossary:\n  path: notfound.yml\n", nil, true},
+		{"glossary:\n  terms:\n    - definition: no name\n", nil, true},
+		{"glossary:\n  path: glossary.yml\n  terms:\n    - name: Member\n      synonyms: [メンバー]\n", nil, true},
+	}
+	for i, tt := range tests {
+		t.Run(fmt.Sprintf("%d", i), func(t *testing.T) {
+			p := filepath.Join(dir, fmt.Sprintf("tbls_%d.yml", i))
+			if err := os.WriteFile(p, []byte(tt.config), 0600); err != nil {
+				t.Fatal(err)
+			}
+			c, err := New()
+			if err != nil {
+				t.Fatal(err)
+			}
+			if err := c.Load(p); err != nil {
+				if !tt.wantErr {
+					t.Error(err)
+				}
+				return
+			}
+			if tt.wantErr {
+				t.Fatal("want error")
+			}
+			got := []string{}
+			for _, term := range c.Glossary.Terms {
+				got = append(got, term.Name)
+			}
+			if !reflect.DeepEqual(got, tt.wantTerms) {
+				t.Errorf("got %v\nwant %v", got, tt.wantTerms)
+			}
+		})
+	}
+}
+
+func TestClassifyColumns(t *testing.T) {
+	newSchema := func() *schema.Schema {
+		return &schema.Schema{
+			Tables: []*schema.Table{
+				{
+					Name: "users",
+					Columns: []*schema.Column{
+						{Name: "email", Type: "text", Comment: `{"name": "メールアドレス", "classification": "confidential:contact"}`},
+						{Name: "phone", Type: "text"},
+						{Name: "plan", Type: "text", Comment: "@classification internal"},
+						{Name: "note", Type: "text"},
+					},
+				},
+			},
+		}
+	}
+	tests := []struct {
+		classification Classification
+		want           map[string]string
+	}{
+		{
+			Classification{},
+			map[string]string{"email": "confidential:contact comment", "plan": "internal comment"},
+		},
+		{
+			Classification{Heuristics: true},
+			map[string]string{"email": "confidential:contact comment", "phone": "pii:phone heuristic", "plan": "internal comment"},
+		},
+		{
+			Classification{Heuristics: true, Rules: []ClassificationRule{{Level: "pii", Category: "email", Columns: []string{"users.email"}}, {Level: "public", Columns: []string{"*.note"}}}},
+			map[string]string{"email": "pii:email config", "phone": "pii:phone heuristic", "plan": "internal comment", "note": "public config"},
+		},
+	}
+	for i, tt := range tests {
+		t.Run(fmt.Sprintf("%d", i), func(t *testing.T) {
+			c, err := New()
+			if err != nil {
+				t.Fatal(err)
+			}
+			c.EnhancedComment.Enabled = true
+			c.EnhancedComment.Parser.EnableJSON = true
+			c.Classification = tt.classification
+			s := newSchema()
+			if err := c.ModifySchema(s); err != nil {
+				t.Fatal(err)
+			}
+			got := map[string]string{}
+			for _, cc := range s.Tables[0].Columns {
+				if cc.Classification != nil {
+					got[cc.Name] = cc.Classification.String() + " " + cc.Classification.Source
+				}
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("got %v\nwant %v", got, tt.want)
+			}
+		})
+	}
+
+	c, err := New()
+	if err != nil {
+		t.Fatal(err)
+	}
+	c.EnhancedComment.Enabled = true
+	s := newSchema()
+	s.Tables[0].Columns[3].Comment = "@classification secret"
+	if err := c.ModifySchema(s); err == nil {
+		t.Error("want error")
+	}
+}
+
+func TestAnnotationComments(t *testing.T) {
+	newSchema := func() *schema.Schema {
+		return &schema.Schema{
+			Tables: []*schema.Table{
+				{
+					Name: "inquiries",
+					Columns: []*schema.Column{
+						{Name: "staff", Type: "text", Comment: "担当者|問い合わせは @support まで"},
+						{Name: "email", Type: "text", Comment: "メール @pii"},
+						{Name: "code", Type: "text", Comment: `{"name": "コード"}`},
+					},
+				},
+				{
+					Name: "logs",
+					Columns: []*schema.Column{
+						{Name: "id", Type: "bigint", Comment: "ID"},
+					},
+				},
+			},
+		}
+	}
+
+	t.Run("enhanced comments disabled", func(t *testing.T) {
+		c, err := New()
+		if err != nil {
+			t.Fatal(err)
+		}
+		s := newSchema()
+		if err := c.ModifySchema(s); err != nil {
+			t.Fatal(err)
+		}
+		staff := s.Tables[0].Columns[0]
+		if staff.LogicalName != "担当者" || staff.Comment != "問い合わせは @support まで" {
+			t.Errorf("got %q %q", staff.LogicalName, staff.Comment)
+		}
+		// structured comments are split in the same way as before enhanced comments were introduced
+		code := s.Tables[0].Columns[2]
+		if code.LogicalName != `{"name": "コード"}` || code.Comment != "" {
+			t.Errorf("got %q %q", code.LogicalName, code.Comment)
+		}
+	})
+
+	t.Run("enhanced comments enabled", func(t *testing.T) {
+		c, err := New()
+		if err != nil {
+			t.Fatal(err)
+		}
+		c.EnhancedComment.Enabled = true
+		c.IncludeTags = []string{"pii"}
+		s := newSchema()
+		if err := c.ModifySchema(s); err != nil {
+			t.Fatal(err)
+		}
+		if len(s.Tables) != 1 || s.Tables[0].Name != "inquiries" {
+			t.Fatalf("got %d tables", len(s.Tables))
+		}
+		email, err := s.Tables[0].FindColumnByName("email")
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got := email.GetTags(); !reflect.DeepEqual(got, []string{"pii"}) {
+			t.Errorf("got %v", got)
+		}
+	})
+}
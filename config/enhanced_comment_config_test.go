@@ -1,9 +1,14 @@
 package config
 
 import (
+	"fmt"
+	"strings"
 	"testing"
 
 	"github.com/goccy/go-yaml"
+	"github.com/google/go-cmp/cmp"
+	"github.com/k1LoW/errors"
+	"github.com/k1LoW/tbls/schema"
 )
 
 func TestDefaultEnhancedCommentConfig(t *testing.T) {
@@ -65,6 +70,7 @@ enhancedComment:
     strictMode: true
     processingTimeout: 2000
     enableBatchProcessing: false
+    maxWorkers: 4
     objectTypes: ["table", "column"]
 `
 
@@ -123,6 +129,10 @@ enhancedComment:
 		t.Errorf("expected ProcessingTimeout 2000, got %d", config.EnhancedComment.Processing.ProcessingTimeout)
 	}
 
+	if config.EnhancedComment.Processing.MaxWorkers != 4 {
+		t.Errorf("expected MaxWorkers 4, got %d", config.EnhancedComment.Processing.MaxWorkers)
+	}
+
 	expectedObjectTypes := []string{"table", "column"}
 	if len(config.EnhancedComment.Processing.ObjectTypes) != len(expectedObjectTypes) {
 		t.Errorf("expected %d ObjectTypes, got %d", len(expectedObjectTypes), len(config.EnhancedComment.Processing.ObjectTypes))
@@ -429,4 +439,248 @@ func TestEnhancedCommentConfigEmptyObjectTypes(t *testing.T) {
 	if !config.IsEnhancedCommentObjectTypeEnabled("arbitrary_type") {
 		t.Error("expected all object types to be enabled when ObjectTypes is empty")
 	}
-}
\ No newline at end of file
+}
+func TestEnhancedCommentMetadataSchema(t *testing.T) {
+	yamlContent := `
+enhancedComment:
+  enabled: true
+  parser:
+    enableJSON: true
+  metadataSchema:
+    table:
+      - key: owner
+        required: true
+    column:
+      - key: pii
+        type: bool
+      - key: level
+        enum: ["public", "internal", "secret"]
+`
+	config := &Config{}
+	if err := yaml.Unmarshal([]byte(yamlContent), config); err != nil {
+		t.Fatalf("failed to unmarshal YAML: %v", err)
+	}
+	if err := config.setDefault(); err != nil {
+		t.Fatalf("failed to set defaults: %v", err)
+	}
+
+	s := &schema.Schema{
+		Tables: []*schema.Table{
+			{
+				Name:    "users",
+				Type:    "BASE TABLE",
+				Comment: `{"name": "ユーザー", "owner": "auth-team"}`,
+				Columns: []*schema.Column{
+					{Name: "id", Comment: `{"name": "ID", "pii": false}`},
+					{Name: "email", Comment: `{"name": "メール", "pii": "maybe", "level": "top"}`},
+				},
+			},
+			{
+				Name:    "logs",
+				Type:    "BASE TABLE",
+				Comment: `{"name": "ログ"}`,
+			},
+			{
+				Name:    "audits",
+				Type:    "BASE TABLE",
+				Comment: "監査ログ",
+			},
+			{
+				Name: "sessions",
+				Type: "BASE TABLE",
+			},
+		},
+	}
+	// violations are warnings outside strict mode
+	if err := config.processEnhancedComments(s); err != nil {
+		t.Fatal(err)
+	}
+	if got := len(config.Warnings()); got != 4 {
+		t.Errorf("got %d warnings, want 4: %v", got, config.Warnings())
+	}
+	for _, w := range config.Warnings() {
+		if !errors.Is(w, schema.ErrMetadataSchemaViolation) {
+			t.Errorf("got %v, want ErrMetadataSchemaViolation", w)
+		}
+	}
+
+	config.EnhancedComment.Processing.StrictMode = true
+	err := config.processEnhancedComments(s)
+	if !errors.Is(err, schema.ErrMetadataSchemaViolation) {
+		t.Fatalf("got %v, want ErrMetadataSchemaViolation", err)
+	}
+	for _, want := range []string{
+		`column users.email: metadata schema violation: key 'pii' must be bool (value: "maybe"), key 'level' must be one of [public, internal, secret] (value: "top")`,
+		`table logs: metadata schema violation: key 'owner' is required`,
+		// tables without structured comments are validated too
+		`table audits: metadata schema violation: key 'owner' is required`,
+		`table sessions: metadata schema violation: key 'owner' is required`,
+	} {
+		if !strings.Contains(err.Error(), want) {
+			t.Errorf("got %q, want to contain %q", err.Error(), want)
+		}
+	}
+	if strings.Contains(err.Error(), "users.id") {
+		t.Errorf("got %q, users.id should be valid", err.Error())
+	}
+
+	config.EnhancedComment.MetadataSchema["unknown"] = []EnhancedCommentMetadataField{{Key: "owner"}}
+	if _, err := config.EnhancedCommentMetadataSchema(); err == nil {
+		t.Error("want error for unsupported object type")
+	}
+}
+
+func TestEnhancedCommentLang(t *testing.T) {
+	tests := []struct {
+		lang            string
+		wantTable       string
+		wantColumn      string
+		wantDescription string
+	}{
+		{"ja", "ユーザー", "メールアドレス", "ログインに使用"},
+		{"en-US", "User", "Email", "Used for login"},
+		{"", "User", "Email", "Used for login"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.lang, func(t *testing.T) {
+			config := &Config{
+				EnhancedComment: EnhancedCommentConfig{
+					Enabled: true,
+					Parser:  EnhancedCommentParserConfig{EnableJSON: true, EnableYAML: true},
+				},
+				Comments: []AdditionalComment{
+					{
+						Table: "users",
+						ColumnComments: map[string]string{
+							"email": "name:\n  ja: メールアドレス\n  en: Email\ndescription:\n  ja: ログインに使用\n  en: Used for login",
+						},
+					},
+				},
+			}
+			config.Format.Lang = tt.lang
+			if err := config.setDefault(); err != nil {
+				t.Fatal(err)
+			}
+			s := &schema.Schema{
+				Tables: []*schema.Table{
+					{
+						Name:    "users",
+						Type:    "BASE TABLE",
+						Comment: `{"name": {"ja": "ユーザー", "en": "User"}}`,
+						Columns: []*schema.Column{{Name: "email"}},
+					},
+				},
+			}
+			if err := config.MergeAdditionalData(s); err != nil {
+				t.Fatal(err)
+			}
+			if err := config.processEnhancedComments(s); err != nil {
+				t.Fatal(err)
+			}
+			if got := s.Tables[0].LogicalName; got != tt.wantTable {
+				t.Errorf("got %q, want %q", got, tt.wantTable)
+			}
+			column := s.Tables[0].Columns[0]
+			if got := column.LogicalName; got != tt.wantColumn {
+				t.Errorf("got %q, want %q", got, tt.wantColumn)
+			}
+			if got := column.EnhancedCommentData.Description; got != tt.wantDescription {
+				t.Errorf("got %q, want %q", got, tt.wantDescription)
+			}
+		})
+	}
+}
+
+func TestEnhancedCommentCheckComments(t *testing.T) {
+	yamlContent := `
+enhancedComment:
+  enabled: true
+  parser:
+    enableJSON: true
+  processing:
+    objectTypes: ["table"]
+  metadataSchema:
+    table:
+      - key: owner
+        required: true
+`
+	config := &Config{}
+	if err := yaml.Unmarshal([]byte(yamlContent), config); err != nil {
+		t.Fatalf("failed to unmarshal YAML: %v", err)
+	}
+	if err := config.setDefault(); err != nil {
+		t.Fatalf("failed to set defaults: %v", err)
+	}
+
+	s := &schema.Schema{
+		Tables: []*schema.Table{
+			{
+				Name:    "users",
+				Type:    "BASE TABLE",
+				Comment: `{"name": "ユーザー"}`,
+				Columns: []*schema.Column{
+					{Name: "email", Comment: `{"name": "メール"`},
+				},
+			},
+			{
+				Name: "sessions",
+				Type: "BASE TABLE",
+			},
+		},
+	}
+	reporter := schema.NewDefaultErrorReporter()
+	if err := config.CheckComments(s, reporter); err != nil {
+		t.Fatal(err)
+	}
+	got := []string{}
+	for _, e := range reporter.Errors() {
+		got = append(got, e.ObjectName+" "+e.ErrorCode)
+	}
+	// columns are not checked because only table comments are processed
+	want := []string{"users " + schema.ErrorCodeMetadataSchemaViolation, "sessions " + schema.ErrorCodeMetadataSchemaViolation}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Error(diff)
+	}
+	if s.Tables[0].Comment != `{"name": "ユーザー"}` {
+		t.Errorf("check should not modify the comment: %q", s.Tables[0].Comment)
+	}
+}
+
+func TestEnhancedCommentMigrationScript(t *testing.T) {
+	comments := []string{
+		`{"name": "ユーザーID", "description": "主キー"}`,
+		"メール|User email @pii @owner identity-team",
+		"@deprecated 旧ステータス",
+		"作成日時|レコード作成日時",
+	}
+	config := &Config{
+		EnhancedComment: EnhancedCommentConfig{
+			Enabled: true,
+			Parser:  EnhancedCommentParserConfig{EnableJSON: true, EnableYAML: true, FallbackToLegacy: true},
+		},
+	}
+	if err := config.setDefault(); err != nil {
+		t.Fatal(err)
+	}
+	table := &schema.Table{Name: "users", Type: "BASE TABLE"}
+	for i, comment := range comments {
+		c := &schema.Column{Name: fmt.Sprintf("c%d", i), Comment: comment}
+		// the driver splits the logical name before ModifySchema
+		c.SetLogicalNameFromComment(config.LogicalNameDelimiter(), config.LogicalNameFallbackToName())
+		table.Columns = append(table.Columns, c)
+	}
+	s := &schema.Schema{Tables: []*schema.Table{table}}
+	if err := config.ModifySchema(s); err != nil {
+		t.Fatal(err)
+	}
+	got, err := schema.CommentMigrationScript(s, "postgres", "", config.LogicalNameDelimiter(), config.LogicalNameFallbackToName())
+	if err != nil {
+		t.Fatal(err)
+	}
+	for i, comment := range comments {
+		want := fmt.Sprintf(`COMMENT ON COLUMN "users"."c%d" IS '%s';`, i, comment)
+		if !strings.Contains(got, want) {
+			t.Errorf("want %s in\n%s", want, got)
+		}
+	}
+}
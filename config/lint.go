@@ -11,21 +11,31 @@ import (
 
 // Lint is the struct for lint config.
 type Lint struct {
-	RequireTableComment      RequireTableComment      `yaml:"requireTableComment"`
-	RequireColumnComment     RequireColumnComment     `yaml:"requireColumnComment"`
-	RequireIndexComment      RequireIndexComment      `yaml:"requireIndexComment"`
-	RequireConstraintComment RequireConstraintComment `yaml:"requireConstraintComment"`
-	RequireTriggerComment    RequireTriggerComment    `yaml:"requireTriggerComment"`
-	RequireTableLabels       RequireTableLabels       `yaml:"requireTableLabels"`
-	UnrelatedTable           UnrelatedTable           `yaml:"unrelatedTable"`
-	ColumnCount              ColumnCount              `yaml:"columnCount"`
-	RequireColumns           RequireColumns           `yaml:"requireColumns"`
-	DuplicateRelations       DuplicateRelations       `yaml:"duplicateRelations"`
-	RequireForeignKeyIndex   RequireForeignKeyIndex   `yaml:"requireForeignKeyIndex"`
-	LabelStyleBigQuery       LabelStyleBigQuery       `yaml:"labelStyleBigQuery"`
-	RequireViewpoints        RequireViewpoints        `yaml:"requireViewpoints"`
+	RequireTableComment           RequireTableComment           `yaml:"requireTableComment"`
+	RequireColumnComment          RequireColumnComment          `yaml:"requireColumnComment"`
+	RequireIndexComment           RequireIndexComment           `yaml:"requireIndexComment"`
+	RequireConstraintComment      RequireConstraintComment      `yaml:"requireConstraintComment"`
+	RequireTriggerComment         RequireTriggerComment         `yaml:"requireTriggerComment"`
+	RequireTableLabels            RequireTableLabels            `yaml:"requireTableLabels"`
+	UnrelatedTable                UnrelatedTable                `yaml:"unrelatedTable"`
+	ColumnCount                   ColumnCount                   `yaml:"columnCount"`
+	RequireColumns                RequireColumns                `yaml:"requireColumns"`
+	DuplicateRelations            DuplicateRelations            `yaml:"duplicateRelations"`
+	RequireForeignKeyIndex        RequireForeignKeyIndex        `yaml:"requireForeignKeyIndex"`
+	LabelStyleBigQuery            LabelStyleBigQuery            `yaml:"labelStyleBigQuery"`
+	RequireViewpoints             RequireViewpoints             `yaml:"requireViewpoints"`
+	RequireLogicalName            RequireLogicalName            `yaml:"requireLogicalName"`
+	RequireTags                   RequireTags                   `yaml:"requireTags"`
+	RequireMetadataKeys           RequireMetadataKeys           `yaml:"requireMetadataKeys"`
+	AllowedTagValues              AllowedTagValues              `yaml:"allowedTagValues"`
+	NoForeignKeyToDeprecated      NoForeignKeyToDeprecated      `yaml:"noForeignKeyToDeprecated"`
+	DeprecatedRequiresReplacement DeprecatedRequiresReplacement `yaml:"deprecatedRequiresReplacement"`
+	RequirePIIClassification      RequirePIIClassification      `yaml:"requirePIIClassification"`
 }
 
+// DefaultDeprecatedReplacementKey is the default metadata key of deprecatedRequiresReplacement.
+const DefaultDeprecatedReplacementKey = "replacement"
+
 // RuleWarn is struct of Rule error.
 type RuleWarn struct {
 	Target  string
@@ -710,3 +720,339 @@ T:
 
 	return warns
 }
+
+// RequireLogicalName checks table and column logical name.
+type RequireLogicalName struct {
+	Enabled       bool     `yaml:"enabled"`
+	Exclude       []string `yaml:"exclude"`
+	ExcludeTables []string `yaml:"excludeTables"`
+}
+
+// IsEnabled return Rule is enabled or not.
+func (r RequireLogicalName) IsEnabled() bool {
+	return r.Enabled
+}
+
+// Check table and column logical name.
+func (r RequireLogicalName) Check(s *schema.Schema, exclude []string) []RuleWarn {
+	warns := []RuleWarn{}
+	if !r.IsEnabled() {
+		return warns
+	}
+	msg := "logical name required."
+
+	nt := s.NormalizeTableNames(r.ExcludeTables)
+	for _, t := range s.Tables {
+		if match(exclude, t.Name) {
+			continue
+		}
+		if match(nt, t.Name) {
+			continue
+		}
+		if t.LogicalName == "" && !match(r.Exclude, t.Name) {
+			warns = append(warns, RuleWarn{
+				Target:  t.Name,
+				Message: msg,
+			})
+		}
+		for _, c := range t.Columns {
+			target := fmt.Sprintf("%s.%s", t.Name, c.Name)
+			if match(r.Exclude, c.Name) || match(r.Exclude, target) {
+				continue
+			}
+			if c.LogicalName == "" {
+				warns = append(warns, RuleWarn{
+					Target:  target,
+					Message: msg,
+				})
+			}
+		}
+	}
+	return warns
+}
+
+// RequireTags checks table tags of enhanced comments.
+type RequireTags struct {
+	Enabled bool     `yaml:"enabled"`
+	Exclude []string `yaml:"exclude"`
+}
+
+// IsEnabled return Rule is enabled or not.
+func (r RequireTags) IsEnabled() bool {
+	return r.Enabled
+}
+
+// Check table tags.
+func (r RequireTags) Check(s *schema.Schema, exclude []string) []RuleWarn {
+	warns := []RuleWarn{}
+	if !r.IsEnabled() {
+		return warns
+	}
+	msg := "table tags required."
+
+	nt := s.NormalizeTableNames(r.Exclude)
+	for _, t := range s.Tables {
+		if match(exclude, t.Name) {
+			continue
+		}
+		if match(nt, t.Name) {
+			continue
+		}
+		if len(t.GetTags()) == 0 {
+			warns = append(warns, RuleWarn{
+				Target:  t.Name,
+				Message: msg,
+			})
+		}
+	}
+	return warns
+}
+
+// RequireMetadataKeys checks if the table has specified metadata keys in enhanced comments.
+type RequireMetadataKeys struct {
+	Enabled bool     `yaml:"enabled"`
+	Keys    []string `yaml:"keys"`
+	Exclude []string `yaml:"exclude"`
+}
+
+// IsEnabled return Rule is enabled or not.
+func (r RequireMetadataKeys) IsEnabled() bool {
+	return r.Enabled
+}
+
+// Check table metadata keys.
+func (r RequireMetadataKeys) Check(s *schema.Schema, exclude []string) []RuleWarn {
+	warns := []RuleWarn{}
+	if !r.IsEnabled() {
+		return warns
+	}
+	msgFmt := "metadata key '%s' required."
+
+	nt := s.NormalizeTableNames(r.Exclude)
+	for _, t := range s.Tables {
+		if match(exclude, t.Name) {
+			continue
+		}
+		if match(nt, t.Name) {
+			continue
+		}
+		metadata := t.EnhancedCommentData.UserMetadata()
+		for _, k := range r.Keys {
+			if _, ok := metadata[k]; !ok {
+				warns = append(warns, RuleWarn{
+					Target:  t.Name,
+					Message: fmt.Sprintf(msgFmt, k),
+				})
+			}
+		}
+	}
+	return warns
+}
+
+// AllowedTagValues checks if table and column tags are in the allowed values.
+type AllowedTagValues struct {
+	Enabled bool     `yaml:"enabled"`
+	Values  []string `yaml:"values"`
+	Exclude []string `yaml:"exclude"`
+}
+
+// IsEnabled return Rule is enabled or not.
+func (r AllowedTagValues) IsEnabled() bool {
+	return r.Enabled
+}
+
+// Check table and column tags.
+func (r AllowedTagValues) Check(s *schema.Schema, exclude []string) []RuleWarn {
+	warns := []RuleWarn{}
+	if !r.IsEnabled() {
+		return warns
+	}
+	msgFmt := "tag '%s' is not allowed. [%s]"
+	allowed := strings.Join(r.Values, ", ")
+
+	nt := s.NormalizeTableNames(r.Exclude)
+	for _, t := range s.Tables {
+		if match(exclude, t.Name) {
+			continue
+		}
+		if match(nt, t.Name) {
+			continue
+		}
+		for _, tag := range t.GetTags() {
+			if !match(r.Values, tag) {
+				warns = append(warns, RuleWarn{
+					Target:  t.Name,
+					Message: fmt.Sprintf(msgFmt, tag, allowed),
+				})
+			}
+		}
+		for _, c := range t.Columns {
+			for _, tag := range c.GetTags() {
+				if !match(r.Values, tag) {
+					warns = append(warns, RuleWarn{
+						Target:  fmt.Sprintf("%s.%s", t.Name, c.Name),
+						Message: fmt.Sprintf(msgFmt, tag, allowed),
+					})
+				}
+			}
+		}
+	}
+	return warns
+}
+
+// NoForeignKeyToDeprecated checks relations to deprecated tables and columns.
+type NoForeignKeyToDeprecated struct {
+	Enabled bool     `yaml:"enabled"`
+	Exclude []string `yaml:"exclude"`
+}
+
+// IsEnabled return Rule is enabled or not.
+func (r NoForeignKeyToDeprecated) IsEnabled() bool {
+	return r.Enabled
+}
+
+// Check relations to deprecated tables and columns.
+func (r NoForeignKeyToDeprecated) Check(s *schema.Schema, exclude []string) []RuleWarn {
+	warns := []RuleWarn{}
+	if !r.IsEnabled() {
+		return warns
+	}
+	msgFmt := "foreign key references deprecated %s. [%s -> %s]"
+
+	nt := s.NormalizeTableNames(r.Exclude)
+	for _, rl := range s.Relations {
+		if rl.Virtual {
+			continue
+		}
+		if match(exclude, rl.Table.Name) || match(exclude, rl.ParentTable.Name) {
+			continue
+		}
+		if match(nt, rl.Table.Name) {
+			continue
+		}
+		// relations from deprecated tables are not checked
+		if rl.Table.IsDeprecated() {
+			continue
+		}
+		if rl.ParentTable.IsDeprecated() {
+			warns = append(warns, RuleWarn{
+				Target:  rl.Table.Name,
+				Message: fmt.Sprintf(msgFmt, "table", rl.Table.Name, rl.ParentTable.Name),
+			})
+			continue
+		}
+		for _, c := range rl.ParentColumns {
+			if c.IsDeprecated() {
+				warns = append(warns, RuleWarn{
+					Target:  rl.Table.Name,
+					Message: fmt.Sprintf(msgFmt, "column", rl.Table.Name, fmt.Sprintf("%s.%s", rl.ParentTable.Name, c.Name)),
+				})
+			}
+		}
+	}
+	return warns
+}
+
+// DeprecatedRequiresReplacement checks if deprecated tables and columns declare their replacement in metadata.
+type DeprecatedRequiresReplacement struct {
+	Enabled bool     `yaml:"enabled"`
+	Key     string   `yaml:"key"`
+	Exclude []string `yaml:"exclude"`
+}
+
+// IsEnabled return Rule is enabled or not.
+func (r DeprecatedRequiresReplacement) IsEnabled() bool {
+	return r.Enabled
+}
+
+// Check replacement of deprecated tables and columns.
+func (r DeprecatedRequiresReplacement) Check(s *schema.Schema, exclude []string) []RuleWarn {
+	warns := []RuleWarn{}
+	if !r.IsEnabled() {
+		return warns
+	}
+	key := r.Key
+	if key == "" {
+		key = DefaultDeprecatedReplacementKey
+	}
+	msgFmt := "deprecated %s requires metadata key '%s'."
+
+	nt := s.NormalizeTableNames(r.Exclude)
+	for _, t := range s.Tables {
+		if match(exclude, t.Name) {
+			continue
+		}
+		if match(nt, t.Name) {
+			continue
+		}
+		if t.IsDeprecated() {
+			if _, ok := t.EnhancedCommentData.UserMetadata()[key]; !ok {
+				warns = append(warns, RuleWarn{
+					Target:  t.Name,
+					Message: fmt.Sprintf(msgFmt, "table", key),
+				})
+			}
+		}
+		for _, c := range t.Columns {
+			target := fmt.Sprintf("%s.%s", t.Name, c.Name)
+			if match(r.Exclude, target) {
+				continue
+			}
+			if !c.IsDeprecated() {
+				continue
+			}
+			if _, ok := c.EnhancedCommentData.UserMetadata()[key]; !ok {
+				warns = append(warns, RuleWarn{
+					Target:  target,
+					Message: fmt.Sprintf(msgFmt, "column", key),
+				})
+			}
+		}
+	}
+	return warns
+}
+
+// RequirePIIClassification checks if columns that look like PII by their names and types are classified.
+type RequirePIIClassification struct {
+	Enabled bool     `yaml:"enabled"`
+	Exclude []string `yaml:"exclude"`
+}
+
+// IsEnabled return Rule is enabled or not.
+func (r RequirePIIClassification) IsEnabled() bool {
+	return r.Enabled
+}
+
+// Check classification of likely PII columns.
+func (r RequirePIIClassification) Check(s *schema.Schema, exclude []string) []RuleWarn {
+	warns := []RuleWarn{}
+	if !r.IsEnabled() {
+		return warns
+	}
+	msgFmt := "column looks like '%s' and requires classification."
+
+	for _, t := range s.Tables {
+		if match(exclude, t.Name) {
+			continue
+		}
+		for _, c := range t.Columns {
+			target := fmt.Sprintf("%s.%s", t.Name, c.Name)
+			if match(r.Exclude, c.Name) || match(r.Exclude, target) {
+				continue
+			}
+			// classification by heuristics is not a classification by the owner
+			if c.Classification != nil && c.Classification.Source != schema.ClassificationSourceHeuristic {
+				continue
+			}
+			guessed := schema.GuessClassification(c)
+			if guessed == nil {
+				continue
+			}
+			warns = append(warns, RuleWarn{
+				Target:  target,
+				Message: fmt.Sprintf(msgFmt, guessed),
+			})
+		}
+	}
+	return warns
+}
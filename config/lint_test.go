@@ -717,3 +717,229 @@ func TestRequireViewpoints(t *testing.T) {
 		}
 	}
 }
+
+func TestRequireLogicalName(t *testing.T) {
+	tests := []struct {
+		enabled       bool
+		lintExclude   []string
+		exclude       []string
+		excludeTables []string
+		want          int
+	}{
+		{true, []string{}, []string{}, []string{}, 9},
+		{false, []string{}, []string{}, []string{}, 0},
+		{true, []string{"table_c"}, []string{}, []string{}, 4},
+		{true, []string{}, []string{}, []string{"table_c"}, 4},
+		{true, []string{}, []string{"table_a", "column_a1", "table_b.column_b2"}, []string{}, 6},
+	}
+
+	for i, tt := range tests {
+		r := RequireLogicalName{
+			Enabled:       tt.enabled,
+			Exclude:       tt.exclude,
+			ExcludeTables: tt.excludeTables,
+		}
+		s := newTestSchema(t)
+		s.Tables[1].LogicalName = "テーブルB"
+		s.Tables[1].Columns[0].LogicalName = "カラムB1"
+		warns := r.Check(s, tt.lintExclude)
+		if len(warns) != tt.want {
+			t.Errorf("TestRequireLogicalName(%d): got %v\nwant %v", i, len(warns), tt.want)
+		}
+	}
+}
+
+func TestRequireTags(t *testing.T) {
+	tests := []struct {
+		enabled     bool
+		lintExclude []string
+		exclude     []string
+		want        int
+	}{
+		{true, []string{}, []string{}, 2},
+		{false, []string{}, []string{}, 0},
+		{true, []string{"table_b"}, []string{}, 1},
+		{true, []string{}, []string{"table_*"}, 0},
+	}
+
+	for i, tt := range tests {
+		r := RequireTags{
+			Enabled: tt.enabled,
+			Exclude: tt.exclude,
+		}
+		s := newTestSchema(t)
+		s.Tables[0].EnhancedCommentData = &schema.CommentData{Tags: []string{"core"}}
+		warns := r.Check(s, tt.lintExclude)
+		if len(warns) != tt.want {
+			t.Errorf("TestRequireTags(%d): got %v\nwant %v", i, len(warns), tt.want)
+		}
+	}
+}
+
+func TestRequireMetadataKeys(t *testing.T) {
+	tests := []struct {
+		enabled     bool
+		keys        []string
+		lintExclude []string
+		exclude     []string
+		want        int
+	}{
+		{true, []string{"owner"}, []string{}, []string{}, 2},
+		{false, []string{"owner"}, []string{}, []string{}, 0},
+		{true, []string{"owner", "since"}, []string{}, []string{}, 5},
+		{true, []string{"owner"}, []string{"table_b"}, []string{}, 1},
+		{true, []string{"owner"}, []string{}, []string{"table_c"}, 1},
+		{true, []string{"object_type"}, []string{}, []string{}, 3},
+	}
+
+	for i, tt := range tests {
+		r := RequireMetadataKeys{
+			Enabled: tt.enabled,
+			Keys:    tt.keys,
+			Exclude: tt.exclude,
+		}
+		s := newTestSchema(t)
+		s.Tables[0].EnhancedCommentData = &schema.CommentData{Metadata: map[string]string{"owner": "team-a", schema.MetadataKeyObjectType: "table"}}
+		warns := r.Check(s, tt.lintExclude)
+		if len(warns) != tt.want {
+			t.Errorf("TestRequireMetadataKeys(%d): got %v\nwant %v", i, len(warns), tt.want)
+		}
+	}
+}
+
+func TestAllowedTagValues(t *testing.T) {
+	tests := []struct {
+		enabled     bool
+		values      []string
+		lintExclude []string
+		exclude     []string
+		want        int
+	}{
+		{true, []string{"core", "pii"}, []string{}, []string{}, 1},
+		{false, []string{"core"}, []string{}, []string{}, 0},
+		{true, []string{"core", "pii*"}, []string{}, []string{}, 0},
+		{true, []string{"core"}, []string{}, []string{}, 2},
+		{true, []string{"core"}, []string{"table_a"}, []string{}, 0},
+		{true, []string{"core"}, []string{}, []string{"table_a"}, 0},
+	}
+
+	for i, tt := range tests {
+		r := AllowedTagValues{
+			Enabled: tt.enabled,
+			Values:  tt.values,
+			Exclude: tt.exclude,
+		}
+		s := newTestSchema(t)
+		s.Tables[0].EnhancedCommentData = &schema.CommentData{Tags: []string{"core"}}
+		s.Tables[0].Columns[0].EnhancedCommentData = &schema.CommentData{Tags: []string{"pii", "pii:email"}}
+		warns := r.Check(s, tt.lintExclude)
+		if len(warns) != tt.want {
+			t.Errorf("TestAllowedTagValues(%d): got %v\nwant %v", i, len(warns), tt.want)
+		}
+	}
+}
+
+func TestNoForeignKeyToDeprecated(t *testing.T) {
+	tests := []struct {
+		enabled          bool
+		deprecatedTable  string
+		deprecatedColumn bool
+		lintExclude      []string
+		exclude          []string
+		want             int
+	}{
+		{true, "table_b", false, []string{}, []string{}, 1},
+		{false, "table_b", false, []string{}, []string{}, 0},
+		{true, "", true, []string{}, []string{}, 1},
+		{true, "", false, []string{}, []string{}, 0},
+		{true, "table_a", true, []string{}, []string{}, 0},
+		{true, "table_b", false, []string{"table_a"}, []string{}, 0},
+		{true, "table_b", false, []string{}, []string{"table_a"}, 0},
+	}
+
+	for i, tt := range tests {
+		r := NoForeignKeyToDeprecated{
+			Enabled: tt.enabled,
+			Exclude: tt.exclude,
+		}
+		s := newTestSchema(t)
+		for _, tbl := range s.Tables {
+			if tbl.Name == tt.deprecatedTable {
+				tbl.EnhancedCommentData = &schema.CommentData{Deprecated: true}
+			}
+		}
+		if tt.deprecatedColumn {
+			s.Tables[1].Columns[0].EnhancedCommentData = &schema.CommentData{Deprecated: true}
+		}
+		warns := r.Check(s, tt.lintExclude)
+		if len(warns) != tt.want {
+			t.Errorf("TestNoForeignKeyToDeprecated(%d): got %v\nwant %v", i, len(warns), tt.want)
+		}
+	}
+}
+
+func TestDeprecatedRequiresReplacement(t *testing.T) {
+	tests := []struct {
+		enabled     bool
+		key         string
+		lintExclude []string
+		exclude     []string
+		want        int
+	}{
+		{true, "", []string{}, []string{}, 1},
+		{false, "", []string{}, []string{}, 0},
+		{true, "replacedBy", []string{}, []string{}, 2},
+		{true, "replacedBy", []string{"table_a"}, []string{}, 1},
+		{true, "replacedBy", []string{}, []string{"table_b.column_b2"}, 1},
+	}
+
+	for i, tt := range tests {
+		r := DeprecatedRequiresReplacement{
+			Enabled: tt.enabled,
+			Key:     tt.key,
+			Exclude: tt.exclude,
+		}
+		s := newTestSchema(t)
+		s.Tables[0].EnhancedCommentData = &schema.CommentData{Deprecated: true, Metadata: map[string]string{"replacement": "table_d"}}
+		s.Tables[1].Columns[1].EnhancedCommentData = &schema.CommentData{Deprecated: true}
+		warns := r.Check(s, tt.lintExclude)
+		if len(warns) != tt.want {
+			t.Errorf("TestDeprecatedRequiresReplacement(%d): got %v\nwant %v", i, len(warns), tt.want)
+		}
+	}
+}
+
+func TestRequirePIIClassification(t *testing.T) {
+	tests := []struct {
+		enabled        bool
+		classification *schema.Classification
+		lintExclude    []string
+		exclude        []string
+		want           int
+	}{
+		{true, nil, []string{}, []string{}, 2},
+		{false, nil, []string{}, []string{}, 0},
+		{true, &schema.Classification{Level: schema.ClassificationPII, Category: "email", Source: schema.ClassificationSourceComment}, []string{}, []string{}, 1},
+		{true, &schema.Classification{Level: schema.ClassificationPII, Category: "email", Source: schema.ClassificationSourceHeuristic}, []string{}, []string{}, 2},
+		{true, nil, []string{"table_b"}, []string{}, 0},
+		{true, nil, []string{}, []string{"table_b.email"}, 1},
+		{true, nil, []string{}, []string{"*Phone*"}, 1},
+	}
+
+	for i, tt := range tests {
+		r := RequirePIIClassification{
+			Enabled: tt.enabled,
+			Exclude: tt.exclude,
+		}
+		s := newTestSchema(t)
+		s.Tables[1].Columns = append(s.Tables[1].Columns,
+			&schema.Column{Name: "email", Type: "text", Classification: tt.classification},
+			&schema.Column{Name: "contactPhoneNumber", Type: "varchar(20)"},
+			&schema.Column{Name: "email_verified", Type: "boolean"},
+		)
+		warns := r.Check(s, tt.lintExclude)
+		if len(warns) != tt.want {
+			t.Errorf("TestRequirePIIClassification(%d): got %v\nwant %v", i, len(warns), tt.want)
+		}
+	}
+}
@@ -13,13 +13,16 @@ type Coverage struct {
 	Tables   []*TableCoverage `json:"tables"`
 	Covered  int              `json:"-"`
 	Total    int              `json:"-"`
+	// Inherited is the number of columns whose comment is inherited from the parent column of the foreign key. They are not counted as covered.
+	Inherited int `json:"inherited,omitempty"`
 }
 
 type TableCoverage struct {
-	Name     string  `json:"name"`
-	Coverage float64 `json:"coverage"`
-	Covered  int     `json:"-"`
-	Total    int     `json:"-"`
+	Name      string  `json:"name"`
+	Coverage  float64 `json:"coverage"`
+	Covered   int     `json:"-"`
+	Total     int     `json:"-"`
+	Inherited int     `json:"inherited,omitempty"`
 }
 
 // Measure coverage.
@@ -50,7 +53,12 @@ func Measure(s *schema.Schema) *Coverage {
 		for _, c := range t.Columns {
 			cover.Total++
 			tcover.Total++
-			if c.Comment != "" && c.Comment != config.NoColumnComment {
+			switch {
+			case c.IsCommentInherited():
+				cover.Inherited++
+				tcover.Inherited++
+			case c.Comment == "" || c.Comment == config.NoColumnComment:
+			default:
 				cover.Covered++
 				tcover.Covered++
 			}
@@ -18,6 +18,29 @@ func TestMeasure(t *testing.T) {
 	}
 }
 
+func TestMeasureInherited(t *testing.T) {
+	s := newTestSchema(t)
+	tb, err := s.FindTableByName("table_b")
+	if err != nil {
+		t.Fatal(err)
+	}
+	// column_b1 ( empty comment ) has the comment inherited from the parent column
+	cb := tb.Columns[0]
+	cb.Inherited = &schema.InheritedComment{From: "table_x.column_x", Description: "column x"}
+	got := Measure(s)
+	if want := 10; got.Covered != want {
+		t.Errorf("got %v want %v", got.Covered, want)
+	}
+	if want := 1; got.Inherited != want {
+		t.Errorf("got %v want %v", got.Inherited, want)
+	}
+	for _, tc := range got.Tables {
+		if tc.Name == "table_b" && tc.Inherited != 1 {
+			t.Errorf("got %v want %v", tc.Inherited, 1)
+		}
+	}
+}
+
 func TestRound(t *testing.T) {
 	tests := []struct {
 		in   float64
@@ -2,6 +2,7 @@ package datasource
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
 	"fmt"
 	"io"
@@ -11,6 +12,7 @@ import (
 	"os/exec"
 	"path/filepath"
 	"slices"
+	"strconv"
 	"strings"
 	"time"
 
@@ -46,38 +48,61 @@ func Analyze(dsn config.DSN) (_ *schema.Schema, err error) {
 	return AnalyzeWithConfig(dsn, nil)
 }
 
+// AnalyzeContext database with context.
+func AnalyzeContext(ctx context.Context, dsn config.DSN) (_ *schema.Schema, err error) {
+	return AnalyzeWithConfigContext(ctx, dsn, nil)
+}
+
 // AnalyzeWithConfig database with configuration for logical name processing.
 func AnalyzeWithConfig(dsn config.DSN, cfg *config.Config) (_ *schema.Schema, err error) {
+	return AnalyzeWithConfigContext(context.Background(), dsn, cfg)
+}
+
+// AnalyzeWithConfigContext database with context and configuration.
+// The analysis is cancelled when ctx is done or the timeout of cfg is exceeded.
+func AnalyzeWithConfigContext(ctx context.Context, dsn config.DSN, cfg *config.Config) (_ *schema.Schema, err error) {
 	defer func() {
 		err = errors.WithStack(err)
 	}()
+	if cfg != nil && cfg.AnalyzeTimeout() > 0 {
+		timeout := cfg.AnalyzeTimeout()
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, timeout)
+		defer cancel()
+		defer func() {
+			if err != nil && errors.Is(ctx.Err(), context.DeadlineExceeded) {
+				err = fmt.Errorf("analysis timed out after %s: %w", timeout, err)
+			}
+		}()
+	}
+
 	urlstr := dsn.URL
 	if strings.HasPrefix(urlstr, "https://") || strings.HasPrefix(urlstr, "http://") {
-		return AnalyzeHTTPResource(dsn)
+		return analyzeUntilDone(ctx, func() (*schema.Schema, error) { return AnalyzeHTTPResource(dsn) })
 	}
 	if strings.HasPrefix(urlstr, "github://") {
-		return AnalyzeGitHubContent(dsn)
+		return analyzeUntilDone(ctx, func() (*schema.Schema, error) { return AnalyzeGitHubContent(dsn) })
 	}
 	if strings.HasPrefix(urlstr, "json://") {
 		return AnalyzeJSON(urlstr)
 	}
 	if strings.HasPrefix(urlstr, "bq://") || strings.HasPrefix(urlstr, "bigquery://") {
-		return AnalyzeBigquery(urlstr)
+		return analyzeUntilDone(ctx, func() (*schema.Schema, error) { return AnalyzeBigquery(urlstr) })
 	}
 	if strings.HasPrefix(urlstr, "span://") || strings.HasPrefix(urlstr, "spanner://") {
-		return AnalyzeSpanner(urlstr)
+		return analyzeUntilDone(ctx, func() (*schema.Schema, error) { return AnalyzeSpanner(urlstr) })
 	}
 	if strings.HasPrefix(urlstr, "dynamodb://") || strings.HasPrefix(urlstr, "dynamo://") {
-		return AnalyzeDynamodb(urlstr)
+		return analyzeUntilDone(ctx, func() (*schema.Schema, error) { return AnalyzeDynamodb(urlstr) })
 	}
 	if strings.HasPrefix(urlstr, "mongodb://") || strings.HasPrefix(urlstr, "mongo://") {
-		return AnalyzeMongodb(urlstr)
+		return analyzeUntilDone(ctx, func() (*schema.Schema, error) { return AnalyzeMongodb(urlstr) })
 	}
 	s := &schema.Schema{}
 	u, err := dburl.Parse(urlstr)
 	if err != nil || !slices.Contains(supportDriversWithDburl, u.Driver) {
 		// Try ext driver
-		return AnalyzeWithExtDriver(urlstr)
+		return analyzeUntilDone(ctx, func() (*schema.Schema, error) { return AnalyzeWithExtDriver(urlstr) })
 	}
 	if err != nil {
 		return nil, err
@@ -110,6 +135,11 @@ func AnalyzeWithConfig(dsn config.DSN, cfg *config.Config) (_ *schema.Schema, er
 		u.RawQuery = values.Encode()
 		urlstr = u.String()
 	}
+	if cfg != nil && cfg.QueryTimeout() > 0 {
+		if setQueryTimeout(u, cfg.QueryTimeout()) {
+			urlstr = u.String()
+		}
+	}
 
 	db, err := dburl.Open(urlstr)
 	if err != nil {
@@ -118,7 +148,7 @@ func AnalyzeWithConfig(dsn config.DSN, cfg *config.Config) (_ *schema.Schema, er
 	defer func() {
 		_ = db.Close()
 	}()
-	if err := db.Ping(); err != nil {
+	if err := db.PingContext(ctx); err != nil {
 		return nil, errors.WithStack(err)
 	}
 
@@ -158,6 +188,16 @@ func AnalyzeWithConfig(dsn config.DSN, cfg *config.Config) (_ *schema.Schema, er
 		return s, fmt.Errorf("unsupported driver '%s'", u.Driver)
 	}
 	
+	// Set concurrency of table analysis
+	if concurrentDriver, ok := driver.(drivers.ConcurrentDriver); ok {
+		if cfg != nil && cfg.Analyze.Concurrency > 0 {
+			concurrency := cfg.Analyze.Concurrency
+			concurrentDriver.SetConcurrency(concurrency)
+			// keep the connections of workers
+			db.SetMaxIdleConns(concurrency)
+		}
+	}
+
 	// Set logical name configuration if available
 	if cfg != nil {
 		if configurableDriver, ok := driver.(drivers.ConfigurableDriver); ok {
@@ -173,13 +213,58 @@ func AnalyzeWithConfig(dsn config.DSN, cfg *config.Config) (_ *schema.Schema, er
 		}
 	}
 	
-	err = driver.Analyze(s)
+	err = drivers.WithContext(driver).AnalyzeContext(ctx, s)
 	if err != nil {
 		return nil, err
 	}
 	return s, nil
 }
 
+// analyzeUntilDone runs analyze that does not take a context and returns the error of ctx when ctx is done before analyze finishes.
+func analyzeUntilDone(ctx context.Context, analyze func() (*schema.Schema, error)) (*schema.Schema, error) {
+	if ctx.Done() == nil {
+		return analyze()
+	}
+	type result struct {
+		s   *schema.Schema
+		err error
+	}
+	ch := make(chan result, 1)
+	go func() {
+		s, err := analyze()
+		ch <- result{s: s, err: err}
+	}()
+	select {
+	case r := <-ch:
+		return r.s, r.err
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+}
+
+// setQueryTimeout sets the timeout of each query to the DSN parameters supported by the driver.
+// It returns false if the driver does not support it or the parameter is already set.
+func setQueryTimeout(u *dburl.URL, timeout time.Duration) bool {
+	var k, v string
+	switch {
+	case u.Driver == "postgres":
+		k, v = "statement_timeout", strconv.FormatInt(timeout.Milliseconds(), 10)
+	case u.Driver == "mysql" && (u.Scheme == "maria" || u.Scheme == "mariadb"):
+		k, v = "max_statement_time", strconv.FormatFloat(timeout.Seconds(), 'f', -1, 64)
+	case u.Driver == "mysql":
+		k, v = "max_execution_time", strconv.FormatInt(timeout.Milliseconds(), 10)
+	default:
+		return false
+	}
+	values := u.Query()
+	if values.Has(k) {
+		return false
+	}
+	values.Set(k, v)
+	u.RawQuery = values.Encode()
+	return true
+}
+
 // AnalyzeHTTPResource analyze `https://` or `http://`
 func AnalyzeHTTPResource(dsn config.DSN) (_ *schema.Schema, err error) {
 	defer func() {
@@ -0,0 +1,49 @@
+package datasource
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"os/exec"
+	"path/filepath"
+	"strings"
+
+	"github.com/cli/safeexec"
+	"github.com/k1LoW/errors"
+	"github.com/k1LoW/tbls/schema"
+)
+
+// AnalyzeGitRevision analyze JSON file ( ex. schema.json ) at the git revision without checking it out.
+func AnalyzeGitRevision(ref, path string) (_ *schema.Schema, err error) {
+	defer func() {
+		err = errors.WithStack(err)
+	}()
+	if ref == "" || strings.HasPrefix(ref, "-") {
+		return nil, fmt.Errorf("invalid git ref: '%s'", ref)
+	}
+	bin, err := safeexec.LookPath("git")
+	if err != nil {
+		return nil, err
+	}
+	dir, file := filepath.Split(filepath.Clean(path))
+	if dir == "" {
+		dir = "."
+	}
+	c := exec.Command(bin, "-C", dir, "show", fmt.Sprintf("%s:./%s", ref, file)) // #nosec
+	stdout := new(bytes.Buffer)
+	stderr := new(bytes.Buffer)
+	c.Stdout = stdout
+	c.Stderr = stderr
+	if err := c.Run(); err != nil {
+		return nil, fmt.Errorf("failed to read %s at git ref '%s': %s: %w", path, ref, strings.TrimSpace(stderr.String()), err)
+	}
+	s := &schema.Schema{}
+	dec := json.NewDecoder(stdout)
+	if err := dec.Decode(s); err != nil {
+		return nil, err
+	}
+	if err := s.Repair(); err != nil {
+		return nil, err
+	}
+	return s, nil
+}
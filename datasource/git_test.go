@@ -0,0 +1,58 @@
+package datasource
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"testing"
+)
+
+func TestAnalyzeGitRevision(t *testing.T) {
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git not found")
+	}
+	dir := t.TempDir()
+	docPath := filepath.Join(dir, "dbdoc")
+	if err := os.MkdirAll(docPath, 0755); err != nil {
+		t.Fatal(err)
+	}
+	b, err := os.ReadFile(filepath.Join("..", "testdata", "testdb.json"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	sf := filepath.Join(docPath, "schema.json")
+	if err := os.WriteFile(sf, b, 0600); err != nil {
+		t.Fatal(err)
+	}
+	git := func(args ...string) {
+		t.Helper()
+		args = append([]string{"-C", dir, "-c", "user.name=tbls", "-c", "user.email=tbls@example.com"}, args...)
+		if out, err := exec.Command("git", args...).CombinedOutput(); err != nil {
+			t.Fatalf("%s: %s", err, out)
+		}
+	}
+	git("init", "-q")
+	git("add", "-A")
+	git("commit", "-q", "-m", "init")
+	// The working tree should not be used
+	if err := os.WriteFile(sf, []byte(`{"name": "modified"}`), 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	s, err := AnalyzeGitRevision("HEAD", sf)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := "testdb"; s.Name != want {
+		t.Errorf("got %v\nwant %v", s.Name, want)
+	}
+	if want := 11; len(s.Tables) != want {
+		t.Errorf("got %v\nwant %v", len(s.Tables), want)
+	}
+
+	for _, ref := range []string{"", "--output=/tmp/x", "unknown"} {
+		if _, err := AnalyzeGitRevision(ref, sf); err == nil {
+			t.Errorf("ref '%s': want error", ref)
+		}
+	}
+}
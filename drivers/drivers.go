@@ -1,15 +1,27 @@
 package drivers
 
 import (
+	"context"
+
 	"github.com/k1LoW/tbls/schema"
+	"golang.org/x/sync/errgroup"
 )
 
+// DefaultConcurrency is the default number of tables analyzed concurrently.
+const DefaultConcurrency = 4
+
 // Driver is the common interface for database drivers.
 type Driver interface {
 	Analyze(*schema.Schema) error
 	Info() (*schema.Driver, error)
 }
 
+// ContextDriver is the interface for drivers that can cancel analysis with context.
+type ContextDriver interface {
+	Driver
+	AnalyzeContext(context.Context, *schema.Schema) error
+}
+
 // ConfigurableDriver is the interface for drivers that can accept configuration.
 type ConfigurableDriver interface {
 	Driver
@@ -17,5 +29,57 @@ type ConfigurableDriver interface {
 	SetTableLogicalNameConfig(delimiter string, fallbackToName bool)
 }
 
+// ConcurrentDriver is the interface for drivers that analyze tables concurrently.
+type ConcurrentDriver interface {
+	Driver
+	SetConcurrency(n int)
+}
+
 // Option is the type for change Config.
 type Option func(Driver) error
+
+// WithContext returns ContextDriver. If the driver does not implement ContextDriver, it is wrapped by an adapter.
+func WithContext(d Driver) ContextDriver {
+	if cd, ok := d.(ContextDriver); ok {
+		return cd
+	}
+	return &contextAdapter{Driver: d}
+}
+
+// contextAdapter runs Analyze of the driver in a goroutine and stops waiting for it when the context is done.
+// The analysis itself is not cancelled, so the schema must be discarded when AnalyzeContext returns an error.
+type contextAdapter struct {
+	Driver
+}
+
+func (a *contextAdapter) AnalyzeContext(ctx context.Context, s *schema.Schema) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	done := make(chan error, 1)
+	go func() {
+		done <- a.Driver.Analyze(s)
+	}()
+	select {
+	case err := <-done:
+		return err
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// ForEach calls fn for 0 to n-1 with at most concurrency goroutines.
+// When fn returns an error, the context passed to the other calls is cancelled and the first error is returned.
+func ForEach(ctx context.Context, n, concurrency int, fn func(ctx context.Context, i int) error) error {
+	if concurrency < 1 {
+		concurrency = 1
+	}
+	eg, ctx := errgroup.WithContext(ctx)
+	eg.SetLimit(concurrency)
+	for i := 0; i < n; i++ {
+		eg.Go(func() error {
+			return fn(ctx, i)
+		})
+	}
+	return eg.Wait()
+}
@@ -0,0 +1,59 @@
+package drivers
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/k1LoW/tbls/schema"
+)
+
+type slowDriver struct {
+	wait time.Duration
+}
+
+func (d *slowDriver) Analyze(s *schema.Schema) error {
+	time.Sleep(d.wait)
+	return nil
+}
+
+func (d *slowDriver) Info() (*schema.Driver, error) {
+	return &schema.Driver{Name: "slow"}, nil
+}
+
+type ctxDriver struct {
+	slowDriver
+	called bool
+}
+
+func (d *ctxDriver) AnalyzeContext(ctx context.Context, s *schema.Schema) error {
+	d.called = true
+	return nil
+}
+
+func TestWithContext(t *testing.T) {
+	t.Run("adapter", func(t *testing.T) {
+		d := WithContext(&slowDriver{wait: time.Second})
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+		defer cancel()
+		if err := d.AnalyzeContext(ctx, &schema.Schema{}); !errors.Is(err, context.DeadlineExceeded) {
+			t.Errorf("got %v\nwant %v", err, context.DeadlineExceeded)
+		}
+	})
+	t.Run("adapter completes", func(t *testing.T) {
+		d := WithContext(&slowDriver{})
+		if err := d.AnalyzeContext(context.Background(), &schema.Schema{}); err != nil {
+			t.Error(err)
+		}
+	})
+	t.Run("context driver", func(t *testing.T) {
+		cd := &ctxDriver{}
+		if err := WithContext(cd).AnalyzeContext(context.Background(), &schema.Schema{}); err != nil {
+			t.Error(err)
+		}
+		if !cd.called {
+			t.Error("AnalyzeContext of the driver should be called")
+		}
+	})
+}
@@ -1,6 +1,7 @@
 package mssql
 
 import (
+	"context"
 	"database/sql"
 	"fmt"
 	"regexp"
@@ -10,6 +11,7 @@ import (
 	"github.com/k1LoW/errors"
 	"github.com/k1LoW/tbls/ddl"
 	"github.com/k1LoW/tbls/dict"
+	"github.com/k1LoW/tbls/drivers"
 	"github.com/k1LoW/tbls/schema"
 )
 
@@ -25,6 +27,7 @@ type Mssql struct {
 	logicalNameFallbackToName      bool
 	tableLogicalNameDelimiter      string
 	tableLogicalNameFallbackToName bool
+	concurrency                    int
 }
 
 type relationLink struct {
@@ -42,6 +45,7 @@ func New(db *sql.DB) *Mssql {
 		logicalNameFallbackToName:      false,
 		tableLogicalNameDelimiter:      "|",
 		tableLogicalNameFallbackToName: false,
+		concurrency:                    drivers.DefaultConcurrency,
 	}
 }
 
@@ -57,15 +61,25 @@ func (m *Mssql) SetTableLogicalNameConfig(delimiter string, fallbackToName bool)
 	m.tableLogicalNameFallbackToName = fallbackToName
 }
 
+// SetConcurrency sets the number of tables analyzed concurrently.
+func (m *Mssql) SetConcurrency(n int) {
+	m.concurrency = n
+}
+
 func (m *Mssql) Analyze(s *schema.Schema) error {
-	d, err := m.Info()
+	return m.AnalyzeContext(context.Background(), s)
+}
+
+// AnalyzeContext SQL Server database schema with context.
+func (m *Mssql) AnalyzeContext(ctx context.Context, s *schema.Schema) error {
+	d, err := m.info(ctx)
 	if err != nil {
 		return errors.WithStack(err)
 	}
 	s.Driver = d
 
 	// tables and comments
-	tableRows, err := m.db.Query(`
+	tableRows, err := m.db.QueryContext(ctx, `
 SELECT schema_name(schema_id) AS table_schema, o.name, o.object_id, o.type, cast(e.value as NVARCHAR(MAX)) AS table_comment
 FROM sys.objects AS o
 LEFT JOIN sys.extended_properties AS e ON
@@ -77,6 +91,7 @@ WHERE type IN ('U', 'V')  ORDER BY OBJECT_ID
 	}
 	defer tableRows.Close()
 
+	msTables := []*msTable{}
 	tables := []*schema.Table{}
 	links := []relationLink{}
 
@@ -110,27 +125,132 @@ WHERE type IN ('U', 'V')  ORDER BY OBJECT_ID
 			table.SetLogicalNameFromComment(m.tableLogicalNameDelimiter, m.tableLogicalNameFallbackToName)
 		}
 
-		// view definition
-		if tableType == "VIEW" {
-			viewDefRows, err := m.db.Query(`
-SELECT definition FROM sys.sql_modules WHERE object_id = @p1
-`, tableOid)
+		msTables = append(msTables, &msTable{
+			oid:        tableOid,
+			objectName: fmt.Sprintf("%s.%s", tableSchema, tableName),
+			table:      table,
+		})
+		tables = append(tables, table)
+	}
+	if err := tableRows.Err(); err != nil {
+		return errors.WithStack(err)
+	}
+	_ = tableRows.Close()
+
+	// columns, constraints, triggers and indexes of each table
+	tableLinks := make([][]relationLink, len(msTables))
+	if err := drivers.ForEach(ctx, len(msTables), m.concurrency, func(ctx context.Context, i int) error {
+		ls, err := m.analyzeTable(ctx, msTables[i])
+		if err != nil {
+			return err
+		}
+		tableLinks[i] = ls
+		return nil
+	}); err != nil {
+		return err
+	}
+	for _, ls := range tableLinks {
+		links = append(links, ls...)
+	}
+
+	functions, err := m.getFunctions(ctx)
+	if err != nil {
+		return err
+	}
+	s.Functions = functions
+
+	s.Tables = tables
+
+	// relations
+	relations := []*schema.Relation{}
+	for _, l := range links {
+		r := &schema.Relation{}
+		table, err := s.FindTableByName(l.table)
+		if err != nil {
+			return err
+		}
+		r.Table = table
+		for _, c := range l.columns {
+			column, err := table.FindColumnByName(c)
+			if err != nil {
+				return err
+			}
+			r.Columns = append(r.Columns, column)
+			column.ParentRelations = append(column.ParentRelations, r)
+		}
+		parentTable, err := s.FindTableByName(l.parentTable)
+		if err != nil {
+			return err
+		}
+		r.ParentTable = parentTable
+		for _, c := range l.parentColumns {
+			column, err := parentTable.FindColumnByName(c)
 			if err != nil {
-				return errors.WithStack(err)
+				return err
 			}
-			defer viewDefRows.Close()
-			for viewDefRows.Next() {
-				var tableDef sql.NullString
-				err := viewDefRows.Scan(&tableDef)
-				if err != nil {
-					return errors.WithStack(err)
+			r.ParentColumns = append(r.ParentColumns, column)
+			column.ChildRelations = append(column.ChildRelations, r)
+		}
+		relations = append(relations, r)
+	}
+
+	s.Relations = relations
+
+	// referenced tables of view
+	for _, t := range s.Tables {
+		if t.Type != "VIEW" {
+			continue
+		}
+		for _, rts := range ddl.ParseReferencedTables(t.Def) {
+			rt, err := s.FindTableByName(rts)
+			if err != nil {
+				rt = &schema.Table{
+					Name:     rts,
+					External: true,
 				}
-				table.Def = tableDef.String
 			}
+			t.ReferencedTables = append(t.ReferencedTables, rt)
 		}
+	}
 
-		// columns and comments
-		columnRows, err := m.db.Query(`
+	return nil
+}
+
+// msTable is a table to be analyzed by analyzeTable.
+type msTable struct {
+	oid        string
+	objectName string
+	table      *schema.Table
+}
+
+// analyzeTable sets the definition, columns, constraints, triggers and indexes of the table and returns the foreign keys of the table.
+func (m *Mssql) analyzeTable(ctx context.Context, t *msTable) (_ []relationLink, err error) {
+	defer func() {
+		err = errors.WithStack(err)
+	}()
+	links := []relationLink{}
+
+	// view definition
+	if t.table.Type == "VIEW" {
+		viewDefRows, err := m.db.QueryContext(ctx, `
+SELECT definition FROM sys.sql_modules WHERE object_id = @p1
+`, t.oid)
+		if err != nil {
+			return nil, errors.WithStack(err)
+		}
+		defer viewDefRows.Close()
+		for viewDefRows.Next() {
+			var tableDef sql.NullString
+			err := viewDefRows.Scan(&tableDef)
+			if err != nil {
+				return nil, errors.WithStack(err)
+			}
+			t.table.Def = tableDef.String
+		}
+	}
+
+	// columns and comments
+	columnRows, err := m.db.QueryContext(ctx, `
 SELECT
   c.name,
   t.name AS type,
@@ -146,48 +266,48 @@ e.major_id = c.object_id AND e.name = 'MS_Description' AND e.minor_id = c.column
 WHERE c.object_id = @p1
 and t.name != 'sysname'
 ORDER BY c.column_id
-`, tableOid)
+`, t.oid)
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+	defer columnRows.Close()
+
+	columns := []*schema.Column{}
+	for columnRows.Next() {
+		var (
+			columnName    string
+			dataType      string
+			maxLength     int
+			isNullable    bool
+			isIdentity    bool
+			columnDefault sql.NullString
+			columnComment sql.NullString
+		)
+		err = columnRows.Scan(&columnName, &dataType, &maxLength, &isNullable, &isIdentity, &columnDefault, &columnComment)
 		if err != nil {
-			return errors.WithStack(err)
+			return nil, errors.WithStack(err)
+		}
+		column := &schema.Column{
+			Name:     columnName,
+			Type:     convertColumnType(dataType, maxLength),
+			Nullable: isNullable,
+			Default:  columnDefault,
+			Comment:  columnComment.String,
 		}
-		defer columnRows.Close()
-
-		columns := []*schema.Column{}
-		for columnRows.Next() {
-			var (
-				columnName    string
-				dataType      string
-				maxLength     int
-				isNullable    bool
-				isIdentity    bool
-				columnDefault sql.NullString
-				columnComment sql.NullString
-			)
-			err = columnRows.Scan(&columnName, &dataType, &maxLength, &isNullable, &isIdentity, &columnDefault, &columnComment)
-			if err != nil {
-				return errors.WithStack(err)
-			}
-			column := &schema.Column{
-				Name:     columnName,
-				Type:     convertColumnType(dataType, maxLength),
-				Nullable: isNullable,
-				Default:  columnDefault,
-				Comment:  columnComment.String,
-			}
-
-			// 論理名をコメントから抽出（設定値を使用）
-			if columnComment.Valid && columnComment.String != "" {
-				column.SetLogicalNameFromComment(m.logicalNameDelimiter, m.logicalNameFallbackToName)
-			}
 
-			columns = append(columns, column)
+		// 論理名をコメントから抽出（設定値を使用）
+		if columnComment.Valid && columnComment.String != "" {
+			column.SetLogicalNameFromComment(m.logicalNameDelimiter, m.logicalNameFallbackToName)
 		}
-		table.Columns = columns
 
-		// constraints
-		constraints := []*schema.Constraint{}
-		/// key constraints
-		keyRows, err := m.db.Query(`
+		columns = append(columns, column)
+	}
+	t.table.Columns = columns
+
+	// constraints
+	constraints := []*schema.Constraint{}
+	/// key constraints
+	keyRows, err := m.db.QueryContext(ctx, `
 SELECT
   c.name,
   i.type_desc,
@@ -207,54 +327,54 @@ INNER JOIN sys.indexes AS i ON i.object_id = c.parent_object_id AND i.index_id =
 WHERE i.object_id = object_id(@p1)
 GROUP BY c.name, i.index_id, i.type_desc, i.is_unique, i.is_primary_key, i.is_unique_constraint, c.is_system_named, i.object_id
 ORDER BY i.index_id
-`, fmt.Sprintf("%s.%s", tableSchema, tableName))
+`, t.objectName)
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+	defer keyRows.Close()
+	for keyRows.Next() {
+		var (
+			indexName               string
+			indexClusterType        string
+			indexIsUnique           bool
+			indexIsPrimaryKey       bool
+			indexIsUniqueConstraint bool
+			indexColumnName         sql.NullString
+			indexIsSystemNamed      bool
+		)
+		err = keyRows.Scan(&indexName, &indexClusterType, &indexIsUnique, &indexIsPrimaryKey, &indexIsUniqueConstraint, &indexColumnName, &indexIsSystemNamed)
 		if err != nil {
-			return errors.WithStack(err)
+			return nil, errors.WithStack(err)
 		}
-		defer keyRows.Close()
-		for keyRows.Next() {
-			var (
-				indexName               string
-				indexClusterType        string
-				indexIsUnique           bool
-				indexIsPrimaryKey       bool
-				indexIsUniqueConstraint bool
-				indexColumnName         sql.NullString
-				indexIsSystemNamed      bool
-			)
-			err = keyRows.Scan(&indexName, &indexClusterType, &indexIsUnique, &indexIsPrimaryKey, &indexIsUniqueConstraint, &indexColumnName, &indexIsSystemNamed)
-			if err != nil {
-				return errors.WithStack(err)
-			}
-			indexType := "-"
-			indexDef := []string{
-				indexClusterType,
-			}
-			if indexIsUnique {
-				indexDef = append(indexDef, "unique")
-			}
-			if indexIsPrimaryKey {
-				indexType = "PRIMARY KEY"
-				indexDef = append(indexDef, "part of a PRIMARY KEY constraint")
-			}
-			if indexIsUniqueConstraint {
-				indexType = "UNIQUE"
-				indexDef = append(indexDef, "part of a UNIQUE constraint")
-			}
-			indexDef = append(indexDef, fmt.Sprintf("[ %s ]", indexColumnName.String))
-
-			constraint := &schema.Constraint{
-				Name:    convertSystemNamed(indexName, indexIsSystemNamed),
-				Type:    indexType,
-				Def:     strings.Join(indexDef, ", "),
-				Table:   &table.Name,
-				Columns: strings.Split(indexColumnName.String, ", "),
-			}
-			constraints = append(constraints, constraint)
+		indexType := "-"
+		indexDef := []string{
+			indexClusterType,
+		}
+		if indexIsUnique {
+			indexDef = append(indexDef, "unique")
 		}
+		if indexIsPrimaryKey {
+			indexType = "PRIMARY KEY"
+			indexDef = append(indexDef, "part of a PRIMARY KEY constraint")
+		}
+		if indexIsUniqueConstraint {
+			indexType = "UNIQUE"
+			indexDef = append(indexDef, "part of a UNIQUE constraint")
+		}
+		indexDef = append(indexDef, fmt.Sprintf("[ %s ]", indexColumnName.String))
+
+		constraint := &schema.Constraint{
+			Name:    convertSystemNamed(indexName, indexIsSystemNamed),
+			Type:    indexType,
+			Def:     strings.Join(indexDef, ", "),
+			Table:   &t.table.Name,
+			Columns: strings.Split(indexColumnName.String, ", "),
+		}
+		constraints = append(constraints, constraint)
+	}
 
-		/// foreign_keys
-		fkRows, err := m.db.Query(`
+	/// foreign_keys
+	fkRows, err := m.db.QueryContext(ctx, `
 SELECT
   f.name AS f_name,
   OBJECT_NAME(f.parent_object_id) AS table_name,
@@ -279,115 +399,115 @@ FROM sys.foreign_keys AS f
 WHERE f.parent_object_id = object_id(@p1)
 GROUP BY f.name, f.parent_object_id, f.referenced_object_id, delete_referential_action_desc, update_referential_action_desc, f.is_system_named, f.object_id
 ORDER BY f.name
-`, fmt.Sprintf("%s.%s", tableSchema, tableName))
+`, t.objectName)
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+	defer fkRows.Close()
+	for fkRows.Next() {
+		var (
+			fkName              string
+			fkTableName         string
+			fkParentTableName   string
+			fkParentSchemaName  string
+			fkColumnNames       string
+			fkParentColumnNames string
+			fkUpdateAction      string
+			fkDeleteAction      string
+			fkIsSystemNamed     bool
+		)
+		err = fkRows.Scan(&fkName, &fkTableName, &fkParentTableName, &fkParentSchemaName, &fkColumnNames, &fkParentColumnNames, &fkUpdateAction, &fkDeleteAction, &fkIsSystemNamed)
 		if err != nil {
-			return errors.WithStack(err)
+			return nil, errors.WithStack(err)
 		}
-		defer fkRows.Close()
-		for fkRows.Next() {
-			var (
-				fkName              string
-				fkTableName         string
-				fkParentTableName   string
-				fkParentSchemaName  string
-				fkColumnNames       string
-				fkParentColumnNames string
-				fkUpdateAction      string
-				fkDeleteAction      string
-				fkIsSystemNamed     bool
-			)
-			err = fkRows.Scan(&fkName, &fkTableName, &fkParentTableName, &fkParentSchemaName, &fkColumnNames, &fkParentColumnNames, &fkUpdateAction, &fkDeleteAction, &fkIsSystemNamed)
-			if err != nil {
-				return errors.WithStack(err)
-			}
-			if fkParentSchemaName != defaultSchemaName {
-				fkParentTableName = fmt.Sprintf("%s.%s", fkParentSchemaName, fkParentTableName)
-			}
-			fkDef := fmt.Sprintf("FOREIGN KEY(%s) REFERENCES %s(%s) ON UPDATE %s ON DELETE %s", fkColumnNames, fkParentTableName, fkParentColumnNames, fkUpdateAction, fkDeleteAction) // #nosec
-			constraint := &schema.Constraint{
-				Name:              convertSystemNamed(fkName, fkIsSystemNamed),
-				Type:              typeFk,
-				Def:               fkDef,
-				Table:             &table.Name,
-				Columns:           strings.Split(fkColumnNames, ", "),
-				ReferencedTable:   &fkParentTableName,
-				ReferencedColumns: strings.Split(fkParentColumnNames, ", "),
-			}
-			links = append(links, relationLink{
-				table:         table.Name,
-				columns:       strings.Split(fkColumnNames, ", "),
-				parentTable:   fkParentTableName,
-				parentColumns: strings.Split(fkParentColumnNames, ", "),
-			})
-
-			constraints = append(constraints, constraint)
+		if fkParentSchemaName != defaultSchemaName {
+			fkParentTableName = fmt.Sprintf("%s.%s", fkParentSchemaName, fkParentTableName)
 		}
+		fkDef := fmt.Sprintf("FOREIGN KEY(%s) REFERENCES %s(%s) ON UPDATE %s ON DELETE %s", fkColumnNames, fkParentTableName, fkParentColumnNames, fkUpdateAction, fkDeleteAction) // #nosec
+		constraint := &schema.Constraint{
+			Name:              convertSystemNamed(fkName, fkIsSystemNamed),
+			Type:              typeFk,
+			Def:               fkDef,
+			Table:             &t.table.Name,
+			Columns:           strings.Split(fkColumnNames, ", "),
+			ReferencedTable:   &fkParentTableName,
+			ReferencedColumns: strings.Split(fkParentColumnNames, ", "),
+		}
+		links = append(links, relationLink{
+			table:         t.table.Name,
+			columns:       strings.Split(fkColumnNames, ", "),
+			parentTable:   fkParentTableName,
+			parentColumns: strings.Split(fkParentColumnNames, ", "),
+		})
+
+		constraints = append(constraints, constraint)
+	}
 
-		/// check_constraints
-		checkRows, err := m.db.Query(`
+	/// check_constraints
+	checkRows, err := m.db.QueryContext(ctx, `
 SELECT name, definition, is_system_named
 FROM sys.check_constraints
 WHERE parent_object_id = object_id(@p1)
-`, fmt.Sprintf("%s.%s", tableSchema, tableName))
+`, t.objectName)
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+	defer checkRows.Close()
+	for checkRows.Next() {
+		var (
+			checkName          string
+			checkDef           string
+			checkIsSystemNamed bool
+		)
+		err = checkRows.Scan(&checkName, &checkDef, &checkIsSystemNamed)
 		if err != nil {
-			return errors.WithStack(err)
+			return nil, errors.WithStack(err)
 		}
-		defer checkRows.Close()
-		for checkRows.Next() {
-			var (
-				checkName          string
-				checkDef           string
-				checkIsSystemNamed bool
-			)
-			err = checkRows.Scan(&checkName, &checkDef, &checkIsSystemNamed)
-			if err != nil {
-				return errors.WithStack(err)
-			}
-			constraint := &schema.Constraint{
-				Name:  convertSystemNamed(checkName, checkIsSystemNamed),
-				Type:  typeCheck,
-				Def:   fmt.Sprintf("CHECK%s", checkDef),
-				Table: &table.Name,
-			}
-			constraints = append(constraints, constraint)
+		constraint := &schema.Constraint{
+			Name:  convertSystemNamed(checkName, checkIsSystemNamed),
+			Type:  typeCheck,
+			Def:   fmt.Sprintf("CHECK%s", checkDef),
+			Table: &t.table.Name,
 		}
+		constraints = append(constraints, constraint)
+	}
 
-		table.Constraints = constraints
+	t.table.Constraints = constraints
 
-		// triggers
-		triggerRows, err := m.db.Query(`
+	// triggers
+	triggerRows, err := m.db.QueryContext(ctx, `
 SELECT name, definition
 FROM sys.triggers AS t
 INNER JOIN sys.sql_modules AS sm
 ON sm.object_id = t.object_id
 WHERE type = 'TR'
 AND parent_id = object_id(@p1)
-`, fmt.Sprintf("%s.%s", tableSchema, tableName))
+`, t.objectName)
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+	defer triggerRows.Close()
+
+	triggers := []*schema.Trigger{}
+	for triggerRows.Next() {
+		var (
+			triggerName string
+			triggerDef  string
+		)
+		err = triggerRows.Scan(&triggerName, &triggerDef)
 		if err != nil {
-			return errors.WithStack(err)
+			return nil, errors.WithStack(err)
 		}
-		defer triggerRows.Close()
-
-		triggers := []*schema.Trigger{}
-		for triggerRows.Next() {
-			var (
-				triggerName string
-				triggerDef  string
-			)
-			err = triggerRows.Scan(&triggerName, &triggerDef)
-			if err != nil {
-				return errors.WithStack(err)
-			}
-			trigger := &schema.Trigger{
-				Name: triggerName,
-				Def:  triggerDef,
-			}
-			triggers = append(triggers, trigger)
+		trigger := &schema.Trigger{
+			Name: triggerName,
+			Def:  triggerDef,
 		}
-		table.Triggers = triggers
+		triggers = append(triggers, trigger)
+	}
+	t.table.Triggers = triggers
 
-		// indexes
-		indexRows, err := m.db.Query(`
+	// indexes
+	indexRows, err := m.db.QueryContext(ctx, `
 SELECT
   i.name AS index_name,
   i.type_desc,
@@ -398,7 +518,7 @@ SELECT
     (SELECT ', ' + COL_NAME(ic.object_id, ic.column_id)
       FROM sys.index_columns AS ic
       WHERE i.object_id = ic.object_id AND i.index_id = ic.index_id
-	  ORDER BY ic.key_ordinal
+  ORDER BY ic.key_ordinal
       FOR XML PATH('')
     ), 1, 2, '') AS column_names,
   c.is_system_named
@@ -409,116 +529,53 @@ WHERE i.object_id = object_id(@p1)
   AND EXISTS (SELECT 1 FROM sys.index_columns AS ic0 WHERE ic0.index_id = i.index_id)
 GROUP BY i.name, i.index_id, i.type_desc, i.is_unique, i.is_primary_key, i.is_unique_constraint, c.is_system_named, i.object_id
 ORDER BY i.index_id
-`, fmt.Sprintf("%s.%s", tableSchema, tableName))
-		if err != nil {
-			return errors.WithStack(err)
-		}
-		defer indexRows.Close()
-		indexes := []*schema.Index{}
-		for indexRows.Next() {
-			var (
-				indexName               string
-				indexType               string
-				indexIsUnique           bool
-				indexIsPrimaryKey       bool
-				indexIsUniqueConstraint bool
-				indexColumnName         sql.NullString
-				indexIsSytemNamed       sql.NullBool
-			)
-			err = indexRows.Scan(&indexName, &indexType, &indexIsUnique, &indexIsPrimaryKey, &indexIsUniqueConstraint, &indexColumnName, &indexIsSytemNamed)
-			if err != nil {
-				return errors.WithStack(err)
-			}
-
-			indexDef := []string{
-				indexType,
-			}
-			if indexIsUnique {
-				indexDef = append(indexDef, "unique")
-			}
-			if indexIsPrimaryKey {
-				indexDef = append(indexDef, "part of a PRIMARY KEY constraint")
-			}
-			if indexIsUniqueConstraint {
-				indexDef = append(indexDef, "part of a UNIQUE constraint")
-			}
-			indexDef = append(indexDef, fmt.Sprintf("[ %s ]", indexColumnName.String))
-
-			index := &schema.Index{
-				Name:    convertSystemNamed(indexName, indexIsSytemNamed.Bool),
-				Def:     strings.Join(indexDef, ", "),
-				Table:   &table.Name,
-				Columns: strings.Split(indexColumnName.String, ", "),
-			}
-
-			indexes = append(indexes, index)
-		}
-		table.Indexes = indexes
-
-		tables = append(tables, table)
-	}
-
-	functions, err := m.getFunctions()
+`, t.objectName)
 	if err != nil {
-		return err
+		return nil, errors.WithStack(err)
 	}
-	s.Functions = functions
-
-	s.Tables = tables
-
-	// relations
-	relations := []*schema.Relation{}
-	for _, l := range links {
-		r := &schema.Relation{}
-		table, err := s.FindTableByName(l.table)
+	defer indexRows.Close()
+	indexes := []*schema.Index{}
+	for indexRows.Next() {
+		var (
+			indexName               string
+			indexType               string
+			indexIsUnique           bool
+			indexIsPrimaryKey       bool
+			indexIsUniqueConstraint bool
+			indexColumnName         sql.NullString
+			indexIsSytemNamed       sql.NullBool
+		)
+		err = indexRows.Scan(&indexName, &indexType, &indexIsUnique, &indexIsPrimaryKey, &indexIsUniqueConstraint, &indexColumnName, &indexIsSytemNamed)
 		if err != nil {
-			return err
+			return nil, errors.WithStack(err)
 		}
-		r.Table = table
-		for _, c := range l.columns {
-			column, err := table.FindColumnByName(c)
-			if err != nil {
-				return err
-			}
-			r.Columns = append(r.Columns, column)
-			column.ParentRelations = append(column.ParentRelations, r)
+
+		indexDef := []string{
+			indexType,
 		}
-		parentTable, err := s.FindTableByName(l.parentTable)
-		if err != nil {
-			return err
+		if indexIsUnique {
+			indexDef = append(indexDef, "unique")
 		}
-		r.ParentTable = parentTable
-		for _, c := range l.parentColumns {
-			column, err := parentTable.FindColumnByName(c)
-			if err != nil {
-				return err
-			}
-			r.ParentColumns = append(r.ParentColumns, column)
-			column.ChildRelations = append(column.ChildRelations, r)
+		if indexIsPrimaryKey {
+			indexDef = append(indexDef, "part of a PRIMARY KEY constraint")
 		}
-		relations = append(relations, r)
-	}
-
-	s.Relations = relations
-
-	// referenced tables of view
-	for _, t := range s.Tables {
-		if t.Type != "VIEW" {
-			continue
+		if indexIsUniqueConstraint {
+			indexDef = append(indexDef, "part of a UNIQUE constraint")
 		}
-		for _, rts := range ddl.ParseReferencedTables(t.Def) {
-			rt, err := s.FindTableByName(rts)
-			if err != nil {
-				rt = &schema.Table{
-					Name:     rts,
-					External: true,
-				}
-			}
-			t.ReferencedTables = append(t.ReferencedTables, rt)
+		indexDef = append(indexDef, fmt.Sprintf("[ %s ]", indexColumnName.String))
+
+		index := &schema.Index{
+			Name:    convertSystemNamed(indexName, indexIsSytemNamed.Bool),
+			Def:     strings.Join(indexDef, ", "),
+			Table:   &t.table.Name,
+			Columns: strings.Split(indexColumnName.String, ", "),
 		}
+
+		indexes = append(indexes, index)
 	}
+	t.table.Indexes = indexes
 
-	return nil
+	return links, nil
 }
 
 const query = `SELECT SCHEMA_NAME(obj.schema_id) AS schema_name,
@@ -546,9 +603,9 @@ LEFT JOIN sys.parameters ret
 WHERE obj.type IN ('FN', 'TF', 'IF', 'P', 'X')
 ORDER BY schema_name, name;`
 
-func (m *Mssql) getFunctions() ([]*schema.Function, error) {
+func (m *Mssql) getFunctions(ctx context.Context) ([]*schema.Function, error) {
 	functions := []*schema.Function{}
-	functionsResult, err := m.db.Query(query)
+	functionsResult, err := m.db.QueryContext(ctx, query)
 	if err != nil {
 		return nil, errors.WithStack(err)
 	}
@@ -583,8 +640,12 @@ func fullTableName(owner string, tableName string) string {
 }
 
 func (m *Mssql) Info() (*schema.Driver, error) {
+	return m.info(context.Background())
+}
+
+func (m *Mssql) info(ctx context.Context) (*schema.Driver, error) {
 	var v string
-	row := m.db.QueryRow(`SELECT @@VERSION`)
+	row := m.db.QueryRowContext(ctx, `SELECT @@VERSION`)
 	err := row.Scan(&v)
 	if err != nil {
 		return nil, err
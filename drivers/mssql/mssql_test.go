@@ -4,6 +4,8 @@ package mssql
 
 import (
 	"database/sql"
+	"encoding/json"
+	"fmt"
 	"log"
 	"testing"
 
@@ -60,3 +62,43 @@ func TestInfo(t *testing.T) {
 		t.Errorf("got not empty string.")
 	}
 }
+
+func TestAnalyzeConcurrency(t *testing.T) {
+	want := &schema.Schema{Name: "testdb"}
+	sequential := New(db)
+	sequential.SetConcurrency(1)
+	if err := sequential.Analyze(want); err != nil {
+		t.Fatal(err)
+	}
+	got := &schema.Schema{Name: "testdb"}
+	concurrent := New(db)
+	concurrent.SetConcurrency(8)
+	if err := concurrent.Analyze(got); err != nil {
+		t.Fatal(err)
+	}
+	wantb, err := json.Marshal(want)
+	if err != nil {
+		t.Fatal(err)
+	}
+	gotb, err := json.Marshal(got)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(gotb) != string(wantb) {
+		t.Error("the result of concurrent analysis differs from that of sequential analysis")
+	}
+}
+
+func BenchmarkAnalyze(b *testing.B) {
+	for _, concurrency := range []int{1, 4, 8} {
+		b.Run(fmt.Sprintf("concurrency=%d", concurrency), func(b *testing.B) {
+			driver := New(db)
+			driver.SetConcurrency(concurrency)
+			for i := 0; i < b.N; i++ {
+				if err := driver.Analyze(&schema.Schema{Name: "testdb"}); err != nil {
+					b.Fatal(err)
+				}
+			}
+		})
+	}
+}
@@ -1,6 +1,7 @@
 package mysql
 
 import (
+	"context"
 	"database/sql"
 	"fmt"
 	"regexp"
@@ -36,6 +37,9 @@ type Mysql struct {
 	logicalNameFallbackToName      bool
 	tableLogicalNameDelimiter      string
 	tableLogicalNameFallbackToName bool
+
+	// Number of tables analyzed concurrently
+	concurrency int
 }
 
 func ShowAutoIcrrement() drivers.Option {
@@ -66,6 +70,7 @@ func New(db *sql.DB, opts ...drivers.Option) (*Mysql, error) {
 		logicalNameFallbackToName:      false,
 		tableLogicalNameDelimiter:      "|",
 		tableLogicalNameFallbackToName: false,
+		concurrency:                    drivers.DefaultConcurrency,
 	}
 	for _, opt := range opts {
 		err := opt(m)
@@ -88,9 +93,19 @@ func (m *Mysql) SetTableLogicalNameConfig(delimiter string, fallbackToName bool)
 	m.tableLogicalNameFallbackToName = fallbackToName
 }
 
+// SetConcurrency sets the number of tables analyzed concurrently.
+func (m *Mysql) SetConcurrency(n int) {
+	m.concurrency = n
+}
+
 // Analyze MySQL database schema.
 func (m *Mysql) Analyze(s *schema.Schema) error {
-	d, err := m.Info()
+	return m.AnalyzeContext(context.Background(), s)
+}
+
+// AnalyzeContext MySQL database schema with context.
+func (m *Mysql) AnalyzeContext(ctx context.Context, s *schema.Schema) error {
+	d, err := m.info(ctx)
 	if err != nil {
 		return errors.WithStack(err)
 	}
@@ -138,7 +153,7 @@ func (m *Mysql) Analyze(s *schema.Schema) error {
 	}
 
 	// bulk get indexes
-	indexRows, err := m.db.Query(`
+	indexRows, err := m.db.QueryContext(ctx, `
 SELECT
 s.table_name,
 (CASE WHEN s.index_name='PRIMARY' AND s.non_unique=0 THEN 'PRIMARY KEY'
@@ -188,7 +203,7 @@ GROUP BY s.table_name, key_type, s.table_name, s.index_name, s.index_type`, s.Na
 	}
 
 	// bulk get triggers
-	triggerRows, err := m.db.Query(`
+	triggerRows, err := m.db.QueryContext(ctx, `
 SELECT
   event_object_table,
   trigger_name,
@@ -239,7 +254,7 @@ SELECT table_name, column_name, column_default, is_nullable, column_type, column
 FROM information_schema.columns
 WHERE table_schema = ? ORDER BY table_name, ordinal_position`
 	}
-	columnRows, err := m.db.Query(columnStmt, s.Name)
+	columnRows, err := m.db.QueryContext(ctx, columnStmt, s.Name)
 	if err != nil {
 		return errors.WithStack(err)
 	}
@@ -296,7 +311,7 @@ WHERE table_schema = ? ORDER BY table_name, ordinal_position`
 	}
 
 	// tables and comments
-	tableRows, err := m.db.Query(m.queryForTables(), s.Name)
+	tableRows, err := m.db.QueryContext(ctx, m.queryForTables(), s.Name)
 	if err != nil {
 		return errors.WithStack(err)
 	}
@@ -329,55 +344,6 @@ WHERE table_schema = ? ORDER BY table_name, ordinal_position`
 			table.SetLogicalNameFromComment(m.tableLogicalNameDelimiter, m.tableLogicalNameFallbackToName)
 		}
 
-		// table definition
-		if tableType == "BASE TABLE" {
-			tableDefRows, err := m.db.Query(fmt.Sprintf("SHOW CREATE TABLE `%s`", tableName))
-			if err != nil {
-				return errors.WithStack(err)
-			}
-			defer tableDefRows.Close()
-			for tableDefRows.Next() {
-				var (
-					tableName string
-					tableDef  string
-				)
-				err := tableDefRows.Scan(&tableName, &tableDef)
-				if err != nil {
-					return errors.WithStack(err)
-				}
-
-				switch {
-				case m.showAutoIncrement:
-					table.Def = tableDef
-				case m.hideAutoIncrement:
-					table.Def = reAI.ReplaceAllLiteralString(tableDef, "")
-				default:
-					table.Def = reAI.ReplaceAllLiteralString(tableDef, " AUTO_INCREMENT=[Redacted by tbls]")
-				}
-			}
-		}
-
-		// view definition
-		if tableType == "VIEW" {
-			viewDefRows, err := m.db.Query(`
-SELECT view_definition FROM information_schema.views
-WHERE table_schema = ?
-AND table_name = ?;
-		`, s.Name, tableName)
-			if err != nil {
-				return errors.WithStack(err)
-			}
-			defer viewDefRows.Close()
-			for viewDefRows.Next() {
-				var tableDef string
-				err := viewDefRows.Scan(&tableDef)
-				if err != nil {
-					return errors.WithStack(err)
-				}
-				table.Def = fmt.Sprintf("CREATE VIEW %s AS (%s)", tableName, tableDef)
-			}
-		}
-
 		// indexes
 		table.Indexes = tableIndexes[table.Name]
 
@@ -392,9 +358,47 @@ AND table_name = ?;
 		tableOrderMap[table.Name] = tableOrder
 		tableOrder++
 	}
+	if err := tableRows.Err(); err != nil {
+		return errors.WithStack(err)
+	}
+	_ = tableRows.Close()
+
+	// bulk get view definitions
+	viewDefRows, err := m.db.QueryContext(ctx, `
+SELECT table_name, view_definition FROM information_schema.views
+WHERE table_schema = ?;
+`, s.Name)
+	if err != nil {
+		return errors.WithStack(err)
+	}
+	defer viewDefRows.Close()
+	for viewDefRows.Next() {
+		var (
+			tableName string
+			tableDef  string
+		)
+		if err := viewDefRows.Scan(&tableName, &tableDef); err != nil {
+			return errors.WithStack(err)
+		}
+		table, ok := tableMap[tableName]
+		if !ok || table.Type != "VIEW" {
+			continue
+		}
+		table.Def = fmt.Sprintf("CREATE VIEW %s AS (%s)", tableName, tableDef)
+	}
+
+	// table definitions
+	if err := drivers.ForEach(ctx, len(tables), m.concurrency, func(ctx context.Context, i int) error {
+		if tables[i].Type != "BASE TABLE" {
+			return nil
+		}
+		return m.setTableDef(ctx, tables[i])
+	}); err != nil {
+		return err
+	}
 
 	// bulk get constraints (PRIMARY KEY, UNIQUE, FOREIGN KEY)
-	constraintRows, err := m.db.Query(`
+	constraintRows, err := m.db.QueryContext(ctx, `
 SELECT
   kcu.table_name,
   kcu.constraint_name,
@@ -483,7 +487,7 @@ GROUP BY kcu.table_name, kcu.constraint_name, sub.costraint_type, kcu.referenced
 
 	// bulk get constraints (CHECK)
 	if supportCheckConstraint {
-		constraintRows, err := m.db.Query(`
+		constraintRows, err := m.db.QueryContext(ctx, `
 SELECT
   t.table_name,
   c.constraint_name,
@@ -517,7 +521,7 @@ WHERE t.table_schema = ?
 		}
 	}
 
-	functions, err := m.getFunctions()
+	functions, err := m.getFunctions(ctx)
 	if err != nil {
 		return err
 	}
@@ -578,6 +582,35 @@ WHERE t.table_schema = ?
 	return nil
 }
 
+// setTableDef sets the result of SHOW CREATE TABLE to the table.
+func (m *Mysql) setTableDef(ctx context.Context, table *schema.Table) error {
+	tableDefRows, err := m.db.QueryContext(ctx, fmt.Sprintf("SHOW CREATE TABLE `%s`", table.Name))
+	if err != nil {
+		return errors.WithStack(err)
+	}
+	defer tableDefRows.Close()
+	for tableDefRows.Next() {
+		var (
+			tableName string
+			tableDef  string
+		)
+		err := tableDefRows.Scan(&tableName, &tableDef)
+		if err != nil {
+			return errors.WithStack(err)
+		}
+
+		switch {
+		case m.showAutoIncrement:
+			table.Def = tableDef
+		case m.hideAutoIncrement:
+			table.Def = reAI.ReplaceAllLiteralString(tableDef, "")
+		default:
+			table.Def = reAI.ReplaceAllLiteralString(tableDef, " AUTO_INCREMENT=[Redacted by tbls]")
+		}
+	}
+	return nil
+}
+
 const queryFunctions = `SELECT r.routine_schema as database_name,
 r.routine_name,
 r.routine_type AS type,
@@ -590,9 +623,9 @@ LEFT JOIN information_schema.parameters p
 WHERE routine_schema NOT IN ('sys', 'information_schema', 'mysql', 'performance_schema')
 GROUP BY r.routine_schema, r.routine_name, r.routine_type, r.data_type, r.routine_definition`
 
-func (m *Mysql) getFunctions() ([]*schema.Function, error) {
+func (m *Mysql) getFunctions(ctx context.Context) ([]*schema.Function, error) {
 	functions := []*schema.Function{}
-	functionsResult, err := m.db.Query(queryFunctions)
+	functionsResult, err := m.db.QueryContext(ctx, queryFunctions)
 	if err != nil {
 		return nil, errors.WithStack(err)
 	}
@@ -624,8 +657,12 @@ func (m *Mysql) getFunctions() ([]*schema.Function, error) {
 
 // Info return schema.Driver.
 func (m *Mysql) Info() (*schema.Driver, error) {
+	return m.info(context.Background())
+}
+
+func (m *Mysql) info(ctx context.Context) (*schema.Driver, error) {
 	var v string
-	row := m.db.QueryRow(`SELECT version();`)
+	row := m.db.QueryRowContext(ctx, `SELECT version();`)
 	err := row.Scan(&v)
 	if err != nil {
 		return nil, err
@@ -1,6 +1,7 @@
 package postgres
 
 import (
+	"context"
 	"database/sql"
 	"fmt"
 	"regexp"
@@ -10,6 +11,7 @@ import (
 	"github.com/k1LoW/errors"
 	"github.com/k1LoW/tbls/ddl"
 	"github.com/k1LoW/tbls/dict"
+	"github.com/k1LoW/tbls/drivers"
 	"github.com/k1LoW/tbls/schema"
 	"github.com/lib/pq"
 )
@@ -25,6 +27,7 @@ type Postgres struct {
 	logicalNameFallbackToName      bool
 	tableLogicalNameDelimiter      string
 	tableLogicalNameFallbackToName bool
+	concurrency                    int
 }
 
 // New return new Postgres.
@@ -36,6 +39,7 @@ func New(db *sql.DB) *Postgres {
 		logicalNameFallbackToName:      false,
 		tableLogicalNameDelimiter:      "|",
 		tableLogicalNameFallbackToName: false,
+		concurrency:                    drivers.DefaultConcurrency,
 	}
 }
 
@@ -51,12 +55,22 @@ func (p *Postgres) SetTableLogicalNameConfig(delimiter string, fallbackToName bo
 	p.tableLogicalNameFallbackToName = fallbackToName
 }
 
+// SetConcurrency sets the number of tables analyzed concurrently.
+func (p *Postgres) SetConcurrency(n int) {
+	p.concurrency = n
+}
+
 // Analyze PostgreSQL database schema.
-func (p *Postgres) Analyze(s *schema.Schema) (err error) {
+func (p *Postgres) Analyze(s *schema.Schema) error {
+	return p.AnalyzeContext(context.Background(), s)
+}
+
+// AnalyzeContext PostgreSQL database schema with context.
+func (p *Postgres) AnalyzeContext(ctx context.Context, s *schema.Schema) (err error) {
 	defer func() {
 		err = errors.WithStack(err)
 	}()
-	d, err := p.Info()
+	d, err := p.info(ctx)
 	if err != nil {
 		return errors.WithStack(err)
 	}
@@ -64,7 +78,7 @@ func (p *Postgres) Analyze(s *schema.Schema) (err error) {
 
 	// current schema
 	var currentSchema sql.NullString
-	schemaRows, err := p.db.Query(`SELECT current_schema()`)
+	schemaRows, err := p.db.QueryContext(ctx, `SELECT current_schema()`)
 	if err != nil {
 		return errors.WithStack(err)
 	}
@@ -82,7 +96,7 @@ func (p *Postgres) Analyze(s *schema.Schema) (err error) {
 
 	// search_path
 	var searchPaths string
-	pathRows, err := p.db.Query(`SHOW search_path`)
+	pathRows, err := p.db.QueryContext(ctx, `SHOW search_path`)
 	if err != nil {
 		return errors.WithStack(err)
 	}
@@ -98,7 +112,7 @@ func (p *Postgres) Analyze(s *schema.Schema) (err error) {
 	for idx, path := range splitPaths {
 		if path == `"$user"` {
 			var userName string
-			userNameRows, err := p.db.Query(`SELECT current_user`)
+			userNameRows, err := p.db.QueryContext(ctx, `SELECT current_user`)
 			if err != nil {
 				return errors.WithStack(err)
 			}
@@ -118,7 +132,7 @@ func (p *Postgres) Analyze(s *schema.Schema) (err error) {
 	fullTableNames := []string{}
 
 	// tables
-	tableRows, err := p.db.Query(`
+	tableRows, err := p.db.QueryContext(ctx, `
 SELECT
     cls.oid AS oid,
     cls.relname AS table_name,
@@ -143,6 +157,7 @@ ORDER BY oid`)
 
 	relations := []*schema.Relation{}
 
+	pgTables := []*pgTable{}
 	tables := []*schema.Table{}
 	for tableRows.Next() {
 		var (
@@ -172,196 +187,47 @@ ORDER BY oid`)
 			table.SetLogicalNameFromComment(p.tableLogicalNameDelimiter, p.tableLogicalNameFallbackToName)
 		}
 
-		// (materialized) view definition
-		if tableType == "VIEW" || tableType == "MATERIALIZED VIEW" {
-			viewDefRows, err := p.db.Query(`SELECT pg_get_viewdef($1::oid);`, tableOid)
-			if err != nil {
-				return errors.WithStack(err)
-			}
-			defer viewDefRows.Close()
-			for viewDefRows.Next() {
-				var tableDef sql.NullString
-				err := viewDefRows.Scan(&tableDef)
-				if err != nil {
-					return errors.WithStack(err)
-				}
-				table.Def = fmt.Sprintf("CREATE %s %s AS (\n%s\n)", tableType, tableName, strings.TrimRight(tableDef.String, ";"))
-			}
-		}
-
-		// constraints
-		constraintRows, err := p.db.Query(p.queryForConstraints(), tableOid)
-		if err != nil {
-			return errors.WithStack(err)
-		}
-		defer constraintRows.Close()
-
-		constraints := []*schema.Constraint{}
-
-		for constraintRows.Next() {
-			var (
-				constraintName                  string
-				constraintDef                   string
-				constraintType                  string
-				constraintReferencedTable       sql.NullString
-				constraintColumnNames           []sql.NullString
-				constraintReferencedColumnNames []sql.NullString
-				constraintComment               sql.NullString
-			)
-			err = constraintRows.Scan(&constraintName, &constraintDef, &constraintType, &constraintReferencedTable, pq.Array(&constraintColumnNames), pq.Array(&constraintReferencedColumnNames), &constraintComment)
-			if err != nil {
-				return errors.WithStack(err)
-			}
-			rt := constraintReferencedTable.String
-			constraint := &schema.Constraint{
-				Name:              constraintName,
-				Type:              convertConstraintType(constraintType),
-				Def:               constraintDef,
-				Table:             &table.Name,
-				Columns:           arrayRemoveNull(constraintColumnNames),
-				ReferencedTable:   &rt,
-				ReferencedColumns: arrayRemoveNull(constraintReferencedColumnNames),
-				Comment:           constraintComment.String,
-			}
-
-			if constraintType == "f" {
-				relation := &schema.Relation{
-					Table: table,
-					Def:   constraintDef,
-				}
-				relations = append(relations, relation)
-			}
-			constraints = append(constraints, constraint)
-		}
-		table.Constraints = constraints
-
-		// triggers
-		if !p.rsMode {
-			triggerRows, err := p.db.Query(`
-SELECT tgname, pg_get_triggerdef(trig.oid), descr.description AS comment
-FROM pg_trigger AS trig
-LEFT JOIN pg_description AS descr ON trig.oid = descr.objoid
-WHERE tgisinternal = false
-AND tgrelid = $1::oid
-ORDER BY tgrelid
-`, tableOid)
-			if err != nil {
-				return errors.WithStack(err)
-			}
-			defer triggerRows.Close()
-
-			triggers := []*schema.Trigger{}
-			for triggerRows.Next() {
-				var (
-					triggerName    string
-					triggerDef     string
-					triggerComment sql.NullString
-				)
-				err = triggerRows.Scan(&triggerName, &triggerDef, &triggerComment)
-				if err != nil {
-					return errors.WithStack(err)
-				}
-				trigger := &schema.Trigger{
-					Name:    triggerName,
-					Def:     triggerDef,
-					Comment: triggerComment.String,
-				}
-				triggers = append(triggers, trigger)
-			}
-			table.Triggers = triggers
-		}
-
-		// columns
-		columnStmt, err := p.queryForColumns(s.Driver.DatabaseVersion)
-		if err != nil {
-			return errors.WithStack(err)
-		}
-		columnRows, err := p.db.Query(columnStmt, tableOid)
-		if err != nil {
-			return errors.WithStack(err)
-		}
-		defer columnRows.Close()
-
-		columns := []*schema.Column{}
-		for columnRows.Next() {
-			var (
-				columnName               string
-				columnDefaultOrGenerated sql.NullString
-				attrgenerated            sql.NullString
-				isNullable               bool
-				dataType                 string
-				columnComment            sql.NullString
-			)
-			err = columnRows.Scan(&columnName, &columnDefaultOrGenerated, &attrgenerated, &isNullable, &dataType, &columnComment)
-			if err != nil {
-				return errors.WithStack(err)
-			}
-			column := &schema.Column{
-				Name:     columnName,
-				Type:     dataType,
-				Nullable: isNullable,
-				Comment:  columnComment.String,
-			}
-
-			// 論理名をコメントから抽出（設定値を使用）
-			if columnComment.Valid && columnComment.String != "" {
-				column.SetLogicalNameFromComment(p.logicalNameDelimiter, p.logicalNameFallbackToName)
-			}
-
-			switch attrgenerated.String {
-			case "":
-				column.Default = columnDefaultOrGenerated
-			case "s":
-				column.ExtraDef = fmt.Sprintf("GENERATED ALWAYS AS %s STORED", columnDefaultOrGenerated.String)
-			default:
-				return fmt.Errorf("unsupported pg_attribute.attrgenerated '%s'", attrgenerated.String)
-			}
-			columns = append(columns, column)
-		}
-		table.Columns = columns
+		pgTables = append(pgTables, &pgTable{
+			oid:       tableOid,
+			tableName: tableName,
+			tableType: tableType,
+			table:     table,
+		})
+		tables = append(tables, table)
+	}
+	if err := tableRows.Err(); err != nil {
+		return errors.WithStack(err)
+	}
+	_ = tableRows.Close()
 
-		// indexes
-		indexRows, err := p.db.Query(p.queryForIndexes(), tableOid)
+	// columns, constraints, triggers and indexes of each table
+	columnStmt, err := p.queryForColumns(s.Driver.DatabaseVersion)
+	if err != nil {
+		return errors.WithStack(err)
+	}
+	tableRelations := make([][]*schema.Relation, len(pgTables))
+	if err := drivers.ForEach(ctx, len(pgTables), p.concurrency, func(ctx context.Context, i int) error {
+		rs, err := p.analyzeTable(ctx, pgTables[i], columnStmt)
 		if err != nil {
-			return errors.WithStack(err)
-		}
-		defer indexRows.Close()
-
-		indexes := []*schema.Index{}
-		for indexRows.Next() {
-			var (
-				indexName        string
-				indexDef         string
-				indexColumnNames []sql.NullString
-				indexComment     sql.NullString
-			)
-			err = indexRows.Scan(&indexName, &indexDef, pq.Array(&indexColumnNames), &indexComment)
-			if err != nil {
-				return errors.WithStack(err)
-			}
-			index := &schema.Index{
-				Name:    indexName,
-				Def:     indexDef,
-				Table:   &table.Name,
-				Columns: arrayRemoveNull(indexColumnNames),
-				Comment: indexComment.String,
-			}
-
-			indexes = append(indexes, index)
+			return err
 		}
-		table.Indexes = indexes
-
-		tables = append(tables, table)
+		tableRelations[i] = rs
+		return nil
+	}); err != nil {
+		return err
+	}
+	for _, rs := range tableRelations {
+		relations = append(relations, rs...)
 	}
 
-	functions, err := p.getFunctions()
+	functions, err := p.getFunctions(ctx)
 	if err != nil {
 		return err
 	}
 	s.Functions = functions
 
 	// Enums
-	enums, err := p.getEnums()
+	enums, err := p.getEnums(ctx)
 	if err != nil {
 		return err
 	}
@@ -438,6 +304,199 @@ LEFT JOIN pg_type AS t ON t.oid = p.prorettype
 WHERE n.nspname NOT IN ('pg_catalog', 'information_schema')
 ORDER BY p.oid;`
 
+// pgTable is a table to be analyzed by analyzeTable.
+type pgTable struct {
+	oid       uint64
+	tableName string
+	tableType string
+	table     *schema.Table
+}
+
+// analyzeTable sets the definition, constraints, triggers, columns and indexes of the table and returns the relations of the table.
+func (p *Postgres) analyzeTable(ctx context.Context, t *pgTable, columnStmt string) (_ []*schema.Relation, err error) {
+	defer func() {
+		err = errors.WithStack(err)
+	}()
+	relations := []*schema.Relation{}
+
+	// (materialized) view definition
+	if t.tableType == "VIEW" || t.tableType == "MATERIALIZED VIEW" {
+		viewDefRows, err := p.db.QueryContext(ctx, `SELECT pg_get_viewdef($1::oid);`, t.oid)
+		if err != nil {
+			return nil, errors.WithStack(err)
+		}
+		defer viewDefRows.Close()
+		for viewDefRows.Next() {
+			var tableDef sql.NullString
+			err := viewDefRows.Scan(&tableDef)
+			if err != nil {
+				return nil, errors.WithStack(err)
+			}
+			t.table.Def = fmt.Sprintf("CREATE %s %s AS (\n%s\n)", t.tableType, t.tableName, strings.TrimRight(tableDef.String, ";"))
+		}
+	}
+
+	// constraints
+	constraintRows, err := p.db.QueryContext(ctx, p.queryForConstraints(), t.oid)
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+	defer constraintRows.Close()
+
+	constraints := []*schema.Constraint{}
+
+	for constraintRows.Next() {
+		var (
+			constraintName                  string
+			constraintDef                   string
+			constraintType                  string
+			constraintReferencedTable       sql.NullString
+			constraintColumnNames           []sql.NullString
+			constraintReferencedColumnNames []sql.NullString
+			constraintComment               sql.NullString
+		)
+		err = constraintRows.Scan(&constraintName, &constraintDef, &constraintType, &constraintReferencedTable, pq.Array(&constraintColumnNames), pq.Array(&constraintReferencedColumnNames), &constraintComment)
+		if err != nil {
+			return nil, errors.WithStack(err)
+		}
+		rt := constraintReferencedTable.String
+		constraint := &schema.Constraint{
+			Name:              constraintName,
+			Type:              convertConstraintType(constraintType),
+			Def:               constraintDef,
+			Table:             &t.table.Name,
+			Columns:           arrayRemoveNull(constraintColumnNames),
+			ReferencedTable:   &rt,
+			ReferencedColumns: arrayRemoveNull(constraintReferencedColumnNames),
+			Comment:           constraintComment.String,
+		}
+
+		if constraintType == "f" {
+			relation := &schema.Relation{
+				Table: t.table,
+				Def:   constraintDef,
+			}
+			relations = append(relations, relation)
+		}
+		constraints = append(constraints, constraint)
+	}
+	t.table.Constraints = constraints
+
+	// triggers
+	if !p.rsMode {
+		triggerRows, err := p.db.QueryContext(ctx, `
+SELECT tgname, pg_get_triggerdef(trig.oid), descr.description AS comment
+FROM pg_trigger AS trig
+LEFT JOIN pg_description AS descr ON trig.oid = descr.objoid
+WHERE tgisinternal = false
+AND tgrelid = $1::oid
+ORDER BY tgrelid
+`, t.oid)
+		if err != nil {
+			return nil, errors.WithStack(err)
+		}
+		defer triggerRows.Close()
+
+		triggers := []*schema.Trigger{}
+		for triggerRows.Next() {
+			var (
+				triggerName    string
+				triggerDef     string
+				triggerComment sql.NullString
+			)
+			err = triggerRows.Scan(&triggerName, &triggerDef, &triggerComment)
+			if err != nil {
+				return nil, errors.WithStack(err)
+			}
+			trigger := &schema.Trigger{
+				Name:    triggerName,
+				Def:     triggerDef,
+				Comment: triggerComment.String,
+			}
+			triggers = append(triggers, trigger)
+		}
+		t.table.Triggers = triggers
+	}
+
+	// columns
+	columnRows, err := p.db.QueryContext(ctx, columnStmt, t.oid)
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+	defer columnRows.Close()
+
+	columns := []*schema.Column{}
+	for columnRows.Next() {
+		var (
+			columnName               string
+			columnDefaultOrGenerated sql.NullString
+			attrgenerated            sql.NullString
+			isNullable               bool
+			dataType                 string
+			columnComment            sql.NullString
+		)
+		err = columnRows.Scan(&columnName, &columnDefaultOrGenerated, &attrgenerated, &isNullable, &dataType, &columnComment)
+		if err != nil {
+			return nil, errors.WithStack(err)
+		}
+		column := &schema.Column{
+			Name:     columnName,
+			Type:     dataType,
+			Nullable: isNullable,
+			Comment:  columnComment.String,
+		}
+
+		// 論理名をコメントから抽出（設定値を使用）
+		if columnComment.Valid && columnComment.String != "" {
+			column.SetLogicalNameFromComment(p.logicalNameDelimiter, p.logicalNameFallbackToName)
+		}
+
+		switch attrgenerated.String {
+		case "":
+			column.Default = columnDefaultOrGenerated
+		case "s":
+			column.ExtraDef = fmt.Sprintf("GENERATED ALWAYS AS %s STORED", columnDefaultOrGenerated.String)
+		default:
+			return nil, fmt.Errorf("unsupported pg_attribute.attrgenerated '%s'", attrgenerated.String)
+		}
+		columns = append(columns, column)
+	}
+	t.table.Columns = columns
+
+	// indexes
+	indexRows, err := p.db.QueryContext(ctx, p.queryForIndexes(), t.oid)
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+	defer indexRows.Close()
+
+	indexes := []*schema.Index{}
+	for indexRows.Next() {
+		var (
+			indexName        string
+			indexDef         string
+			indexColumnNames []sql.NullString
+			indexComment     sql.NullString
+		)
+		err = indexRows.Scan(&indexName, &indexDef, pq.Array(&indexColumnNames), &indexComment)
+		if err != nil {
+			return nil, errors.WithStack(err)
+		}
+		index := &schema.Index{
+			Name:    indexName,
+			Def:     indexDef,
+			Table:   &t.table.Name,
+			Columns: arrayRemoveNull(indexColumnNames),
+			Comment: indexComment.String,
+		}
+
+		indexes = append(indexes, index)
+	}
+	t.table.Indexes = indexes
+
+	return relations, nil
+}
+
 const queryFunctions = `SELECT
   n.nspname AS schema_name,
   p.proname AS specific_name,
@@ -454,8 +513,8 @@ const queryStoredProcedureSupported = `SELECT column_name
 FROM information_schema.columns
 WHERE table_name='pg_proc' and column_name='prokind';`
 
-func (p *Postgres) isProceduresSupported() (bool, error) {
-	result, err := p.db.Query(queryStoredProcedureSupported)
+func (p *Postgres) isProceduresSupported(ctx context.Context) (bool, error) {
+	result, err := p.db.QueryContext(ctx, queryStoredProcedureSupported)
 	if err != nil {
 		return false, errors.WithStack(err)
 	}
@@ -474,9 +533,9 @@ func (p *Postgres) isProceduresSupported() (bool, error) {
 	return false, nil
 }
 
-func (p *Postgres) getFunctions() ([]*schema.Function, error) {
+func (p *Postgres) getFunctions(ctx context.Context) ([]*schema.Function, error) {
 	var functions []*schema.Function
-	storedProcedureSupported, err := p.isProceduresSupported()
+	storedProcedureSupported, err := p.isProceduresSupported(ctx)
 	if err != nil {
 		return nil, errors.WithStack(err)
 	}
@@ -485,12 +544,12 @@ func (p *Postgres) getFunctions() ([]*schema.Function, error) {
 		return functions, nil
 	}
 	if storedProcedureSupported {
-		functions, err = p.getFunctionsByQuery(queryFunctions)
+		functions, err = p.getFunctionsByQuery(ctx, queryFunctions)
 		if err != nil {
 			return nil, errors.WithStack(err)
 		}
 	} else {
-		functions, err = p.getFunctionsByQuery(queryFunctions95)
+		functions, err = p.getFunctionsByQuery(ctx, queryFunctions95)
 		if err != nil {
 			return nil, errors.WithStack(err)
 		}
@@ -498,9 +557,9 @@ func (p *Postgres) getFunctions() ([]*schema.Function, error) {
 	return functions, nil
 }
 
-func (p *Postgres) getFunctionsByQuery(query string) ([]*schema.Function, error) {
+func (p *Postgres) getFunctionsByQuery(ctx context.Context, query string) ([]*schema.Function, error) {
 	functions := []*schema.Function{}
-	functionsResult, err := p.db.Query(query)
+	functionsResult, err := p.db.QueryContext(ctx, query)
 	if err != nil {
 		return nil, errors.WithStack(err)
 	}
@@ -530,10 +589,10 @@ func (p *Postgres) getFunctionsByQuery(query string) ([]*schema.Function, error)
 	return functions, nil
 }
 
-func (p *Postgres) getEnums() ([]*schema.Enum, error) {
+func (p *Postgres) getEnums(ctx context.Context) ([]*schema.Enum, error) {
 	enums := []*schema.Enum{}
 
-	enumsResult, err := p.db.Query(`SELECT n.nspname, t.typname AS enum_name, ARRAY_AGG(e.enumlabel) AS enum_values
+	enumsResult, err := p.db.QueryContext(ctx, `SELECT n.nspname, t.typname AS enum_name, ARRAY_AGG(e.enumlabel) AS enum_values
 											FROM pg_type t, pg_enum e, pg_catalog.pg_namespace n
 											WHERE t.typcategory = 'E'
 											  AND t.oid = e.enumtypid
@@ -571,8 +630,12 @@ func fullTableName(owner string, tableName string) string {
 
 // Info return schema.Driver.
 func (p *Postgres) Info() (*schema.Driver, error) {
+	return p.info(context.Background())
+}
+
+func (p *Postgres) info(ctx context.Context) (*schema.Driver, error) {
 	var v string
-	row := p.db.QueryRow(`SELECT version();`)
+	row := p.db.QueryRowContext(ctx, `SELECT version();`)
 	err := row.Scan(&v)
 	if err != nil {
 		return nil, err
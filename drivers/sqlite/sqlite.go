@@ -1,6 +1,7 @@
 package sqlite
 
 import (
+	"context"
 	"database/sql"
 	"fmt"
 	"sort"
@@ -62,18 +63,23 @@ type fk struct {
 }
 
 // Analyze SQLite database schema.
-func (l *Sqlite) Analyze(s *schema.Schema) (err error) {
+func (l *Sqlite) Analyze(s *schema.Schema) error {
+	return l.AnalyzeContext(context.Background(), s)
+}
+
+// AnalyzeContext SQLite database schema with context.
+func (l *Sqlite) AnalyzeContext(ctx context.Context, s *schema.Schema) (err error) {
 	defer func() {
 		err = errors.WithStack(err)
 	}()
-	d, err := l.Info()
+	d, err := l.info(ctx)
 	if err != nil {
 		return errors.WithStack(err)
 	}
 	s.Driver = d
 
 	// tables
-	tableRows, err := l.db.Query(`
+	tableRows, err := l.db.QueryContext(ctx, `
 SELECT name, type, sql
 FROM sqlite_master
 WHERE name != 'sqlite_sequence' AND (type = 'table' OR type = 'view');`)
@@ -126,7 +132,7 @@ WHERE name != 'sqlite_sequence' AND (type = 'table' OR type = 'view');`)
 		constraints := []*schema.Constraint{}
 
 		// columns
-		columnRows, err := l.db.Query(fmt.Sprintf("PRAGMA table_info(`%s`)", tableName))
+		columnRows, err := l.db.QueryContext(ctx, fmt.Sprintf("PRAGMA table_info(`%s`)", tableName))
 		if err != nil {
 			return errors.WithStack(err)
 		}
@@ -178,7 +184,7 @@ WHERE name != 'sqlite_sequence' AND (type = 'table' OR type = 'view');`)
 		fkMap := map[string]*fk{}
 		fkSlice := []*fk{}
 
-		foreignKeyRows, err := l.db.Query(fmt.Sprintf("PRAGMA foreign_key_list(`%s`)", tableName))
+		foreignKeyRows, err := l.db.QueryContext(ctx, fmt.Sprintf("PRAGMA foreign_key_list(`%s`)", tableName))
 		if err != nil {
 			return errors.WithStack(err)
 		}
@@ -253,7 +259,7 @@ WHERE name != 'sqlite_sequence' AND (type = 'table' OR type = 'view');`)
 		}
 
 		// indexes and constraints(UNIQUE, PRIMARY KEY)
-		indexRows, err := l.db.Query(fmt.Sprintf("PRAGMA index_list(`%s`)", tableName))
+		indexRows, err := l.db.QueryContext(ctx, fmt.Sprintf("PRAGMA index_list(`%s`)", tableName))
 		if err != nil {
 			return errors.WithStack(err)
 		}
@@ -286,7 +292,7 @@ WHERE name != 'sqlite_sequence' AND (type = 'table' OR type = 'view');`)
 				col                sql.NullString
 				cols               []string
 			)
-			row, err := l.db.Query(fmt.Sprintf("PRAGMA index_info(`%s`)", indexName))
+			row, err := l.db.QueryContext(ctx, fmt.Sprintf("PRAGMA index_info(`%s`)", indexName))
 			if err != nil {
 				return errors.WithStack(err)
 			}
@@ -306,7 +312,7 @@ WHERE name != 'sqlite_sequence' AND (type = 'table' OR type = 'view');`)
 
 			switch indexCreatedBy {
 			case "c":
-				row, err := l.db.Query(`SELECT sql FROM sqlite_master WHERE type = 'index' AND tbl_name = ? AND name = ?;
+				row, err := l.db.QueryContext(ctx, `SELECT sql FROM sqlite_master WHERE type = 'index' AND tbl_name = ? AND name = ?;
 `, tableName, indexName)
 				if err != nil {
 					return errors.WithStack(err)
@@ -351,7 +357,7 @@ WHERE name != 'sqlite_sequence' AND (type = 'table' OR type = 'view');`)
 		}
 
 		// triggers
-		triggerRows, err := l.db.Query(`
+		triggerRows, err := l.db.QueryContext(ctx, `
 SELECT name, sql FROM sqlite_master WHERE type = 'trigger' AND tbl_name = ?;
 `, tableName)
 		if err != nil {
@@ -451,8 +457,12 @@ SELECT name, sql FROM sqlite_master WHERE type = 'trigger' AND tbl_name = ?;
 
 // Info return schema.Driver.
 func (l *Sqlite) Info() (*schema.Driver, error) {
+	return l.info(context.Background())
+}
+
+func (l *Sqlite) info(ctx context.Context) (*schema.Driver, error) {
 	var v string
-	row := l.db.QueryRow(`SELECT sqlite_version();`)
+	row := l.db.QueryRowContext(ctx, `SELECT sqlite_version();`)
 	err := row.Scan(&v)
 	if err != nil {
 		return nil, err
@@ -0,0 +1,200 @@
+package glossary
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/goccy/go-yaml"
+	"github.com/k1LoW/errors"
+	"github.com/k1LoW/tbls/schema"
+)
+
+// FileName is the file name of the glossary document.
+const FileName = "glossary.md"
+
+// Term is a business term.
+type Term struct {
+	Name       string   `yaml:"name" json:"name"`
+	Definition string   `yaml:"definition,omitempty" json:"definition,omitempty"`
+	Synonyms   []string `yaml:"synonyms,omitempty" json:"synonyms,omitempty"`
+	Owners     []string `yaml:"owners,omitempty" json:"owners,omitempty"`
+}
+
+// Reference is a column whose comment or logical name contains a term.
+type Reference struct {
+	Table  string
+	Column string
+}
+
+// Glossary is the set of business terms.
+type Glossary struct {
+	terms []*Term
+	words []word
+}
+
+// word is a name or synonym of a term.
+type word struct {
+	text string
+	term *Term
+}
+
+// protectedRe matches code spans, links and URLs that should not be linked.
+var protectedRe = regexp.MustCompile("`[^`]*`|\\[[^\\]]*\\]\\([^)]*\\)|https?://[^\\s)]+")
+
+// New return Glossary. Terms are sorted by name.
+func New(terms []*Term) *Glossary {
+	g := &Glossary{}
+	for _, t := range terms {
+		g.terms = append(g.terms, t)
+		for _, w := range append([]string{t.Name}, t.Synonyms...) {
+			if w == "" {
+				continue
+			}
+			g.words = append(g.words, word{text: w, term: t})
+		}
+	}
+	sort.SliceStable(g.terms, func(i, j int) bool {
+		return g.terms[i].Name < g.terms[j].Name
+	})
+	// match the longest word first
+	sort.SliceStable(g.words, func(i, j int) bool {
+		return len(g.words[i].text) > len(g.words[j].text)
+	})
+	return g
+}
+
+// LoadFile load terms from the glossary file.
+func LoadFile(path string) (_ []*Term, err error) {
+	defer func() {
+		err = errors.WithStack(err)
+	}()
+	b, err := os.ReadFile(filepath.Clean(path))
+	if err != nil {
+		return nil, fmt.Errorf("failed to load glossary file: %w", err)
+	}
+	f := struct {
+		Terms []*Term `yaml:"terms"`
+	}{}
+	if err := yaml.Unmarshal(b, &f); err != nil {
+		return nil, fmt.Errorf("failed to load glossary file %s: %w", path, err)
+	}
+	return f.Terms, nil
+}
+
+// Terms return terms sorted by name.
+func (g *Glossary) Terms() []*Term {
+	return g.terms
+}
+
+// Link replaces the first occurrence of each term in text with a markdown link generated by href.
+// Code spans, links and URLs in text are left as they are.
+func (g *Glossary) Link(text string, href func(*Term) string) string {
+	if text == "" || len(g.words) == 0 {
+		return text
+	}
+	linked := map[*Term]bool{}
+	var b strings.Builder
+	pos := 0
+	for _, loc := range protectedRe.FindAllStringIndex(text, -1) {
+		b.WriteString(g.link(text[pos:loc[0]], href, linked))
+		b.WriteString(text[loc[0]:loc[1]])
+		pos = loc[1]
+	}
+	b.WriteString(g.link(text[pos:], href, linked))
+	return b.String()
+}
+
+func (g *Glossary) link(text string, href func(*Term) string, linked map[*Term]bool) string {
+	var b strings.Builder
+	for i := 0; i < len(text); {
+		if w, ok := g.match(text, i, linked); ok {
+			b.WriteString(fmt.Sprintf("[%s](%s)", text[i:i+len(w.text)], href(w.term)))
+			linked[w.term] = true
+			i += len(w.text)
+			continue
+		}
+		_, size := utf8.DecodeRuneInString(text[i:])
+		b.WriteString(text[i : i+size])
+		i += size
+	}
+	return b.String()
+}
+
+// Contains reports whether text contains the term.
+func (g *Glossary) Contains(text string, t *Term) bool {
+	for i := 0; i < len(text); {
+		if w, ok := g.match(text, i, nil); ok {
+			if w.term == t {
+				return true
+			}
+			i += len(w.text)
+			continue
+		}
+		_, size := utf8.DecodeRuneInString(text[i:])
+		i += size
+	}
+	return false
+}
+
+// match returns the longest word starting at i, skipping terms already linked.
+func (g *Glossary) match(text string, i int, linked map[*Term]bool) (word, bool) {
+	for _, w := range g.words {
+		end := i + len(w.text)
+		if end > len(text) || linked[w.term] || !strings.EqualFold(text[i:end], w.text) {
+			continue
+		}
+		// ASCII words must match whole words
+		first, _ := utf8.DecodeRuneInString(w.text)
+		last, _ := utf8.DecodeLastRuneInString(w.text)
+		prev, _ := utf8.DecodeLastRuneInString(text[:i])
+		next, _ := utf8.DecodeRuneInString(text[end:])
+		if isWordChar(first) && i > 0 && isWordChar(prev) {
+			continue
+		}
+		if isWordChar(last) && end < len(text) && isWordChar(next) {
+			continue
+		}
+		return w, true
+	}
+	return word{}, false
+}
+
+// References return the columns whose comment or logical name contains the term.
+func (g *Glossary) References(s *schema.Schema) map[*Term][]Reference {
+	refs := map[*Term][]Reference{}
+	for _, tbl := range s.Tables {
+		for _, c := range tbl.Columns {
+			text := c.GetEnhancedLogicalNameOrFallback(false) + "\n" + c.GetDescription()
+			for _, t := range g.terms {
+				if g.Contains(text, t) {
+					refs[t] = append(refs[t], Reference{Table: tbl.Name, Column: c.Name})
+				}
+			}
+		}
+	}
+	return refs
+}
+
+// Anchor return the anchor of the term heading in glossary.md.
+func Anchor(t *Term) string {
+	var b strings.Builder
+	for _, r := range strings.ToLower(t.Name) {
+		switch {
+		case unicode.IsLetter(r), unicode.IsDigit(r), r == '-', r == '_':
+			b.WriteRune(r)
+		case r == ' ':
+			b.WriteRune('-')
+		}
+	}
+	return b.String()
+}
+
+func isWordChar(r rune) bool {
+	return r < utf8.RuneSelf && (r == '_' || unicode.IsLetter(r) || unicode.IsDigit(r))
+}
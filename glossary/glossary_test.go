@@ -0,0 +1,131 @@
+package glossary
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/k1LoW/tbls/schema"
+)
+
+var testTerms = []*Term{
+	{Name: "会員", Definition: "サービスに登録したユーザー", Synonyms: []string{"メンバー"}, Owners: []string{"auth-team"}},
+	{Name: "会員番号", Definition: "会員ごとに採番される番号"},
+	{Name: "SKU", Definition: "Stock keeping unit"},
+}
+
+func href(t *Term) string {
+	return "glossary.md#" + Anchor(t)
+}
+
+func TestLink(t *testing.T) {
+	g := New(testTerms)
+	tests := []struct {
+		in   string
+		want string
+	}{
+		{"", ""},
+		{"会員の名前", "[会員](glossary.md#会員)の名前"},
+		// the longest term is linked
+		{"会員番号", "[会員番号](glossary.md#会員番号)"},
+		// each term is linked once
+		{"会員と会員番号と会員", "[会員](glossary.md#会員)と[会員番号](glossary.md#会員番号)と会員"},
+		{"メンバーと会員", "[メンバー](glossary.md#会員)と会員"},
+		{"sku of the item", "[sku](glossary.md#sku) of the item"},
+		{"SKUS", "SKUS"},
+		{"item_sku", "item_sku"},
+		{"`会員` を参照 https://example.com/SKU", "`会員` を参照 https://example.com/SKU"},
+		{"[会員](https://example.com) SKU", "[会員](https://example.com) [SKU](glossary.md#sku)"},
+	}
+	for _, tt := range tests {
+		if got := g.Link(tt.in, href); got != tt.want {
+			t.Errorf("Link(%q) got %q, want %q", tt.in, got, tt.want)
+		}
+	}
+}
+
+func TestReferences(t *testing.T) {
+	g := New(testTerms)
+	s := &schema.Schema{
+		Tables: []*schema.Table{
+			{
+				Name: "users",
+				Columns: []*schema.Column{
+					{Name: "id", Comment: "会員番号"},
+					{Name: "name", LogicalName: "会員名"},
+					{Name: "sku", Comment: `{"description": "購入したSKU"}`, EnhancedCommentData: &schema.CommentData{Description: "購入したSKU"}},
+				},
+			},
+			{
+				Name: "members",
+				Columns: []*schema.Column{
+					{Name: "id", LogicalName: "メンバーID"},
+				},
+			},
+		},
+	}
+	got := map[string][]Reference{}
+	for term, refs := range g.References(s) {
+		got[term.Name] = refs
+	}
+	want := map[string][]Reference{
+		"会員":   {{Table: "users", Column: "name"}, {Table: "members", Column: "id"}},
+		"会員番号": {{Table: "users", Column: "id"}},
+		"SKU":  {{Table: "users", Column: "sku"}},
+	}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Error(diff)
+	}
+}
+
+func TestTerms(t *testing.T) {
+	got := []string{}
+	for _, term := range New(testTerms).Terms() {
+		got = append(got, term.Name)
+	}
+	if diff := cmp.Diff([]string{"SKU", "会員", "会員番号"}, got); diff != "" {
+		t.Error(diff)
+	}
+}
+
+func TestLoadFile(t *testing.T) {
+	p := filepath.Join(t.TempDir(), "glossary.yml")
+	in := `terms:
+  - name: 会員
+    definition: サービスに登録したユーザー
+    synonyms:
+      - メンバー
+    owners:
+      - auth-team
+`
+	if err := os.WriteFile(p, []byte(in), 0600); err != nil {
+		t.Fatal(err)
+	}
+	got, err := LoadFile(p)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if diff := cmp.Diff(testTerms[:1], got); diff != "" {
+		t.Error(diff)
+	}
+	if _, err := LoadFile(filepath.Join(t.TempDir(), "notfound.yml")); err == nil {
+		t.Error("want error")
+	}
+}
+
+func TestAnchor(t *testing.T) {
+	tests := []struct {
+		in   string
+		want string
+	}{
+		{"会員", "会員"},
+		{"Order Item", "order-item"},
+		{"A/B test (beta)", "ab-test-beta"},
+	}
+	for _, tt := range tests {
+		if got := Anchor(&Term{Name: tt.in}); got != tt.want {
+			t.Errorf("got %q, want %q", got, tt.want)
+		}
+	}
+}
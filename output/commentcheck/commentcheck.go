@@ -0,0 +1,78 @@
+package commentcheck
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+
+	"github.com/k1LoW/tbls/schema"
+)
+
+// SupportFormats is the list of supported comment check report formats.
+var SupportFormats = []string{"text", "json", "sarif"}
+
+// CommentCheck struct.
+type CommentCheck struct {
+	format string
+}
+
+// New return CommentCheck.
+func New(format string) (*CommentCheck, error) {
+	for _, f := range SupportFormats {
+		if f == format {
+			return &CommentCheck{
+				format: format,
+			}, nil
+		}
+	}
+	return nil, fmt.Errorf("unsupported comment check format '%s'", format)
+}
+
+// report is the JSON representation of the comment check result.
+type report struct {
+	Summary map[schema.ErrorSeverity]int   `json:"summary"`
+	Results []*schema.EnhancedCommentError `json:"results"`
+}
+
+// OutputReport output the problems found by comment check.
+func (c *CommentCheck) OutputReport(wr io.Writer, errs []*schema.EnhancedCommentError) error {
+	switch c.format {
+	case "json":
+		r := &report{
+			Summary: map[schema.ErrorSeverity]int{},
+			Results: []*schema.EnhancedCommentError{},
+		}
+		for _, e := range errs {
+			r.Summary[e.Severity]++
+			r.Results = append(r.Results, e)
+		}
+		encoder := json.NewEncoder(wr)
+		encoder.SetIndent("", "  ")
+		return encoder.Encode(r)
+	case "sarif":
+		encoder := json.NewEncoder(wr)
+		encoder.SetIndent("", "  ")
+		return encoder.Encode(newSARIFLog(errs))
+	default:
+		return outputText(wr, errs)
+	}
+}
+
+func outputText(wr io.Writer, errs []*schema.EnhancedCommentError) error {
+	for _, e := range errs {
+		if _, err := fmt.Fprintln(wr, TextLine(e)); err != nil {
+			return err
+		}
+		for _, s := range e.Suggestions {
+			if _, err := fmt.Fprintf(wr, "  - %s\n", s); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// TextLine returns a one-line text representation of the problem.
+func TextLine(e *schema.EnhancedCommentError) string {
+	return fmt.Sprintf("%s %s: [%s] %s %s", e.ObjectType, e.ObjectName, e.Severity, e.ErrorCode, e.Message)
+}
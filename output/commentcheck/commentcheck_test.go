@@ -0,0 +1,133 @@
+package commentcheck
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+
+	"github.com/k1LoW/tbls/schema"
+)
+
+func testErrors() []*schema.EnhancedCommentError {
+	parseErr := schema.NewErrorBuilder().
+		WithMessage("json parser failed to parse comment: unexpected end of JSON input").
+		WithSeverity(schema.ErrorSeverityError).
+		WithCategory(schema.ErrorCategoryParsing).
+		WithErrorCode(schema.ErrorCodeJSONParseFailed).
+		WithObjectInfo(schema.ObjectTypeColumn, "users.email").
+		WithSuggestion("check the comment format").
+		Build()
+	reporter := schema.NewDefaultErrorReporter()
+	reporter.ReportError(parseErr)
+	reporter.ReportRecovery(parseErr, &schema.CommentData{})
+	reporter.ReportError(schema.NewValidationError("too long", schema.ObjectTypeTable, "users"))
+	return reporter.Errors()
+}
+
+func TestOutputReportText(t *testing.T) {
+	o, err := New("text")
+	if err != nil {
+		t.Fatal(err)
+	}
+	got := new(bytes.Buffer)
+	if err := o.OutputReport(got, testErrors()); err != nil {
+		t.Fatal(err)
+	}
+	want := `column users.email: [ERROR] E_JSON_PARSE_FAILED json parser failed to parse comment: unexpected end of JSON input
+  - check the comment format
+column users.email: [INFO] E_RECOVERY_SUCCESS Recovered from error: json parser failed to parse comment: unexpected end of JSON input
+table users: [WARNING] E_VALIDATION_FAILED too long
+  - コメント内容を見直してください
+`
+	if got.String() != want {
+		t.Errorf("got %v\nwant %v", got.String(), want)
+	}
+}
+
+func TestOutputReportJSON(t *testing.T) {
+	o, err := New("json")
+	if err != nil {
+		t.Fatal(err)
+	}
+	buf := new(bytes.Buffer)
+	if err := o.OutputReport(buf, testErrors()); err != nil {
+		t.Fatal(err)
+	}
+	var got struct {
+		Summary map[string]int `json:"summary"`
+		Results []struct {
+			Severity    string   `json:"severity"`
+			ErrorCode   string   `json:"error_code"`
+			ObjectType  string   `json:"object_type"`
+			ObjectName  string   `json:"object_name"`
+			Suggestions []string `json:"suggestions"`
+		} `json:"results"`
+	}
+	if err := json.Unmarshal(buf.Bytes(), &got); err != nil {
+		t.Fatal(err)
+	}
+	if got.Summary["ERROR"] != 1 || got.Summary["INFO"] != 1 || got.Summary["WARNING"] != 1 {
+		t.Errorf("got %v", got.Summary)
+	}
+	if len(got.Results) != 3 {
+		t.Fatalf("got %d results", len(got.Results))
+	}
+	r := got.Results[0]
+	if r.Severity != "ERROR" || r.ErrorCode != schema.ErrorCodeJSONParseFailed || r.ObjectType != "column" || r.ObjectName != "users.email" || len(r.Suggestions) != 1 {
+		t.Errorf("got %+v", r)
+	}
+
+	buf.Reset()
+	if err := o.OutputReport(buf, nil); err != nil {
+		t.Fatal(err)
+	}
+	if want := "{\n  \"summary\": {},\n  \"results\": []\n}\n"; buf.String() != want {
+		t.Errorf("got %q, want %q", buf.String(), want)
+	}
+}
+
+func TestOutputReportSARIF(t *testing.T) {
+	o, err := New("sarif")
+	if err != nil {
+		t.Fatal(err)
+	}
+	buf := new(bytes.Buffer)
+	if err := o.OutputReport(buf, testErrors()); err != nil {
+		t.Fatal(err)
+	}
+	var got sarifLog
+	if err := json.Unmarshal(buf.Bytes(), &got); err != nil {
+		t.Fatal(err)
+	}
+	if got.Version != "2.1.0" || len(got.Runs) != 1 {
+		t.Fatalf("got %+v", got)
+	}
+	run := got.Runs[0]
+	rules := []string{}
+	for _, r := range run.Tool.Driver.Rules {
+		rules = append(rules, r.ID)
+	}
+	if want := []string{schema.ErrorCodeJSONParseFailed, schema.ErrorCodeRecoverySuccess, schema.ErrorCodeValidationFailed}; len(rules) != len(want) || rules[0] != want[0] || rules[1] != want[1] || rules[2] != want[2] {
+		t.Errorf("got %v, want %v", rules, want)
+	}
+	levels := []string{}
+	for _, r := range run.Results {
+		levels = append(levels, r.Level)
+		if run.Tool.Driver.Rules[r.RuleIndex].ID != r.RuleID {
+			t.Errorf("rule index of %s is %d", r.RuleID, r.RuleIndex)
+		}
+	}
+	if want := []string{"error", "note", "warning"}; len(levels) != len(want) || levels[0] != want[0] || levels[1] != want[1] || levels[2] != want[2] {
+		t.Errorf("got %v, want %v", levels, want)
+	}
+	loc := run.Results[0].Locations[0].LogicalLocations[0]
+	if loc.FullyQualifiedName != "users.email" || loc.Kind != "column" {
+		t.Errorf("got %+v", loc)
+	}
+}
+
+func TestNewUnsupportedFormat(t *testing.T) {
+	if _, err := New("xml"); err == nil {
+		t.Error("want error")
+	}
+}
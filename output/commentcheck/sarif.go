@@ -0,0 +1,131 @@
+package commentcheck
+
+import (
+	"github.com/k1LoW/tbls/schema"
+	"github.com/k1LoW/tbls/version"
+)
+
+const (
+	sarifVersion = "2.1.0"
+	sarifSchema  = "https://json.schemastore.org/sarif-2.1.0.json"
+)
+
+// sarifLog is a SARIF 2.1.0 log that contains a single run of comment check.
+type sarifLog struct {
+	Version string     `json:"version"`
+	Schema  string     `json:"$schema"`
+	Runs    []sarifRun `json:"runs"`
+}
+
+type sarifRun struct {
+	Tool    sarifTool     `json:"tool"`
+	Results []sarifResult `json:"results"`
+}
+
+type sarifTool struct {
+	Driver sarifDriver `json:"driver"`
+}
+
+type sarifDriver struct {
+	Name           string      `json:"name"`
+	InformationURI string      `json:"informationUri"`
+	Version        string      `json:"version"`
+	Rules          []sarifRule `json:"rules"`
+}
+
+type sarifRule struct {
+	ID               string       `json:"id"`
+	ShortDescription sarifMessage `json:"shortDescription"`
+}
+
+type sarifResult struct {
+	RuleID     string          `json:"ruleId"`
+	RuleIndex  int             `json:"ruleIndex"`
+	Level      string          `json:"level"`
+	Message    sarifMessage    `json:"message"`
+	Locations  []sarifLocation `json:"locations"`
+	Properties sarifProperties `json:"properties"`
+}
+
+type sarifMessage struct {
+	Text string `json:"text"`
+}
+
+type sarifLocation struct {
+	LogicalLocations []sarifLogicalLocation `json:"logicalLocations"`
+}
+
+type sarifLogicalLocation struct {
+	FullyQualifiedName string `json:"fullyQualifiedName"`
+	Kind               string `json:"kind"`
+}
+
+type sarifProperties struct {
+	Severity    string   `json:"severity"`
+	Category    string   `json:"category"`
+	Suggestions []string `json:"suggestions,omitempty"`
+}
+
+// newSARIFLog convert the problems found by comment check to a SARIF log.
+// Each error code becomes a rule, and each problem becomes a result located at the database object.
+func newSARIFLog(errs []*schema.EnhancedCommentError) *sarifLog {
+	driver := sarifDriver{
+		Name:           version.Name,
+		InformationURI: "https://github.com/k1LoW/tbls",
+		Version:        version.Version,
+		Rules:          []sarifRule{},
+	}
+	results := []sarifResult{}
+	ruleIndexes := map[string]int{}
+	for _, e := range errs {
+		idx, ok := ruleIndexes[e.ErrorCode]
+		if !ok {
+			idx = len(driver.Rules)
+			ruleIndexes[e.ErrorCode] = idx
+			driver.Rules = append(driver.Rules, sarifRule{
+				ID:               e.ErrorCode,
+				ShortDescription: sarifMessage{Text: e.Category.String()},
+			})
+		}
+		results = append(results, sarifResult{
+			RuleID:    e.ErrorCode,
+			RuleIndex: idx,
+			Level:     sarifLevel(e.Severity),
+			Message:   sarifMessage{Text: e.Message},
+			Locations: []sarifLocation{
+				{
+					LogicalLocations: []sarifLogicalLocation{
+						{FullyQualifiedName: e.ObjectName, Kind: string(e.ObjectType)},
+					},
+				},
+			},
+			Properties: sarifProperties{
+				Severity:    e.Severity.String(),
+				Category:    e.Category.String(),
+				Suggestions: e.Suggestions,
+			},
+		})
+	}
+	return &sarifLog{
+		Version: sarifVersion,
+		Schema:  sarifSchema,
+		Runs: []sarifRun{
+			{
+				Tool:    sarifTool{Driver: driver},
+				Results: results,
+			},
+		},
+	}
+}
+
+// sarifLevel convert severity to SARIF result level.
+func sarifLevel(sv schema.ErrorSeverity) string {
+	switch {
+	case sv >= schema.ErrorSeverityError:
+		return "error"
+	case sv == schema.ErrorSeverityWarning:
+		return "warning"
+	default:
+		return "note"
+	}
+}
@@ -0,0 +1,93 @@
+package diff
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/goccy/go-yaml"
+	"github.com/k1LoW/tbls/schema"
+)
+
+// SupportFormats is the list of supported diff output formats.
+var SupportFormats = []string{"json", "yaml", "text", "markdown", "jsonpatch"}
+
+// Diff struct.
+type Diff struct {
+	format string
+}
+
+// New return Diff.
+func New(format string) (*Diff, error) {
+	for _, f := range SupportFormats {
+		if f == format {
+			return &Diff{
+				format: format,
+			}, nil
+		}
+	}
+	return nil, fmt.Errorf("unsupported diff format '%s'", format)
+}
+
+// OutputDiff output schema.SchemaDiff.
+func (d *Diff) OutputDiff(wr io.Writer, sd *schema.SchemaDiff) error {
+	switch d.format {
+	case "json":
+		encoder := json.NewEncoder(wr)
+		encoder.SetIndent("", "  ")
+		return encoder.Encode(sd)
+	case "yaml":
+		encoder := yaml.NewEncoder(wr)
+		return encoder.Encode(sd)
+	case "markdown":
+		return outputMarkdown(wr, sd)
+	case "jsonpatch":
+		ops, err := JSONPatch(sd)
+		if err != nil {
+			return err
+		}
+		encoder := json.NewEncoder(wr)
+		encoder.SetIndent("", "  ")
+		return encoder.Encode(ops)
+	default:
+		return outputText(wr, sd)
+	}
+}
+
+func outputText(wr io.Writer, sd *schema.SchemaDiff) error {
+	for _, c := range sd.Changes {
+		if _, err := fmt.Fprintln(wr, TextLine(c)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// TextLine returns a one-line text representation of the change.
+func TextLine(c *schema.Change) string {
+	var mark string
+	switch c.Type {
+	case schema.DiffAdded:
+		mark = "+"
+	case schema.DiffRemoved:
+		mark = "-"
+	default:
+		mark = "~"
+	}
+	line := fmt.Sprintf("%s %s %s", mark, c.ObjectType, c.Path())
+	if c.Severity != "" {
+		line = fmt.Sprintf("%s [%s]", line, c.Severity)
+	}
+	fields := []string{}
+	if c.Type == schema.DiffRenamed {
+		fields = append(fields, fmt.Sprintf("renamed %s -> %s", c.From, c.Name))
+	}
+	if len(c.Fields)+len(fields) == 0 {
+		return line
+	}
+	for _, f := range c.Fields {
+		fields = append(fields, f.String())
+	}
+	return fmt.Sprintf("%s (%s)", line, strings.Join(fields, ", "))
+}
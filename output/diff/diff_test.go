@@ -0,0 +1,241 @@
+package diff
+
+import (
+	"bytes"
+	"encoding/json"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/k1LoW/tbls/schema"
+)
+
+func TestOutputDiff(t *testing.T) {
+	after := "VARCHAR(50)"
+	sd := &schema.SchemaDiff{
+		Changes: []*schema.Change{
+			{Type: schema.DiffAdded, ObjectType: schema.ObjectTypeTable, Table: "users", Name: "users", Severity: schema.SeveritySafe},
+			{Type: schema.DiffRemoved, ObjectType: schema.ObjectTypeColumn, Table: "posts", Name: "body", Severity: schema.SeverityBreaking},
+			{Type: schema.DiffModified, ObjectType: schema.ObjectTypeColumn, Table: "posts", Name: "title", Severity: schema.SeverityBreaking, Fields: []*schema.FieldChange{
+				{Field: "type", Before: "VARCHAR(255)", After: after},
+				{Field: "nullable", Before: true, After: false},
+			}},
+			{Type: schema.DiffRenamed, ObjectType: schema.ObjectTypeColumn, Table: "users", Name: "username", From: "user_name", Severity: schema.SeverityBreaking},
+		},
+	}
+	tests := []struct {
+		format string
+		want   string
+	}{
+		{"text", `+ table users [safe]
+- column posts.body [breaking]
+~ column posts.title [breaking] (type: "VARCHAR(255)" -> "VARCHAR(50)", nullable: true -> false)
+~ column users.username [breaking] (renamed user_name -> username)
+`},
+		{"markdown", `## Schema changes
+
+**4** changes ( max severity: **breaking** )
+
+| Object | Added | Removed | Modified | Renamed |
+| ------ | ----- | ------- | -------- | ------- |
+| table | 1 | 0 | 0 | 0 |
+| column | 0 | 1 | 1 | 1 |
+
+<details>
+<summary><code>users</code> ( 2 changes, breaking )</summary>
+
+- **added** table ` + "`users` `safe`" + `
+- **renamed** column ` + "`user_name` → `username` `breaking`" + `
+
+</details>
+
+<details>
+<summary><code>posts</code> ( 2 changes, breaking )</summary>
+
+- **removed** column ` + "`body` `breaking`" + `
+- **modified** column ` + "`title` `breaking`: `type: \"VARCHAR(255)\" -> \"VARCHAR(50)\"`, `nullable: true -> false`" + `
+
+</details>
+`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.format, func(t *testing.T) {
+			o, err := New(tt.format)
+			if err != nil {
+				t.Fatal(err)
+			}
+			got := new(bytes.Buffer)
+			if err := o.OutputDiff(got, sd); err != nil {
+				t.Fatal(err)
+			}
+			if got.String() != tt.want {
+				t.Errorf("got %v\nwant %v", got.String(), tt.want)
+			}
+		})
+	}
+}
+
+func TestOutputDiffKeys(t *testing.T) {
+	sd := &schema.SchemaDiff{
+		Changes: []*schema.Change{
+			{Type: schema.DiffModified, ObjectType: schema.ObjectTypeColumn, Table: "posts", Name: "title", Fields: []*schema.FieldChange{
+				{Field: "extra_def", Before: "", After: "auto_increment"},
+			}},
+		},
+	}
+	// JSON and YAML use the same snake_case keys
+	for _, format := range []string{"json", "yaml"} {
+		t.Run(format, func(t *testing.T) {
+			o, err := New(format)
+			if err != nil {
+				t.Fatal(err)
+			}
+			got := new(bytes.Buffer)
+			if err := o.OutputDiff(got, sd); err != nil {
+				t.Fatal(err)
+			}
+			if !strings.Contains(got.String(), "object_type") || strings.Contains(got.String(), "objectType") {
+				t.Errorf("got %v", got.String())
+			}
+		})
+	}
+}
+
+func TestNewUnsupportedFormat(t *testing.T) {
+	if _, err := New("xml"); err == nil {
+		t.Error("want error")
+	}
+}
+
+func TestJSONPatch(t *testing.T) {
+	from := &schema.Schema{
+		Name: "testdb",
+		Tables: []*schema.Table{
+			{Name: "a", Type: "TABLE", Columns: []*schema.Column{
+				{Name: "id", Type: "int"},
+				{Name: "name", Type: "varchar(255)"},
+				{Name: "memo", Type: "text"},
+			}},
+			{Name: "b/c", Type: "TABLE", Columns: []*schema.Column{
+				{Name: "id", Type: "int"},
+			}},
+		},
+	}
+	to := &schema.Schema{
+		Name: "testdb",
+		Tables: []*schema.Table{
+			{Name: "a", Type: "TABLE", Comment: "table a", Columns: []*schema.Column{
+				{Name: "id", Type: "bigint"},
+				{Name: "email", Type: "text"},
+				{Name: "name", Type: "varchar(255)"},
+			}},
+			{Name: "d", Type: "TABLE", Columns: []*schema.Column{
+				{Name: "id", Type: "int"},
+			}},
+		},
+	}
+	if err := from.Repair(); err != nil {
+		t.Fatal(err)
+	}
+	if err := to.Repair(); err != nil {
+		t.Fatal(err)
+	}
+	sd := schema.Diff(from, to)
+	ops, err := JSONPatch(sd)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(ops) == 0 {
+		t.Fatal("want operations")
+	}
+
+	doc, err := toGeneric(from)
+	if err != nil {
+		t.Fatal(err)
+	}
+	b, err := json.Marshal(ops)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var decoded []map[string]any
+	if err := json.Unmarshal(b, &decoded); err != nil {
+		t.Fatal(err)
+	}
+	for _, op := range decoded {
+		doc, err = applyOperation(doc, op)
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+	want, err := toGeneric(to)
+	if err != nil {
+		t.Fatal(err)
+	}
+	gotb, _ := json.Marshal(doc)
+	wantb, _ := json.Marshal(want)
+	if string(gotb) != string(wantb) {
+		t.Errorf("got %s\nwant %s", gotb, wantb)
+	}
+
+	if _, err := JSONPatch(&schema.SchemaDiff{}); err == nil {
+		t.Error("want error")
+	}
+}
+
+// applyOperation is a minimal JSON Patch implementation for testing.
+func applyOperation(doc any, op map[string]any) (any, error) {
+	path := op["path"].(string)
+	if path == "" {
+		return op["value"], nil
+	}
+	tokens := strings.Split(path[1:], "/")
+	for i, tk := range tokens {
+		tokens[i] = strings.NewReplacer("~1", "/", "~0", "~").Replace(tk)
+	}
+	parent := doc
+	for _, tk := range tokens[:len(tokens)-1] {
+		switch p := parent.(type) {
+		case map[string]any:
+			parent = p[tk]
+		case []any:
+			i, err := strconv.Atoi(tk)
+			if err != nil {
+				return nil, err
+			}
+			parent = p[i]
+		}
+	}
+	last := tokens[len(tokens)-1]
+	set := func(v any) {
+		if len(tokens) == 1 {
+			doc = v
+			return
+		}
+		pp, _ := applyOperation(doc, map[string]any{"op": "replace", "path": "/" + strings.Join(tokens[:len(tokens)-1], "/"), "value": v})
+		doc = pp
+	}
+	switch p := parent.(type) {
+	case map[string]any:
+		switch op["op"] {
+		case "remove":
+			delete(p, last)
+		default:
+			p[last] = op["value"]
+		}
+	case []any:
+		i, err := strconv.Atoi(last)
+		if err != nil {
+			return nil, err
+		}
+		switch op["op"] {
+		case "remove":
+			set(append(append([]any{}, p[:i]...), p[i+1:]...))
+		case "add":
+			n := append(append(append([]any{}, p[:i]...), op["value"]), p[i:]...)
+			set(n)
+		default:
+			p[i] = op["value"]
+		}
+	}
+	return doc, nil
+}
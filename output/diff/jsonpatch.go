@@ -0,0 +1,207 @@
+package diff
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+
+	"github.com/k1LoW/tbls/schema"
+)
+
+// Operation is a JSON Patch ( RFC 6902 ) operation.
+type Operation struct {
+	Op    string
+	Path  string
+	Value any
+}
+
+// MarshalJSON return custom JSON byte. `value` is omitted only for remove operation.
+func (o Operation) MarshalJSON() ([]byte, error) {
+	if o.Op == "remove" {
+		return json.Marshal(struct {
+			Op   string `json:"op"`
+			Path string `json:"path"`
+		}{o.Op, o.Path})
+	}
+	return json.Marshal(struct {
+		Op    string `json:"op"`
+		Path  string `json:"path"`
+		Value any    `json:"value"`
+	}{o.Op, o.Path, o.Value})
+}
+
+// JSONPatch returns JSON Patch operations that transform the tbls JSON schema of sd.From into that of sd.To.
+func JSONPatch(sd *schema.SchemaDiff) ([]Operation, error) {
+	if sd.From == nil || sd.To == nil {
+		return nil, fmt.Errorf("schemas to compare are required to output JSON Patch")
+	}
+	a, err := toGeneric(sd.From)
+	if err != nil {
+		return nil, err
+	}
+	b, err := toGeneric(sd.To)
+	if err != nil {
+		return nil, err
+	}
+	return diffValue("", a, b), nil
+}
+
+func toGeneric(s *schema.Schema) (any, error) {
+	b, err := json.Marshal(s)
+	if err != nil {
+		return nil, err
+	}
+	var v any
+	if err := json.Unmarshal(b, &v); err != nil {
+		return nil, err
+	}
+	return v, nil
+}
+
+func diffValue(path string, a, b any) []Operation {
+	if reflect.DeepEqual(a, b) {
+		return nil
+	}
+	switch av := a.(type) {
+	case map[string]any:
+		if bv, ok := b.(map[string]any); ok {
+			return diffObject(path, av, bv)
+		}
+	case []any:
+		if bv, ok := b.([]any); ok {
+			return diffArray(path, av, bv)
+		}
+	}
+	return []Operation{{Op: "replace", Path: path, Value: b}}
+}
+
+func diffObject(path string, a, b map[string]any) []Operation {
+	ops := []Operation{}
+	keys := []string{}
+	for k := range a {
+		keys = append(keys, k)
+	}
+	for k := range b {
+		if _, ok := a[k]; !ok {
+			keys = append(keys, k)
+		}
+	}
+	sort.Strings(keys)
+	for _, k := range keys {
+		p := fmt.Sprintf("%s/%s", path, escapePointer(k))
+		av, inA := a[k]
+		bv, inB := b[k]
+		switch {
+		case !inB:
+			ops = append(ops, Operation{Op: "remove", Path: p})
+		case !inA:
+			ops = append(ops, Operation{Op: "add", Path: p, Value: bv})
+		default:
+			ops = append(ops, diffValue(p, av, bv)...)
+		}
+	}
+	return ops
+}
+
+// diffArray matches elements by key ( ex. name ). The operations are ordered as replaces, removes ( descending index ) and adds ( ascending index ) so that indexes stay valid.
+// If elements can not be matched or the order of matched elements changed, the whole array is replaced.
+func diffArray(path string, a, b []any) []Operation {
+	ak, okA := elementKeys(a)
+	bk, okB := elementKeys(b)
+	if !okA || !okB {
+		if len(a) == len(b) {
+			ops := []Operation{}
+			for i := range a {
+				ops = append(ops, diffValue(fmt.Sprintf("%s/%d", path, i), a[i], b[i])...)
+			}
+			return ops
+		}
+		return []Operation{{Op: "replace", Path: path, Value: b}}
+	}
+	bIndex := map[string]int{}
+	for i, k := range bk {
+		bIndex[k] = i
+	}
+	aIndex := map[string]int{}
+	for i, k := range ak {
+		aIndex[k] = i
+	}
+
+	// matched elements must keep the relative order
+	last := -1
+	for _, k := range ak {
+		j, ok := bIndex[k]
+		if !ok {
+			continue
+		}
+		if j < last {
+			return []Operation{{Op: "replace", Path: path, Value: b}}
+		}
+		last = j
+	}
+
+	ops := []Operation{}
+	for i, k := range ak {
+		if j, ok := bIndex[k]; ok {
+			ops = append(ops, diffValue(fmt.Sprintf("%s/%d", path, i), a[i], b[j])...)
+		}
+	}
+	for i := len(ak) - 1; i >= 0; i-- {
+		if _, ok := bIndex[ak[i]]; !ok {
+			ops = append(ops, Operation{Op: "remove", Path: fmt.Sprintf("%s/%d", path, i)})
+		}
+	}
+	for j, k := range bk {
+		if _, ok := aIndex[k]; !ok {
+			ops = append(ops, Operation{Op: "add", Path: fmt.Sprintf("%s/%d", path, j), Value: b[j]})
+		}
+	}
+	return ops
+}
+
+// elementKeys returns the unique keys of array elements.
+func elementKeys(arr []any) ([]string, bool) {
+	keys := []string{}
+	seen := map[string]struct{}{}
+	for _, e := range arr {
+		o, ok := e.(map[string]any)
+		if !ok {
+			return nil, false
+		}
+		k, ok := elementKey(o)
+		if !ok {
+			return nil, false
+		}
+		if _, ok := seen[k]; ok {
+			return nil, false
+		}
+		seen[k] = struct{}{}
+		keys = append(keys, k)
+	}
+	return keys, true
+}
+
+func elementKey(o map[string]any) (string, bool) {
+	if n, ok := o["name"].(string); ok {
+		if args, ok := o["arguments"].(string); ok {
+			// functions can be overloaded
+			return fmt.Sprintf("%s(%s)", n, args), true
+		}
+		return n, true
+	}
+	if t, ok := o["table"].(string); ok {
+		// relations
+		if pt, ok := o["parent_table"].(string); ok {
+			return fmt.Sprintf("%s%v->%s%v", t, o["columns"], pt, o["parent_columns"]), true
+		}
+	}
+	return "", false
+}
+
+var pointerEscaper = strings.NewReplacer("~", "~0", "/", "~1")
+
+func escapePointer(s string) string {
+	return pointerEscaper.Replace(s)
+}
@@ -0,0 +1,121 @@
+package diff
+
+import (
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/k1LoW/tbls/schema"
+)
+
+// othersGroup is the group name of changes that do not belong to a table.
+const othersGroup = "Others"
+
+var markdownObjectTypes = []schema.ObjectType{
+	schema.ObjectTypeTable,
+	schema.ObjectTypeColumn,
+	schema.ObjectTypeIndex,
+	schema.ObjectTypeConstraint,
+	schema.ObjectTypeTrigger,
+	schema.ObjectTypeRelation,
+	schema.ObjectTypeFunction,
+	schema.ObjectTypeEnum,
+}
+
+var markdownDiffTypes = []schema.DiffType{
+	schema.DiffAdded,
+	schema.DiffRemoved,
+	schema.DiffModified,
+	schema.DiffRenamed,
+}
+
+// outputMarkdown output GitHub Flavored Markdown summary with collapsible sections per table.
+func outputMarkdown(wr io.Writer, sd *schema.SchemaDiff) error {
+	b := new(strings.Builder)
+	b.WriteString("## Schema changes\n\n")
+	if !sd.HasChanges() {
+		b.WriteString("No changes.\n")
+		_, err := io.WriteString(wr, b.String())
+		return err
+	}
+	fmt.Fprintf(b, "**%d** changes ( max severity: **%s** )\n\n", len(sd.Changes), sd.MaxSeverity())
+
+	// summary table
+	counts := map[schema.ObjectType]map[schema.DiffType]int{}
+	for _, c := range sd.Changes {
+		if _, ok := counts[c.ObjectType]; !ok {
+			counts[c.ObjectType] = map[schema.DiffType]int{}
+		}
+		counts[c.ObjectType][c.Type]++
+	}
+	b.WriteString("| Object | Added | Removed | Modified | Renamed |\n")
+	b.WriteString("| ------ | ----- | ------- | -------- | ------- |\n")
+	for _, ot := range markdownObjectTypes {
+		cs, ok := counts[ot]
+		if !ok {
+			continue
+		}
+		fmt.Fprintf(b, "| %s |", ot)
+		for _, dt := range markdownDiffTypes {
+			fmt.Fprintf(b, " %d |", cs[dt])
+		}
+		b.WriteString("\n")
+	}
+
+	// per table
+	groups := []string{}
+	grouped := map[string][]*schema.Change{}
+	for _, c := range sd.Changes {
+		g := c.Table
+		if g == "" {
+			g = othersGroup
+		}
+		if _, ok := grouped[g]; !ok {
+			groups = append(groups, g)
+		}
+		grouped[g] = append(grouped[g], c)
+	}
+	for _, g := range groups {
+		changes := grouped[g]
+		max := (&schema.SchemaDiff{Changes: changes}).MaxSeverity()
+		b.WriteString("\n<details>\n")
+		fmt.Fprintf(b, "<summary><code>%s</code> ( %d changes, %s )</summary>\n\n", escapeHTML(g), len(changes), max)
+		for _, c := range changes {
+			fmt.Fprintf(b, "- %s\n", markdownLine(c))
+		}
+		b.WriteString("\n</details>\n")
+	}
+	_, err := io.WriteString(wr, b.String())
+	return err
+}
+
+func markdownLine(c *schema.Change) string {
+	name := c.Name
+	if c.Table == "" || c.ObjectType == schema.ObjectTypeTable || c.ObjectType == schema.ObjectTypeRelation {
+		name = c.Path()
+	}
+	var line string
+	switch c.Type {
+	case schema.DiffRenamed:
+		line = fmt.Sprintf("**%s** %s `%s` → `%s`", c.Type, c.ObjectType, c.From, name)
+	default:
+		line = fmt.Sprintf("**%s** %s `%s`", c.Type, c.ObjectType, name)
+	}
+	if c.Severity != "" {
+		line = fmt.Sprintf("%s `%s`", line, c.Severity)
+	}
+	if len(c.Fields) == 0 {
+		return line
+	}
+	fields := []string{}
+	for _, f := range c.Fields {
+		fields = append(fields, fmt.Sprintf("`%s`", f.String()))
+	}
+	return fmt.Sprintf("%s: %s", line, strings.Join(fields, ", "))
+}
+
+var htmlEscaper = strings.NewReplacer("&", "&amp;", "<", "&lt;", ">", "&gt;")
+
+func escapeHTML(s string) string {
+	return htmlEscaper.Replace(s)
+}
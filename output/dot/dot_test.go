@@ -8,19 +8,31 @@ import (
 	"testing"
 
 	"github.com/k1LoW/tbls/config"
+	"github.com/k1LoW/tbls/schema"
 	"github.com/k1LoW/tbls/testutil"
 	"github.com/tenntenn/golden"
 )
 
 func TestOutputSchema(t *testing.T) {
+	classification := config.Classification{
+		Rules: []config.ClassificationRule{
+			{Level: "pii", Category: "email", Columns: []string{"a.a2"}},
+			{Level: "internal", Columns: []string{"b.b2"}},
+			{Level: "public", Columns: []string{"b.b"}},
+		},
+	}
 	tests := []struct {
 		hideDef         bool
 		showColumnTypes *config.ShowColumnTypes
+		deprecated      bool
+		classification  config.Classification
 		wantFile        string
 	}{
-		{false, nil, "dot_test_schema.dot"},
-		{true, nil, "dot_test_schema.dot.hidedef"},
-		{false, &config.ShowColumnTypes{Related: true}, "dot_test_schema.dot.hide_not_related_column"},
+		{false, nil, false, config.Classification{}, "dot_test_schema.dot"},
+		{true, nil, false, config.Classification{}, "dot_test_schema.dot.hidedef"},
+		{false, &config.ShowColumnTypes{Related: true}, false, config.Classification{}, "dot_test_schema.dot.hide_not_related_column"},
+		{false, nil, true, config.Classification{}, "dot_test_schema.dot.deprecated"},
+		{false, nil, false, classification, "dot_test_schema.dot.classification"},
 	}
 	for _, tt := range tests {
 		t.Run(tt.wantFile, func(t *testing.T) {
@@ -34,6 +46,10 @@ func TestOutputSchema(t *testing.T) {
 			}
 			c.ER.HideDef = tt.hideDef
 			c.ER.ShowColumnTypes = tt.showColumnTypes
+			c.Classification = tt.classification
+			if tt.deprecated {
+				deprecate(t, s)
+			}
 			if err := c.ModifySchema(s); err != nil {
 				t.Error(err)
 			}
@@ -198,3 +214,21 @@ func testdataDir() string {
 	dir, _ := filepath.Abs(filepath.Join(filepath.Dir(filepath.Dir(wd)), "testdata"))
 	return dir
 }
+
+func deprecate(t *testing.T, s *schema.Schema) {
+	t.Helper()
+	b, err := s.FindTableByName("b")
+	if err != nil {
+		t.Fatal(err)
+	}
+	b.EnhancedCommentData = &schema.CommentData{Deprecated: true}
+	a, err := s.FindTableByName("a")
+	if err != nil {
+		t.Fatal(err)
+	}
+	a2, err := a.FindColumnByName("a2")
+	if err != nil {
+		t.Fatal(err)
+	}
+	a2.EnhancedCommentData = &schema.CommentData{Deprecated: true}
+}
@@ -0,0 +1,128 @@
+(function () {
+  'use strict';
+
+  var themeKey = 'tbls-theme';
+
+  // Apply the stored theme before the page is rendered to avoid flickering.
+  try {
+    var stored = window.localStorage.getItem(themeKey);
+    if (stored === 'light' || stored === 'dark') {
+      document.documentElement.setAttribute('data-theme', stored);
+    }
+  } catch (e) {
+    // localStorage is not available (e.g. disabled for file:// URLs)
+  }
+
+  function currentTheme() {
+    var theme = document.documentElement.getAttribute('data-theme');
+    if (theme) {
+      return theme;
+    }
+    return window.matchMedia && window.matchMedia('(prefers-color-scheme: dark)').matches ? 'dark' : 'light';
+  }
+
+  function toggleTheme() {
+    var next = currentTheme() === 'dark' ? 'light' : 'dark';
+    document.documentElement.setAttribute('data-theme', next);
+    try {
+      window.localStorage.setItem(themeKey, next);
+    } catch (e) {
+      // ignore
+    }
+  }
+
+  // search returns entries matching all the words of the query in the name, logical name or comment.
+  function search(query, limit) {
+    var words = query.toLowerCase().split(/\s+/).filter(function (w) {
+      return w !== '';
+    });
+    var results = [];
+    if (words.length === 0) {
+      return results;
+    }
+    var entries = window.tblsSearchIndex || [];
+    for (var i = 0; i < entries.length && results.length < limit; i++) {
+      var e = entries[i];
+      var text = [e.name, e.logicalName || '', e.comment || ''].join('\n').toLowerCase();
+      var matched = words.every(function (w) {
+        return text.indexOf(w) !== -1;
+      });
+      if (matched) {
+        results.push(e);
+      }
+    }
+    return results;
+  }
+
+  function element(tag, className, text) {
+    var el = document.createElement(tag);
+    if (className) {
+      el.className = className;
+    }
+    if (text) {
+      el.textContent = text;
+    }
+    return el;
+  }
+
+  function render(list, results) {
+    list.textContent = '';
+    if (results.length === 0) {
+      list.appendChild(element('li', 'empty', 'No results'));
+      return;
+    }
+    results.forEach(function (e) {
+      var li = element('li');
+      var a = element('a');
+      a.href = e.url;
+      a.appendChild(element('span', 'kind', e.kind));
+      a.appendChild(element('span', 'name', e.name));
+      if (e.logicalName) {
+        a.appendChild(document.createTextNode(' '));
+        a.appendChild(element('span', 'logical-name', e.logicalName));
+      }
+      if (e.comment) {
+        a.appendChild(element('span', 'comment', e.comment));
+      }
+      li.appendChild(a);
+      list.appendChild(li);
+    });
+  }
+
+  document.addEventListener('DOMContentLoaded', function () {
+    var toggle = document.getElementById('theme-toggle');
+    if (toggle) {
+      toggle.addEventListener('click', toggleTheme);
+    }
+
+    var input = document.getElementById('search');
+    var list = document.getElementById('search-results');
+    if (!input || !list) {
+      return;
+    }
+    input.addEventListener('input', function () {
+      if (input.value.trim() === '') {
+        list.hidden = true;
+        return;
+      }
+      render(list, search(input.value, 50));
+      list.hidden = false;
+    });
+    input.addEventListener('keydown', function (ev) {
+      if (ev.key === 'Escape') {
+        input.value = '';
+        list.hidden = true;
+      } else if (ev.key === 'Enter') {
+        var first = list.querySelector('a');
+        if (first) {
+          window.location.href = first.href;
+        }
+      }
+    });
+    document.addEventListener('click', function (ev) {
+      if (!input.contains(ev.target) && !list.contains(ev.target)) {
+        list.hidden = true;
+      }
+    });
+  });
+})();
@@ -0,0 +1,355 @@
+package html
+
+import (
+	"bytes"
+	"embed"
+	"encoding/json"
+	"fmt"
+	"html/template"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/k1LoW/errors"
+	"github.com/k1LoW/tbls/config"
+	"github.com/k1LoW/tbls/output"
+	"github.com/k1LoW/tbls/output/gviz"
+	"github.com/k1LoW/tbls/schema"
+	"github.com/samber/lo"
+	"gitlab.com/golang-commonmark/mdurl"
+)
+
+// IndexFileName is the file name of the top page of the site.
+const IndexFileName = "index.html"
+
+// AssetsDir is the directory of the style sheet, scripts and search index of the site.
+const AssetsDir = "assets"
+
+// SearchIndexFileName is the file name of the search index in AssetsDir.
+// It is a script instead of JSON, because browsers can not fetch files from file:// URLs.
+const SearchIndexFileName = "search-index.js"
+
+var _ output.Output = &HTML{}
+
+//go:embed templates/* assets/*
+var tmpl embed.FS
+
+// HTML struct.
+type HTML struct {
+	config *config.Config
+	tmpl   embed.FS
+	// name is the name of the site shown in the header of every page
+	name string
+}
+
+// New return HTML.
+func New(c *config.Config) *HTML {
+	return &HTML{
+		config: c,
+		tmpl:   tmpl,
+	}
+}
+
+// SearchEntry is an entry of the client-side full-text search.
+type SearchEntry struct {
+	Kind        string `json:"kind"`
+	Name        string `json:"name"`
+	LogicalName string `json:"logicalName,omitempty"`
+	Comment     string `json:"comment,omitempty"`
+	URL         string `json:"url"`
+}
+
+// OutputSchema output index.html.
+func (h *HTML) OutputSchema(wr io.Writer, s *schema.Schema) error {
+	data := map[string]interface{}{
+		"Title":  s.Name,
+		"Schema": s,
+	}
+	if err := h.withER(data, func(g *gviz.Gviz, wr io.Writer) error {
+		return g.OutputSchema(wr, s)
+	}); err != nil {
+		return err
+	}
+	return h.execute(wr, "index", data)
+}
+
+// OutputTable output html for table.
+func (h *HTML) OutputTable(wr io.Writer, t *schema.Table) error {
+	data := map[string]interface{}{
+		"Title": t.Name,
+		"Table": t,
+	}
+	if err := h.withER(data, func(g *gviz.Gviz, wr io.Writer) error {
+		return g.OutputTable(wr, t)
+	}); err != nil {
+		return err
+	}
+	return h.execute(wr, "table", data)
+}
+
+// OutputViewpoint output html for viewpoint.
+func (h *HTML) OutputViewpoint(wr io.Writer, i int, v *schema.Viewpoint) error {
+	groups := []map[string]interface{}{}
+	nogroup := v.Schema.Tables
+	for _, g := range v.Groups {
+		tables, _, err := v.Schema.SeparateTablesThatAreIncludedOrNot(&schema.FilterOption{
+			Include:       g.Tables,
+			IncludeLabels: g.Labels,
+		})
+		if err != nil {
+			return errors.WithStack(err)
+		}
+		groups = append(groups, map[string]interface{}{
+			"Name":   g.Name,
+			"Desc":   g.Desc,
+			"Tables": tables,
+		})
+		nogroup = lo.Without(nogroup, tables...)
+	}
+	if len(v.Groups) > 0 && len(nogroup) > 0 {
+		groups = append(groups, map[string]interface{}{
+			"Name":   "-",
+			"Desc":   "",
+			"Tables": nogroup,
+		})
+	}
+	data := map[string]interface{}{
+		"Title":     v.Name,
+		"Viewpoint": v,
+		"Index":     i,
+		"Groups":    groups,
+	}
+	if err := h.withER(data, func(g *gviz.Gviz, wr io.Writer) error {
+		return g.OutputViewpoint(wr, v)
+	}); err != nil {
+		return err
+	}
+	return h.execute(wr, "viewpoint", data)
+}
+
+// OutputSearchIndex output the search index of tables, columns and viewpoints.
+func (h *HTML) OutputSearchIndex(wr io.Writer, s *schema.Schema) error {
+	entries := []*SearchEntry{}
+	for _, t := range s.Tables {
+		entries = append(entries, &SearchEntry{
+			Kind:        "table",
+			Name:        t.Name,
+			LogicalName: t.GetEnhancedLogicalNameOrFallback(false),
+			Comment:     t.GetDescription(),
+			URL:         tableURL(t.Name),
+		})
+		for _, c := range t.Columns {
+			entries = append(entries, &SearchEntry{
+				Kind:        "column",
+				Name:        fmt.Sprintf("%s.%s", t.Name, c.Name),
+				LogicalName: c.GetEnhancedLogicalNameOrFallback(false),
+				Comment:     c.GetDescription(),
+				URL:         fmt.Sprintf("%s#%s", tableURL(t.Name), columnID(c.Name)),
+			})
+		}
+	}
+	for i, v := range s.Viewpoints {
+		entries = append(entries, &SearchEntry{
+			Kind:    "viewpoint",
+			Name:    v.Name,
+			Comment: v.Desc,
+			URL:     viewpointURL(i),
+		})
+	}
+	b, err := json.Marshal(entries)
+	if err != nil {
+		return errors.WithStack(err)
+	}
+	if _, err := fmt.Fprintf(wr, "window.tblsSearchIndex = %s;\n", b); err != nil {
+		return errors.WithStack(err)
+	}
+	return nil
+}
+
+// withER renders the ER diagram as SVG into data["ER"] unless ER is skipped.
+func (h *HTML) withER(data map[string]interface{}, render func(g *gviz.Gviz, wr io.Writer) error) error {
+	if h.config.ER.Skip {
+		return nil
+	}
+	// the site embeds SVG regardless of the ER format of Markdown documents
+	format := h.config.ER.Format
+	h.config.ER.Format = "svg"
+	defer func() {
+		h.config.ER.Format = format
+	}()
+	buf := new(bytes.Buffer)
+	if err := render(gviz.New(h.config), buf); err != nil {
+		return errors.WithStack(err)
+	}
+	svg := buf.String()
+	// strip the XML declaration and DOCTYPE to inline the SVG
+	if i := strings.Index(svg, "<svg"); i > 0 {
+		svg = svg[i:]
+	}
+	data["ER"] = template.HTML(svg) // #nosec
+	return nil
+}
+
+func (h *HTML) execute(wr io.Writer, page string, data map[string]interface{}) error {
+	t, err := template.New("layout").Funcs(h.funcs()).ParseFS(h.tmpl, "templates/layout.html.tmpl", fmt.Sprintf("templates/%s.html.tmpl", page))
+	if err != nil {
+		return errors.WithStack(err)
+	}
+	data["Page"] = page
+	data["SiteName"] = h.name
+	if h.name == "" {
+		data["SiteName"] = h.config.Name
+	}
+	if err := t.ExecuteTemplate(wr, "layout", data); err != nil {
+		return errors.WithStack(err)
+	}
+	return nil
+}
+
+func (h *HTML) funcs() template.FuncMap {
+	funcs := template.FuncMap(output.Funcs(&h.config.MergedDict))
+	funcs["table_url"] = tableURL
+	funcs["viewpoint_url"] = viewpointURL
+	funcs["column_id"] = columnID
+	funcs["column_url"] = func(name string) string {
+		return "#" + columnID(name)
+	}
+	funcs["child_tables"] = func(c *schema.Column) []*schema.Table {
+		return lo.UniqBy(lo.Map(c.ChildRelations, func(r *schema.Relation, _ int) *schema.Table {
+			return r.Table
+		}), func(t *schema.Table) string {
+			return t.Name
+		})
+	}
+	funcs["parent_tables"] = func(c *schema.Column) []*schema.Table {
+		return lo.UniqBy(lo.Map(c.ParentRelations, func(r *schema.Relation, _ int) *schema.Table {
+			return r.ParentTable
+		}), func(t *schema.Table) string {
+			return t.Name
+		})
+	}
+	funcs["classification_style"] = func(c *schema.Classification) template.CSS {
+		if color := output.ClassificationColor(c); color != "" {
+			return template.CSS(fmt.Sprintf("background-color: %s", color)) // #nosec
+		}
+		return ""
+	}
+	return funcs
+}
+
+// Output generate the static HTML documentation site.
+func Output(s *schema.Schema, c *config.Config, force bool) (e error) {
+	docPath := c.DocPath
+
+	fullPath, err := filepath.Abs(docPath)
+	if err != nil {
+		return errors.WithStack(err)
+	}
+
+	if !force && outputExists(s, fullPath) {
+		return errors.New("output html files already exists")
+	}
+
+	if err := os.MkdirAll(filepath.Join(fullPath, AssetsDir), 0755); err != nil { // #nosec
+		return errors.WithStack(err)
+	}
+
+	h := New(c)
+	h.name = s.Name
+	write := func(fn string, out func(wr io.Writer) error) error {
+		f, err := os.Create(filepath.Clean(filepath.Join(fullPath, fn)))
+		if err != nil {
+			return errors.WithStack(err)
+		}
+		if err := out(f); err != nil {
+			_ = f.Close()
+			return errors.WithStack(err)
+		}
+		fmt.Printf("%s\n", filepath.Join(docPath, fn))
+		return f.Close()
+	}
+
+	// assets
+	assets, err := fs.ReadDir(h.tmpl, AssetsDir)
+	if err != nil {
+		return errors.WithStack(err)
+	}
+	for _, a := range assets {
+		b, err := h.tmpl.ReadFile(filepath.ToSlash(filepath.Join(AssetsDir, a.Name())))
+		if err != nil {
+			return errors.WithStack(err)
+		}
+		if err := write(filepath.Join(AssetsDir, a.Name()), func(wr io.Writer) error {
+			_, err := wr.Write(b)
+			return err
+		}); err != nil {
+			return err
+		}
+	}
+	if err := write(filepath.Join(AssetsDir, SearchIndexFileName), func(wr io.Writer) error {
+		return h.OutputSearchIndex(wr, s)
+	}); err != nil {
+		return err
+	}
+
+	// index.html
+	if err := write(IndexFileName, func(wr io.Writer) error {
+		return h.OutputSchema(wr, s)
+	}); err != nil {
+		return err
+	}
+
+	// tables
+	for _, t := range s.Tables {
+		if err := write(tableFileName(t.Name), func(wr io.Writer) error {
+			return h.OutputTable(wr, t)
+		}); err != nil {
+			return err
+		}
+	}
+
+	// viewpoints
+	for i, v := range s.Viewpoints {
+		if err := write(viewpointURL(i), func(wr io.Writer) error {
+			return h.OutputViewpoint(wr, i, v)
+		}); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func outputExists(s *schema.Schema, path string) bool {
+	// index.html
+	if _, err := os.Lstat(filepath.Join(path, IndexFileName)); err == nil {
+		return true
+	}
+	// tables
+	for _, t := range s.Tables {
+		if _, err := os.Lstat(filepath.Join(path, tableFileName(t.Name))); err == nil {
+			return true
+		}
+	}
+	return false
+}
+
+func tableFileName(name string) string {
+	return fmt.Sprintf("%s.html", name)
+}
+
+// tableURL returns the link to the table page. The name is escaped as with the links of Markdown documents.
+func tableURL(name string) string {
+	return fmt.Sprintf("%s.html", mdurl.Encode(name))
+}
+
+func viewpointURL(i int) string {
+	return fmt.Sprintf("viewpoint-%d.html", i)
+}
+
+// columnID returns the id of the column row. It is also used as the fragment of links, so the name is escaped.
+func columnID(name string) string {
+	return fmt.Sprintf("column-%s", mdurl.Encode(name))
+}
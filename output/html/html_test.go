@@ -0,0 +1,206 @@
+package html
+
+import (
+	"bytes"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/k1LoW/tbls/config"
+	"github.com/k1LoW/tbls/schema"
+	"github.com/k1LoW/tbls/testutil"
+)
+
+func TestOutput(t *testing.T) {
+	s := testutil.NewSchema(t)
+	c, err := config.New()
+	if err != nil {
+		t.Fatal(err)
+	}
+	docPath := t.TempDir()
+	if err := c.Load(filepath.Join(testdataDir(), "out_test_tbls.yml"), config.DocPath(docPath), config.ERSkip(true)); err != nil {
+		t.Fatal(err)
+	}
+	if err := c.ModifySchema(s); err != nil {
+		t.Fatal(err)
+	}
+	if err := Output(s, c, true); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		file string
+		want []string
+	}{
+		{IndexFileName, []string{`<link rel="stylesheet" href="assets/style.css">`, `<a href="a.html">a</a>`, `<a href="viewpoint-0.html">table a b</a>`}},
+		{"a.html", []string{`<tr id="column-a">`, `<a href="b.html">b</a>`, "TABLE A"}},
+		{"viewpoint-0.html", []string{"<h1>table a b</h1>", `<a href="a.html">a</a>`, `<a href="b.html">b</a>`}},
+		{filepath.Join(AssetsDir, "style.css"), []string{"prefers-color-scheme: dark"}},
+		{filepath.Join(AssetsDir, "tbls.js"), []string{"tblsSearchIndex"}},
+		{filepath.Join(AssetsDir, SearchIndexFileName), []string{`"url":"a.html#column-a"`}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.file, func(t *testing.T) {
+			b, err := os.ReadFile(filepath.Join(docPath, tt.file))
+			if err != nil {
+				t.Fatal(err)
+			}
+			for _, w := range tt.want {
+				if !strings.Contains(string(b), w) {
+					t.Errorf("%s does not contain %q", tt.file, w)
+				}
+			}
+			// the site should work without network access
+			if strings.Contains(string(b), "cdn") {
+				t.Errorf("%s refers to an external CDN", tt.file)
+			}
+		})
+	}
+
+	if err := Output(s, c, false); err == nil {
+		t.Error("want error")
+	}
+}
+
+func TestOutputTable(t *testing.T) {
+	c, err := config.New()
+	if err != nil {
+		t.Fatal(err)
+	}
+	c.ER.Skip = true
+	tbl := &schema.Table{
+		Name:    "users",
+		Comment: "<b>users</b>\nsecond line",
+		Columns: []*schema.Column{
+			{Name: "id", Type: "bigint"},
+			{Name: "email", Type: "text", LogicalName: "Email", Classification: &schema.Classification{Level: schema.ClassificationPII, Category: "email", Source: schema.ClassificationSourceConfig}},
+		},
+	}
+	h := New(c)
+	buf := new(bytes.Buffer)
+	if err := h.OutputTable(buf, tbl); err != nil {
+		t.Fatal(err)
+	}
+	got := buf.String()
+	for _, w := range []string{
+		"&lt;b&gt;users&lt;/b&gt;\nsecond line",
+		`<tr id="column-email">`,
+		`<div class="logical-name">Email</div>`,
+		`<td style="background-color: #FFD6D6">pii:email</td>`,
+	} {
+		if !strings.Contains(got, w) {
+			t.Errorf("got %s\nwant contains %q", got, w)
+		}
+	}
+	if strings.Contains(got, `class="er"`) {
+		t.Error("ER should be skipped")
+	}
+}
+
+func TestOutputSearchIndex(t *testing.T) {
+	c, err := config.New()
+	if err != nil {
+		t.Fatal(err)
+	}
+	s := &schema.Schema{
+		Name: "testdb",
+		Tables: []*schema.Table{
+			{
+				Name:        "users",
+				Comment:     `{"description": "Registered users", "logicalName": "User"}`,
+				LogicalName: "User",
+				EnhancedCommentData: &schema.CommentData{
+					Description: "Registered users",
+					LogicalName: "User",
+				},
+				Columns: []*schema.Column{
+					{Name: "id", Type: "bigint", Comment: "ID"},
+				},
+			},
+		},
+		Viewpoints: schema.Viewpoints{
+			{Name: "users", Desc: "Tables of users"},
+		},
+	}
+	buf := new(bytes.Buffer)
+	if err := New(c).OutputSearchIndex(buf, s); err != nil {
+		t.Fatal(err)
+	}
+	prefix := "window.tblsSearchIndex = "
+	got := buf.String()
+	if !strings.HasPrefix(got, prefix) {
+		t.Fatalf("got %s", got)
+	}
+	entries := []*SearchEntry{}
+	if err := json.Unmarshal([]byte(strings.TrimSuffix(strings.TrimPrefix(got, prefix), ";\n")), &entries); err != nil {
+		t.Fatal(err)
+	}
+	want := []*SearchEntry{
+		{Kind: "table", Name: "users", LogicalName: "User", Comment: "Registered users", URL: "users.html"},
+		{Kind: "column", Name: "users.id", Comment: "ID", URL: "users.html#column-id"},
+		{Kind: "viewpoint", Name: "users", Comment: "Tables of users", URL: "viewpoint-0.html"},
+	}
+	if diff := cmp.Diff(want, entries); diff != "" {
+		t.Error(diff)
+	}
+}
+
+func TestOutputEscapedNames(t *testing.T) {
+	c, err := config.New()
+	if err != nil {
+		t.Fatal(err)
+	}
+	c.ER.Skip = true
+	c.DocPath = t.TempDir()
+	users := &schema.Table{
+		Name:    "user data",
+		Columns: []*schema.Column{{Name: "first name", Type: "text"}},
+	}
+	posts := &schema.Table{
+		Name:    "posts",
+		Columns: []*schema.Column{{Name: "user id", Type: "int"}},
+	}
+	s := &schema.Schema{
+		Name:   "testdb",
+		Tables: []*schema.Table{users, posts},
+		Relations: []*schema.Relation{
+			{Table: posts, Columns: posts.Columns, ParentTable: users, ParentColumns: users.Columns},
+		},
+	}
+	if err := s.Repair(); err != nil {
+		t.Fatal(err)
+	}
+	if err := Output(s, c, true); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		file string
+		want []string
+	}{
+		{IndexFileName, []string{`<a href="user%20data.html">user data</a>`}},
+		{"user data.html", []string{`<tr id="column-first%20name">`, `<a class="anchor" href="#column-first%20name">first name</a>`}},
+		{"posts.html", []string{`<a href="user%20data.html">user data</a>`}},
+		{filepath.Join(AssetsDir, SearchIndexFileName), []string{`"url":"user%20data.html#column-first%20name"`}},
+	}
+	for _, tt := range tests {
+		b, err := os.ReadFile(filepath.Join(c.DocPath, tt.file))
+		if err != nil {
+			t.Fatal(err)
+		}
+		for _, w := range tt.want {
+			if !strings.Contains(string(b), w) {
+				t.Errorf("%s does not contain %q", tt.file, w)
+			}
+		}
+	}
+}
+
+func testdataDir() string {
+	wd, _ := os.Getwd()
+	dir, _ := filepath.Abs(filepath.Join(filepath.Dir(filepath.Dir(wd)), "testdata"))
+	return dir
+}
@@ -13,7 +13,9 @@ import (
 	"text/template"
 
 	"github.com/k1LoW/errors"
+	"github.com/k1LoW/tbls/changelog"
 	"github.com/k1LoW/tbls/config"
+	"github.com/k1LoW/tbls/glossary"
 	"github.com/k1LoW/tbls/output"
 	"github.com/k1LoW/tbls/output/mermaid"
 	"github.com/k1LoW/tbls/schema"
@@ -34,16 +36,22 @@ var tmpl embed.FS
 
 // Md struct.
 type Md struct {
-	config *config.Config
-	tmpl   embed.FS
+	config    *config.Config
+	tmpl      embed.FS
+	changelog *changelog.Changelog
+	glossary  *glossary.Glossary
 }
 
 // New return Md.
 func New(c *config.Config) *Md {
-	return &Md{
+	m := &Md{
 		config: c,
 		tmpl:   tmpl,
 	}
+	if len(c.Glossary.Terms) > 0 {
+		m.glossary = glossary.New(c.Glossary.Terms)
+	}
+	return m
 }
 
 // OutputSchema output .md format for all tables.
@@ -130,6 +138,97 @@ func (m *Md) OutputViewpoint(wr io.Writer, i int, v *schema.Viewpoint) error {
 	return nil
 }
 
+// OutputChangelog output md format for changelog.
+func (m *Md) OutputChangelog(wr io.Writer, cl *changelog.Changelog) error {
+	ts, err := m.tmpl.ReadFile("templates/changelog.md.tmpl")
+	if err != nil {
+		return errors.WithStack(err)
+	}
+	tmpl := template.Must(template.New("changelog").Funcs(output.Funcs(&m.config.MergedDict)).Parse(string(ts)))
+	entries := []map[string]interface{}{}
+	for i := len(cl.Entries) - 1; i >= 0; i-- {
+		e := cl.Entries[i]
+		changes := []string{}
+		for _, c := range e.Changes {
+			changes = append(changes, changelog.Describe(c))
+		}
+		entries = append(entries, map[string]interface{}{
+			"Date":    e.Date.Format(changelog.DateFormat),
+			"Changes": changes,
+		})
+	}
+	templateData := map[string]interface{}{
+		"Entries": entries,
+	}
+	if err := tmpl.Execute(wr, templateData); err != nil {
+		return errors.WithStack(err)
+	}
+	return nil
+}
+
+// OutputGlossary output md format for glossary.
+func (m *Md) OutputGlossary(wr io.Writer, s *schema.Schema) error {
+	ts, err := m.tmpl.ReadFile("templates/glossary.md.tmpl")
+	if err != nil {
+		return errors.WithStack(err)
+	}
+	tmpl := template.Must(template.New("glossary").Funcs(output.Funcs(&m.config.MergedDict)).Parse(string(ts)))
+	refs := m.glossary.References(s)
+	terms := []map[string]interface{}{}
+	for _, t := range m.glossary.Terms() {
+		columnsData := [][]string{
+			{
+				m.config.MergedDict.Lookup("Table"),
+				m.config.MergedDict.Lookup("Column"),
+			},
+			{"-----", "------"},
+		}
+		for _, r := range refs[t] {
+			columnsData = append(columnsData, []string{
+				fmt.Sprintf("[%s](%s%s.md)", r.Table, m.config.BaseURL, mdurl.Encode(r.Table)),
+				r.Column,
+			})
+		}
+		if m.config.Format.Adjust {
+			columnsData = adjustTable(columnsData)
+		}
+		terms = append(terms, map[string]interface{}{
+			"Name":       t.Name,
+			"Definition": t.Definition,
+			"Synonyms":   t.Synonyms,
+			"Owners":     t.Owners,
+			"Columns":    columnsData,
+			"Referenced": len(refs[t]) > 0,
+		})
+	}
+	if err := tmpl.Execute(wr, map[string]interface{}{
+		"Terms": terms,
+	}); err != nil {
+		return errors.WithStack(err)
+	}
+	return nil
+}
+
+// linkTerms links glossary terms in text to glossary.md.
+func (m *Md) linkTerms(text string) string {
+	if m.glossary == nil {
+		return text
+	}
+	return m.glossary.Link(text, func(t *glossary.Term) string {
+		return fmt.Sprintf("%s%s#%s", m.config.BaseURL, glossary.FileName, glossary.Anchor(t))
+	})
+}
+
+// loadChangelog load changelog in the doc path for History of tables.
+func (m *Md) loadChangelog(docPath string) error {
+	cl, err := changelog.Load(filepath.Join(docPath, changelog.DataFileName))
+	if err != nil {
+		return err
+	}
+	m.changelog = cl
+	return nil
+}
+
 // Output generate markdown files.
 func Output(s *schema.Schema, c *config.Config, force bool) (e error) {
 	docPath := c.DocPath
@@ -160,6 +259,11 @@ func Output(s *schema.Schema, c *config.Config, force bool) (e error) {
 		return errors.WithStack(err)
 	}
 	md := New(c)
+	if c.Changelog.Enabled {
+		if err := md.loadChangelog(fullPath); err != nil {
+			return err
+		}
+	}
 	if err := md.OutputSchema(f, s); err != nil {
 		return errors.WithStack(err)
 	}
@@ -200,6 +304,38 @@ func Output(s *schema.Schema, c *config.Config, force bool) (e error) {
 		}
 	}
 
+	// glossary.md
+	if md.glossary != nil {
+		f, err := os.Create(filepath.Clean(filepath.Join(fullPath, glossary.FileName)))
+		if err != nil {
+			return errors.WithStack(err)
+		}
+		if err := md.OutputGlossary(f, s); err != nil {
+			_ = f.Close()
+			return errors.WithStack(err)
+		}
+		fmt.Printf("%s\n", filepath.Join(docPath, glossary.FileName))
+		if err := f.Close(); err != nil {
+			return errors.WithStack(err)
+		}
+	}
+
+	// CHANGELOG.md
+	if md.changelog != nil {
+		f, err := os.Create(filepath.Clean(filepath.Join(fullPath, changelog.FileName)))
+		if err != nil {
+			return errors.WithStack(err)
+		}
+		if err := md.OutputChangelog(f, md.changelog); err != nil {
+			_ = f.Close()
+			return errors.WithStack(err)
+		}
+		fmt.Printf("%s\n", filepath.Join(docPath, changelog.FileName))
+		if err := f.Close(); err != nil {
+			return errors.WithStack(err)
+		}
+	}
+
 	return nil
 }
 
@@ -325,6 +461,11 @@ func DiffSchemaAndDocs(docPath string, s *schema.Schema, c *config.Config) (stri
 
 	// README.md
 	md := New(c)
+	if c.Changelog.Enabled {
+		if err := md.loadChangelog(fullPath); err != nil {
+			return "", err
+		}
+	}
 	buf := new(bytes.Buffer)
 	if err := md.OutputSchema(buf, s); err != nil {
 		return "", errors.WithStack(err)
@@ -358,6 +499,8 @@ func DiffSchemaAndDocs(docPath string, s *schema.Schema, c *config.Config) (stri
 		diff += text
 	}
 	diffed["README.md"] = struct{}{}
+	// CHANGELOG.md is the history of documents, not the current database
+	diffed[changelog.FileName] = struct{}{}
 
 	// tables
 	for _, t := range s.Tables {
@@ -422,6 +565,36 @@ func DiffSchemaAndDocs(docPath string, s *schema.Schema, c *config.Config) (stri
 		diffed[fn] = struct{}{}
 	}
 
+	// glossary.md
+	if md.glossary != nil {
+		buf := new(bytes.Buffer)
+		to := fmt.Sprintf("%s %s", mdsn, "glossary")
+		if err := md.OutputGlossary(buf, s); err != nil {
+			return "", errors.WithStack(err)
+		}
+		targetPath := filepath.Join(fullPath, glossary.FileName)
+		a, err := os.ReadFile(filepath.Clean(targetPath))
+		if err != nil {
+			a = []byte{}
+		}
+		from := filepath.Join(docPath, glossary.FileName)
+
+		d := difflib.UnifiedDiff{
+			A:        difflib.SplitLines(string(a)),
+			B:        difflib.SplitLines(buf.String()),
+			FromFile: from,
+			ToFile:   to,
+			Context:  3,
+		}
+
+		text, _ := difflib.GetUnifiedDiffString(d)
+		if text != "" {
+			diff += fmt.Sprintf("diff '%s' '%s'\n", from, to)
+			diff += text
+		}
+		diffed[glossary.FileName] = struct{}{}
+	}
+
 	files, err := os.ReadDir(fullPath)
 	if err != nil {
 		return "", errors.WithStack(err)
@@ -555,10 +728,15 @@ func (m *Md) makeTableTemplateData(t *schema.Table) map[string]interface{} {
 	m.adjustColumnHeader(&columnsHeader, &columnsHeaderLine, t.ShowColumn(schema.ColumnChildren, hideColumns), "Children")
 	m.adjustColumnHeader(&columnsHeader, &columnsHeaderLine, t.ShowColumn(schema.ColumnParents, hideColumns), "Parents")
 	m.adjustColumnHeader(&columnsHeader, &columnsHeaderLine, t.ShowColumn(schema.ColumnComment, hideColumns), "Comment")
+	m.adjustColumnHeader(&columnsHeader, &columnsHeaderLine, t.ShowColumn(schema.ColumnTags, hideColumns), "Tags")
+	m.adjustColumnHeader(&columnsHeader, &columnsHeaderLine, t.ShowColumn(schema.ColumnDeprecated, hideColumns), "Deprecated")
 	m.adjustColumnHeader(&columnsHeader, &columnsHeaderLine, t.ShowColumn(schema.ColumnLabels, hideColumns), "Labels")
 
 	columnsData = append(columnsData, columnsHeader, columnsHeaderLine)
 
+	// Column metadata
+	columnMetadata := []map[string]interface{}{}
+
 	for _, c := range t.Columns {
 		childRelations := []string{}
 		cEncountered := map[string]bool{}
@@ -592,7 +770,11 @@ func (m *Md) makeTableTemplateData(t *schema.Table) map[string]interface{} {
 		data := []string{
 			c.Name,
 		}
-		adjustData(&data, m.config.IsLogicalNameEnabled(), mdEscRep.Replace(c.GetLogicalNameOrFallback(m.config.LogicalNameFallbackToName())))
+		inherited := c.Inherited
+		if inherited == nil {
+			inherited = &schema.InheritedComment{}
+		}
+		adjustData(&data, m.config.IsLogicalNameEnabled(), italic(m.linkTerms(mdEscRep.Replace(c.GetLogicalNameOrFallback(m.config.LogicalNameFallbackToName()))), inherited.LogicalName != ""))
 		data = append(data,
 			c.Type,
 			c.Default.String,
@@ -603,9 +785,18 @@ func (m *Md) makeTableTemplateData(t *schema.Table) map[string]interface{} {
 		adjustData(&data, t.ShowColumn(schema.ColumnPercents, hideColumns), fmt.Sprintf("%.1f", c.Percents.Float64))
 		adjustData(&data, t.ShowColumn(schema.ColumnChildren, hideColumns), strings.Join(childRelations, " "))
 		adjustData(&data, t.ShowColumn(schema.ColumnParents, hideColumns), strings.Join(parentRelations, " "))
-		adjustData(&data, t.ShowColumn(schema.ColumnComment, hideColumns), mdEscRep.Replace(c.Comment))
+		adjustData(&data, t.ShowColumn(schema.ColumnComment, hideColumns), italic(m.linkTerms(mdEscRep.Replace(columnDescription(c))), inherited.Description != ""))
+		adjustData(&data, t.ShowColumn(schema.ColumnTags, hideColumns), italic(mdEscRep.Replace(strings.Join(c.GetTags(), ", ")), len(inherited.Tags) > 0))
+		adjustData(&data, t.ShowColumn(schema.ColumnDeprecated, hideColumns), deprecatedValue(c.IsDeprecated()))
 		adjustData(&data, t.ShowColumn(schema.ColumnLabels, hideColumns), output.LabelJoin(c.Labels))
 		columnsData = append(columnsData, data)
+
+		if metadata := c.EnhancedCommentData.UserMetadata(); len(metadata) > 0 {
+			columnMetadata = append(columnMetadata, map[string]interface{}{
+				"Name":     c.Name,
+				"Metadata": m.metadataData(metadata, adjust),
+			})
+		}
 	}
 
 	// Viewpoints
@@ -719,6 +910,23 @@ func (m *Md) makeTableTemplateData(t *schema.Table) map[string]interface{} {
 		triggersData = append(triggersData, data)
 	}
 
+	// History
+	historyData := [][]string{
+		{
+			m.config.MergedDict.Lookup("Date"),
+			m.config.MergedDict.Lookup("Change"),
+		},
+		{"----", "------"},
+	}
+	if m.changelog != nil {
+		for _, h := range m.changelog.TableHistory(t.Name) {
+			historyData = append(historyData, []string{
+				h.Date.Format(changelog.DateFormat),
+				strings.ReplaceAll(changelog.Describe(h.Change), "|", `\|`),
+			})
+		}
+	}
+
 	// Referenced Tables
 	hasReferencedTableWithLabels := false
 	for _, rt := range t.ReferencedTables {
@@ -742,11 +950,17 @@ func (m *Md) makeTableTemplateData(t *schema.Table) map[string]interface{} {
 		return map[string]interface{}{
 			"Table":            t,
 			"DisplayFormat":    m.config.TableLogicalNameDisplayFormat(),
+			"Description":      m.linkTerms(description(t.Comment, t.EnhancedCommentData)),
+			"Tags":             t.GetTags(),
+			"Deprecated":       t.IsDeprecated(),
+			"Metadata":         m.metadataData(t.EnhancedCommentData.UserMetadata(), adjust),
 			"Columns":          adjustTable(columnsData),
+			"ColumnMetadata":   columnMetadata,
 			"Viewpoints":       adjustTable(viewpointsData),
 			"Constraints":      adjustTable(constraintsData),
 			"Indexes":          adjustTable(indexesData),
 			"Triggers":         adjustTable(triggersData),
+			"History":          adjustTable(historyData),
 			"ReferencedTables": adjustTable(referencedTables),
 		}
 	}
@@ -754,11 +968,17 @@ func (m *Md) makeTableTemplateData(t *schema.Table) map[string]interface{} {
 	return map[string]interface{}{
 		"Table":            t,
 		"DisplayFormat":    m.config.TableLogicalNameDisplayFormat(),
+		"Description":      m.linkTerms(description(t.Comment, t.EnhancedCommentData)),
+		"Tags":             t.GetTags(),
+		"Deprecated":       t.IsDeprecated(),
+		"Metadata":         m.metadataData(t.EnhancedCommentData.UserMetadata(), adjust),
 		"Columns":          columnsData,
+		"ColumnMetadata":   columnMetadata,
 		"Viewpoints":       viewpointsData,
 		"Constraints":      constraintsData,
 		"Indexes":          indexesData,
 		"Triggers":         triggersData,
+		"History":          historyData,
 		"ReferencedTables": referencedTables,
 	}
 }
@@ -832,7 +1052,7 @@ func (m *Md) tablesData(tables []*schema.Table, number, adjust, showOnlyFirstPar
 	)
 
 	for _, t := range tables {
-		comment := t.Comment
+		comment := description(t.Comment, t.EnhancedCommentData)
 		if showOnlyFirstParagraph {
 			comment = output.ShowOnlyFirstParagraph(comment)
 		}
@@ -842,10 +1062,14 @@ func (m *Md) tablesData(tables []*schema.Table, number, adjust, showOnlyFirstPar
 			displayName = t.GetDisplayName(m.config.TableLogicalNameDisplayFormat())
 		}
 		
+		link := fmt.Sprintf("[%s](%s%s.md)", displayName, m.config.BaseURL, mdurl.Encode(t.Name))
+		if t.IsDeprecated() {
+			link = fmt.Sprintf("%s `%s`", link, m.config.MergedDict.Lookup("Deprecated"))
+		}
 		d := []string{
-			fmt.Sprintf("[%s](%s%s.md)", displayName, m.config.BaseURL, mdurl.Encode(t.Name)),
+			link,
 			fmt.Sprintf("%d", len(t.Columns)),
-			comment,
+			m.linkTerms(comment),
 			t.Type,
 		}
 		if hasTableWithLabels {
@@ -964,6 +1188,60 @@ func (m *Md) viewpointsData(viewpoints []*schema.Viewpoint, number, adjust, show
 	return data
 }
 
+// metadataData returns the key-value table of the metadata of the enhanced comment.
+func (m *Md) metadataData(metadata map[string]string, adjust bool) [][]string {
+	if len(metadata) == 0 {
+		return nil
+	}
+	data := [][]string{
+		{
+			m.config.MergedDict.Lookup("Key"),
+			m.config.MergedDict.Lookup("Value"),
+		},
+		{"---", "-----"},
+	}
+	keys := lo.Keys(metadata)
+	sort.Strings(keys)
+	for _, k := range keys {
+		data = append(data, []string{mdEscRep.Replace(k), mdEscRep.Replace(metadata[k])})
+	}
+	if adjust {
+		return adjustTable(data)
+	}
+	return data
+}
+
+// description returns the description of the enhanced comment if the comment is structured ( JSON or YAML ).
+func description(comment string, data *schema.CommentData) string {
+	if data != nil && schema.IsStructuredComment(comment) {
+		return data.Description
+	}
+	return comment
+}
+
+// columnDescription returns the description of the column, or the description inherited from the parent column if it has none.
+func columnDescription(c *schema.Column) string {
+	if c.Inherited != nil && c.Inherited.Description != "" {
+		return c.Inherited.Description
+	}
+	return description(c.Comment, c.EnhancedCommentData)
+}
+
+// italic emphasizes the value inherited from the parent column of the foreign key.
+func italic(value string, inherited bool) string {
+	if !inherited || value == "" {
+		return value
+	}
+	return "*" + value + "*"
+}
+
+func deprecatedValue(deprecated bool) string {
+	if deprecated {
+		return "true"
+	}
+	return ""
+}
+
 func adjustData(data *[]string, hasData bool, value string) {
 	if hasData {
 		*data = append(*data, value)
@@ -1,12 +1,17 @@
 package md
 
 import (
+	"encoding/json"
 	"os"
 	"path/filepath"
 	"slices"
+	"strings"
 	"testing"
+	"time"
 
+	"github.com/k1LoW/tbls/changelog"
 	"github.com/k1LoW/tbls/config"
+	"github.com/k1LoW/tbls/glossary"
 	"github.com/k1LoW/tbls/schema"
 	"github.com/k1LoW/tbls/testutil"
 	"github.com/tenntenn/golden"
@@ -249,6 +254,247 @@ func TestDiffSchemas(t *testing.T) {
 	}
 }
 
+func TestOutputChangelog(t *testing.T) {
+	s := testutil.NewSchema(t)
+	c, err := config.New()
+	if err != nil {
+		t.Fatal(err)
+	}
+	docPath := t.TempDir()
+	if err := c.Load(filepath.Join(testdataDir(), "out_test_tbls.yml"), config.DocPath(docPath), config.ERSkip(true)); err != nil {
+		t.Fatal(err)
+	}
+	c.Changelog.Enabled = true
+	if err := c.ModifySchema(s); err != nil {
+		t.Fatal(err)
+	}
+	if err := Output(s, c, true); err != nil {
+		t.Fatal(err)
+	}
+	b, err := json.Marshal(s)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(c.SchemaFilePath(), b, 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	ta, err := s.FindTableByName("a")
+	if err != nil {
+		t.Fatal(err)
+	}
+	ta.Columns = append(ta.Columns, &schema.Column{
+		Name:     "added",
+		Type:     "text",
+		Nullable: true,
+	})
+	cl, err := changelog.Build(docPath, s, c.DiffOption(), time.Now())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := cl.Write(filepath.Join(docPath, changelog.DataFileName)); err != nil {
+		t.Fatal(err)
+	}
+	if err := Output(s, c, true); err != nil {
+		t.Fatal(err)
+	}
+
+	got, err := os.ReadFile(filepath.Join(docPath, "CHANGELOG.md"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := "- column `a.added` added"; !strings.Contains(string(got), want) {
+		t.Errorf("got %v\nwant %v", string(got), want)
+	}
+	got, err = os.ReadFile(filepath.Join(docPath, "a.md"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := "## History"; !strings.Contains(string(got), want) {
+		t.Errorf("got %v\nwant %v", string(got), want)
+	}
+
+	diff, err := DiffSchemaAndDocs(docPath, s, c)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if diff != "" {
+		t.Errorf("got %v\nwant empty", diff)
+	}
+}
+
+func TestOutputEnhancedComment(t *testing.T) {
+	s := testutil.NewSchema(t)
+	ta, err := s.FindTableByName("a")
+	if err != nil {
+		t.Fatal(err)
+	}
+	ta.Comment = `{"name": "テーブルA", "description": "table a", "deprecated": true, "tags": ["core"]}`
+	ta.Columns[0].Comment = `{"description": "column a", "tags": ["pk", "core"], "owner": "team-a"}`
+	ta.Columns[1].Comment = `{"description": "column a2", "deprecated": true}`
+	c, err := config.New()
+	if err != nil {
+		t.Fatal(err)
+	}
+	docPath := t.TempDir()
+	if err := c.Load(filepath.Join(testdataDir(), "empty.yml"), config.DocPath(docPath), config.ERSkip(true)); err != nil {
+		t.Fatal(err)
+	}
+	c.EnhancedComment.Enabled = true
+	c.EnhancedComment.Parser.EnableJSON = true
+	if err := c.ModifySchema(s); err != nil {
+		t.Fatal(err)
+	}
+	if err := Output(s, c, true); err != nil {
+		t.Fatal(err)
+	}
+
+	got, err := os.ReadFile(filepath.Join(docPath, "README.md"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := "[a](a.md) `Deprecated` | 2 | table a |"; !strings.Contains(string(got), want) {
+		t.Errorf("got %v\nwant %v", string(got), want)
+	}
+
+	got, err = os.ReadFile(filepath.Join(docPath, "a.md"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, want := range []string{
+		"> **Deprecated**",
+		"Tags: `core`",
+		"| Comment | Tags | Deprecated |",
+		"| column a | pk, core |  |",
+		"| column a2 |  | true |",
+		"<summary><strong>a</strong> Metadata</summary>",
+		"| owner | team-a |",
+	} {
+		if !strings.Contains(string(got), want) {
+			t.Errorf("got %v\nwant %v", string(got), want)
+		}
+	}
+}
+
+func TestOutputInheritedComment(t *testing.T) {
+	s := testutil.NewSchema(t)
+	ta, err := s.FindTableByName("a")
+	if err != nil {
+		t.Fatal(err)
+	}
+	tb, err := s.FindTableByName("b")
+	if err != nil {
+		t.Fatal(err)
+	}
+	// b.b references a.a
+	ta.Columns[0].Comment = `{"name": "エー", "description": "column a", "tags": ["pk"]}`
+	tb.Columns[0].Comment = ""
+	c, err := config.New()
+	if err != nil {
+		t.Fatal(err)
+	}
+	docPath := t.TempDir()
+	if err := c.Load(filepath.Join(testdataDir(), "empty.yml"), config.DocPath(docPath), config.ERSkip(true)); err != nil {
+		t.Fatal(err)
+	}
+	c.EnhancedComment.Enabled = true
+	c.EnhancedComment.Parser.EnableJSON = true
+	c.Format.LogicalName.Enabled = true
+	c.InheritComments.Enabled = true
+	if err := c.ModifySchema(s); err != nil {
+		t.Fatal(err)
+	}
+	if err := Output(s, c, true); err != nil {
+		t.Fatal(err)
+	}
+
+	got, err := os.ReadFile(filepath.Join(docPath, "b.md"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := "| b | *エー* | INTEGER |"; !strings.Contains(string(got), want) {
+		t.Errorf("got %v\nwant %v", string(got), want)
+	}
+	if want := "| *column a* | *pk* |"; !strings.Contains(string(got), want) {
+		t.Errorf("got %v\nwant %v", string(got), want)
+	}
+}
+
+func TestOutputGlossary(t *testing.T) {
+	s := testutil.NewSchema(t)
+	c, err := config.New()
+	if err != nil {
+		t.Fatal(err)
+	}
+	docPath := t.TempDir()
+	if err := c.Load(filepath.Join(testdataDir(), "empty.yml"), config.DocPath(docPath), config.ERSkip(true)); err != nil {
+		t.Fatal(err)
+	}
+	c.Glossary.Terms = []*glossary.Term{
+		{Name: "table a", Definition: "The first table"},
+		{Name: "B2", Definition: "The second column of table b", Synonyms: []string{"column 2"}, Owners: []string{"team-b"}},
+	}
+	if err := c.ModifySchema(s); err != nil {
+		t.Fatal(err)
+	}
+	if err := Output(s, c, true); err != nil {
+		t.Fatal(err)
+	}
+
+	got, err := os.ReadFile(filepath.Join(docPath, "README.md"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := "| [table a](glossary.md#table-a) |"; !strings.Contains(string(got), want) {
+		t.Errorf("got %v\nwant %v", string(got), want)
+	}
+	got, err = os.ReadFile(filepath.Join(docPath, "b.md"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := "| column [b2](glossary.md#b2) |"; !strings.Contains(string(got), want) {
+		t.Errorf("got %v\nwant %v", string(got), want)
+	}
+	got, err = os.ReadFile(filepath.Join(docPath, "glossary.md"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := `# Glossary
+
+## B2
+
+The second column of table b
+
+- Synonyms: column 2
+- Owners: team-b
+
+### Referenced Columns
+
+| Table | Column |
+| ----- | ------ |
+| [b](b.md) | b2 |
+
+## table a
+
+The first table
+
+---
+
+> Generated by [tbls](https://github.com/k1LoW/tbls)
+`
+	if string(got) != want {
+		t.Errorf("got %v\nwant %v", string(got), want)
+	}
+
+	diff, err := DiffSchemaAndDocs(docPath, s, c)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if diff != "" {
+		t.Errorf("got %v", diff)
+	}
+}
+
 func testdataDir() string {
 	wd, _ := os.Getwd()
 	dir, _ := filepath.Abs(filepath.Join(filepath.Dir(filepath.Dir(wd)), "testdata"))
@@ -71,6 +71,7 @@ func (m *Mermaid) OutputSchema(wr io.Writer, s *schema.Schema) error {
 		"showComment":     m.config.ER.Comment,
 		"showDef":         !m.config.ER.HideDef,
 		"showColumnTypes": m.config.ER.ShowColumnTypes,
+		"classDefs":       output.ClassDefs(s.Tables),
 	}); err != nil {
 		return errors.WithStack(err)
 	}
@@ -97,6 +98,7 @@ func (m *Mermaid) OutputTable(wr io.Writer, t *schema.Table) error {
 		"showComment":     m.config.ER.Comment,
 		"showDef":         !m.config.ER.HideDef,
 		"showColumnTypes": m.config.ER.ShowColumnTypes,
+		"classDefs":       output.ClassDefs(tables),
 	}); err != nil {
 		return errors.WithStack(err)
 	}
@@ -20,6 +20,13 @@ type Output interface {
 
 var escapeMermaidRe = regexp.MustCompile(`[^a-zA-Z0-9_\-]`)
 
+// ClassificationColors are the background colors of classified columns in ER diagrams.
+var ClassificationColors = map[schema.ClassificationLevel]string{
+	schema.ClassificationInternal:     "#FFF9DB",
+	schema.ClassificationConfidential: "#FFE8CC",
+	schema.ClassificationPII:          "#FFD6D6",
+}
+
 func Funcs(d *dict.Dict) map[string]interface{} {
 	return template.FuncMap{
 		"nl2br": func(text string) string {
@@ -84,7 +91,39 @@ func Funcs(d *dict.Dict) map[string]interface{} {
 				return ""
 			}
 		},
+		"classification_color": ClassificationColor,
+		"classification_label": func(c *schema.Classification) string {
+			if c == nil {
+				return ""
+			}
+			return c.String()
+		},
+	}
+}
+
+// ClassificationColor return the background color of the classification. It returns "" if c is nil or public.
+func ClassificationColor(c *schema.Classification) string {
+	if c == nil {
+		return ""
+	}
+	return ClassificationColors[c.Level]
+}
+
+// ClassDefs return Mermaid classDef statements of the classifications of tables.
+func ClassDefs(tables []*schema.Table) []string {
+	used := map[schema.ClassificationLevel]bool{}
+	for _, t := range tables {
+		if color := ClassificationColor(t.Classification()); color != "" {
+			used[t.Classification().Level] = true
+		}
+	}
+	defs := []string{}
+	for _, l := range schema.ClassificationLevels {
+		if used[l] {
+			defs = append(defs, fmt.Sprintf("classDef %s fill:%s", l, ClassificationColors[l]))
+		}
 	}
+	return defs
 }
 
 func ShowOnlyFirstParagraph(text string) string {
@@ -0,0 +1,106 @@
+package pii
+
+import (
+	"fmt"
+	"io"
+	"slices"
+	"strings"
+
+	"github.com/k1LoW/tbls/config"
+	"github.com/k1LoW/tbls/output"
+	"github.com/k1LoW/tbls/schema"
+)
+
+var _ output.Output = &PII{}
+
+// cellEscRep is a replacer for markdown table cells.
+var cellEscRep = strings.NewReplacer(`|`, `\|`, "\n", " ")
+
+// PII struct.
+type PII struct {
+	config *config.Config
+}
+
+// New return PII.
+func New(c *config.Config) *PII {
+	return &PII{
+		config: c,
+	}
+}
+
+// OutputSchema output the inventory of classified columns of all tables.
+func (p *PII) OutputSchema(wr io.Writer, s *schema.Schema) error {
+	return p.output(wr, s.Name, s.Tables)
+}
+
+// OutputTable output the inventory of classified columns of the table.
+func (p *PII) OutputTable(wr io.Writer, t *schema.Table) error {
+	return p.output(wr, t.Name, []*schema.Table{t})
+}
+
+func (p *PII) output(wr io.Writer, name string, tables []*schema.Table) error {
+	d := &p.config.MergedDict
+	counts := map[schema.ClassificationLevel]int{}
+	unclassified := 0
+	rows := [][]string{}
+	for _, t := range tables {
+		for _, c := range t.Columns {
+			if c.Classification == nil {
+				unclassified++
+				continue
+			}
+			counts[c.Classification.Level]++
+			rows = append(rows, []string{
+				cellEscRep.Replace(t.Name),
+				cellEscRep.Replace(c.Name),
+				cellEscRep.Replace(c.Type),
+				string(c.Classification.Level),
+				cellEscRep.Replace(c.Classification.Category),
+				c.Classification.Source,
+			})
+		}
+	}
+
+	if _, err := fmt.Fprintf(wr, "# %s %s\n\n", name, d.Lookup("PII Inventory")); err != nil {
+		return err
+	}
+	summary := [][]string{
+		{d.Lookup("Classification"), d.Lookup("Columns")},
+		{"--------------", "-------"},
+	}
+	// the most sensitive level first
+	levels := slices.Clone(schema.ClassificationLevels)
+	slices.Reverse(levels)
+	for _, l := range levels {
+		summary = append(summary, []string{string(l), fmt.Sprintf("%d", counts[l])})
+	}
+	summary = append(summary, []string{d.Lookup("Unclassified"), fmt.Sprintf("%d", unclassified)})
+	if err := writeTable(wr, summary); err != nil {
+		return err
+	}
+	if len(rows) == 0 {
+		return nil
+	}
+
+	// the most sensitive columns first
+	slices.SortStableFunc(rows, func(a, b []string) int {
+		return schema.ClassificationLevel(b[3]).Rank() - schema.ClassificationLevel(a[3]).Rank()
+	})
+	columns := [][]string{
+		{d.Lookup("Table"), d.Lookup("Column"), d.Lookup("Type"), d.Lookup("Classification"), d.Lookup("Category"), d.Lookup("Source")},
+		{"-----", "------", "----", "--------------", "--------", "------"},
+	}
+	if _, err := fmt.Fprint(wr, "\n"); err != nil {
+		return err
+	}
+	return writeTable(wr, append(columns, rows...))
+}
+
+func writeTable(wr io.Writer, rows [][]string) error {
+	for _, r := range rows {
+		if _, err := fmt.Fprintf(wr, "| %s |\n", strings.Join(r, " | ")); err != nil {
+			return err
+		}
+	}
+	return nil
+}
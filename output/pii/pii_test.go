@@ -0,0 +1,66 @@
+package pii
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/k1LoW/tbls/config"
+	"github.com/k1LoW/tbls/schema"
+)
+
+func TestOutputSchema(t *testing.T) {
+	s := &schema.Schema{
+		Name: "testdb",
+		Tables: []*schema.Table{
+			{
+				Name: "users",
+				Columns: []*schema.Column{
+					{Name: "id", Type: "bigint"},
+					{Name: "plan", Type: "text", Classification: &schema.Classification{Level: schema.ClassificationInternal, Source: schema.ClassificationSourceConfig}},
+					{Name: "email", Type: "varchar(255)", Classification: &schema.Classification{Level: schema.ClassificationPII, Category: "email", Source: schema.ClassificationSourceComment}},
+				},
+			},
+			{
+				Name: "logs",
+				Columns: []*schema.Column{
+					{Name: "remote_addr", Type: "inet", Classification: &schema.Classification{Level: schema.ClassificationPII, Category: "ip_address", Source: schema.ClassificationSourceHeuristic}},
+				},
+			},
+		},
+	}
+	c, err := config.New()
+	if err != nil {
+		t.Fatal(err)
+	}
+	got := new(bytes.Buffer)
+	if err := New(c).OutputSchema(got, s); err != nil {
+		t.Fatal(err)
+	}
+	want := `# testdb PII Inventory
+
+| Classification | Columns |
+| -------------- | ------- |
+| pii | 2 |
+| confidential | 0 |
+| internal | 1 |
+| public | 0 |
+| Unclassified | 1 |
+
+| Table | Column | Type | Classification | Category | Source |
+| ----- | ------ | ---- | -------------- | -------- | ------ |
+| users | email | varchar(255) | pii | email | comment |
+| logs | remote_addr | inet | pii | ip_address | heuristic |
+| users | plan | text | internal |  | config |
+`
+	if got.String() != want {
+		t.Errorf("got %v\nwant %v", got.String(), want)
+	}
+
+	got.Reset()
+	if err := New(c).OutputTable(got, &schema.Table{Name: "logs", Columns: []*schema.Column{{Name: "id"}}}); err != nil {
+		t.Fatal(err)
+	}
+	if want := "| Unclassified | 1 |\n"; !bytes.HasSuffix(got.Bytes(), []byte(want)) {
+		t.Errorf("got %v\nwant suffix %v", got.String(), want)
+	}
+}
@@ -71,6 +71,7 @@ func (p *PlantUML) OutputSchema(wr io.Writer, s *schema.Schema) error {
 		"showComment":     p.config.ER.Comment,
 		"showDef":         !p.config.ER.HideDef,
 		"showColumnTypes": p.config.ER.ShowColumnTypes,
+		"hasDeprecated":   hasDeprecated(s.Tables),
 	}); err != nil {
 		return errors.WithStack(err)
 	}
@@ -97,9 +98,28 @@ func (p *PlantUML) OutputTable(wr io.Writer, t *schema.Table) error {
 		"showComment":     p.config.ER.Comment,
 		"showDef":         !p.config.ER.HideDef,
 		"showColumnTypes": p.config.ER.ShowColumnTypes,
+		"hasDeprecated":   hasDeprecated(tables),
 	}); err != nil {
 		return errors.WithStack(err)
 	}
 
 	return nil
 }
+
+// hasDeprecated returns true if deprecated tables or columns are drawn.
+func hasDeprecated(tables []*schema.Table) bool {
+	for _, t := range tables {
+		if t.HideForER {
+			continue
+		}
+		if t.IsDeprecated() {
+			return true
+		}
+		for _, c := range t.Columns {
+			if !c.HideForER && c.IsDeprecated() {
+				return true
+			}
+		}
+	}
+	return false
+}
@@ -7,19 +7,31 @@ import (
 	"testing"
 
 	"github.com/k1LoW/tbls/config"
+	"github.com/k1LoW/tbls/schema"
 	"github.com/k1LoW/tbls/testutil"
 	"github.com/tenntenn/golden"
 )
 
 func TestOutputSchema(t *testing.T) {
+	classification := config.Classification{
+		Rules: []config.ClassificationRule{
+			{Level: "pii", Category: "email", Columns: []string{"a.a2"}},
+			{Level: "internal", Columns: []string{"b.b2"}},
+			{Level: "public", Columns: []string{"b.b"}},
+		},
+	}
 	tests := []struct {
 		hideDef         bool
 		showColumnTypes *config.ShowColumnTypes
+		deprecated      bool
+		classification  config.Classification
 		wantFile        string
 	}{
-		{false, nil, "plantuml_test_schema.puml"},
-		{true, nil, "plantuml_test_schema.puml.hidedef"},
-		{false, &config.ShowColumnTypes{Related: true}, "plantuml_test_schema.puml.hide_not_related_column"},
+		{false, nil, false, config.Classification{}, "plantuml_test_schema.puml"},
+		{true, nil, false, config.Classification{}, "plantuml_test_schema.puml.hidedef"},
+		{false, &config.ShowColumnTypes{Related: true}, false, config.Classification{}, "plantuml_test_schema.puml.hide_not_related_column"},
+		{false, nil, true, config.Classification{}, "plantuml_test_schema.puml.deprecated"},
+		{false, nil, false, classification, "plantuml_test_schema.puml.classification"},
 	}
 	for _, tt := range tests {
 		t.Run(tt.wantFile, func(t *testing.T) {
@@ -33,6 +45,10 @@ func TestOutputSchema(t *testing.T) {
 			}
 			c.ER.HideDef = tt.hideDef
 			c.ER.ShowColumnTypes = tt.showColumnTypes
+			c.Classification = tt.classification
+			if tt.deprecated {
+				deprecate(t, s)
+			}
 			if err := c.ModifySchema(s); err != nil {
 				t.Error(err)
 			}
@@ -147,3 +163,21 @@ func testdataDir() string {
 	dir, _ := filepath.Abs(filepath.Join(filepath.Dir(filepath.Dir(wd)), "testdata"))
 	return dir
 }
+
+func deprecate(t *testing.T, s *schema.Schema) {
+	t.Helper()
+	b, err := s.FindTableByName("b")
+	if err != nil {
+		t.Fatal(err)
+	}
+	b.EnhancedCommentData = &schema.CommentData{Deprecated: true}
+	a, err := s.FindTableByName("a")
+	if err != nil {
+		t.Fatal(err)
+	}
+	a2, err := a.FindColumnByName("a2")
+	if err != nil {
+		t.Fatal(err)
+	}
+	a2.EnhancedCommentData = &schema.CommentData{Deprecated: true}
+}
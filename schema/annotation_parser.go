@@ -0,0 +1,234 @@
+package schema
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// MetadataKeyDeprecatedReason @deprecated に続く理由を保持するメタデータキー
+const MetadataKeyDeprecatedReason = "deprecated_reason"
+
+// annotationRe 行頭または空白に続く @key にマッチする正規表現（メールアドレス等の a@b は対象外）
+var annotationRe = regexp.MustCompile(`(?:^|\s)@([A-Za-z_][\w-]*)`)
+
+// annotationKeyRe アノテーションのキーとして使用できる文字列
+var annotationKeyRe = regexp.MustCompile(`^[A-Za-z_][\w-]*$`)
+
+// AnnotationParser Javadoc風のアノテーション（@tag / @key value）形式のコメントを解析するパーサー
+// 値のないアノテーション（@tag）はタグ、値のあるアノテーション（@key value）はメタデータとして扱う
+// 最初のアノテーションより前のテキストは従来形式（論理名|説明）として解析する
+type AnnotationParser struct {
+	name     string
+	priority int
+	maxSize  int
+}
+
+// NewAnnotationParser 新しいAnnotationParserを作成
+func NewAnnotationParser() *AnnotationParser {
+	return &AnnotationParser{
+		name:     "annotation",
+		priority: 50,   // JSON/YAMLより低く、LegacyParserより高い優先度
+		maxSize:  8192, // コメント文字列の最大サイズ（バイト）
+	}
+}
+
+// Name パーサーの名前を返す
+func (p *AnnotationParser) Name() string {
+	return p.name
+}
+
+// Priority パーサーの優先度を返す
+func (p *AnnotationParser) Priority() int {
+	return p.priority
+}
+
+// SetPriority パーサーの優先度を設定
+func (p *AnnotationParser) SetPriority(priority int) {
+	p.priority = priority
+}
+
+// CanParse このパーサーでコメントを解析可能かを判定（アノテーションを1つ以上含む場合のみ）
+func (p *AnnotationParser) CanParse(comment string) bool {
+	if comment == "" || len(comment) > p.maxSize {
+		return false
+	}
+	return len(findAnnotations(comment)) > 0
+}
+
+// ParseComment コメントを解析してCommentDataに変換
+func (p *AnnotationParser) ParseComment(comment string, delimiter string) (*CommentData, error) {
+	if comment == "" {
+		return &CommentData{Source: comment}, nil
+	}
+	if len(comment) > p.maxSize {
+		return nil, NewCommentParseError(p.name, comment, "comment too large", ErrCommentTooLong)
+	}
+	annotations := findAnnotations(comment)
+	if len(annotations) == 0 {
+		return nil, NewCommentParseError(p.name, comment, "no annotation found", ErrInvalidCommentFormat)
+	}
+
+	// アノテーションより前のテキストは従来形式として解析
+	result, err := NewLegacyParser().ParseComment(strings.TrimSpace(comment[:annotations[0].start]), delimiter)
+	if err != nil {
+		return nil, err
+	}
+	for _, a := range annotations {
+		// @pii @owner:team のように値が @ で始まる場合、値のないアノテーションか値なのかを区別できない
+		if strings.HasPrefix(a.value, "@") {
+			return nil, NewCommentParseError(p.name, comment, fmt.Sprintf("value of @%s starts with @: %q", a.key, a.value), ErrInvalidCommentFormat)
+		}
+		switch a.key {
+		case "name":
+			result.LogicalName = a.value
+		case "description":
+			result.Description = a.value
+		case "tags":
+			result.Tags = append(result.Tags, strings.FieldsFunc(a.value, func(r rune) bool {
+				return r == ',' || unicode.IsSpace(r)
+			})...)
+		case "tag":
+			if a.value != "" {
+				result.Tags = append(result.Tags, a.value)
+			}
+		case "priority":
+			priority, err := parseIntFromString(a.value)
+			if err != nil {
+				return nil, NewCommentParseError(p.name, comment, fmt.Sprintf("invalid @priority: %q", a.value), ErrInvalidCommentFormat)
+			}
+			result.Priority = priority
+		case "deprecated":
+			switch strings.ToLower(a.value) {
+			case "", "true":
+				result.Deprecated = true
+			case "false":
+				result.Deprecated = false
+			default:
+				result.Deprecated = true
+				result.setMetadata(MetadataKeyDeprecatedReason, a.value)
+			}
+		default:
+			// 値のないアノテーション（@pii）はタグ、値のあるアノテーションはメタデータとして扱う
+			if a.value == "" {
+				result.Tags = append(result.Tags, a.key)
+				continue
+			}
+			result.setMetadata(a.key, a.value)
+		}
+	}
+	result.Source = comment
+	return result, nil
+}
+
+func (cd *CommentData) setMetadata(key, value string) {
+	if cd.Metadata == nil {
+		cd.Metadata = map[string]string{}
+	}
+	cd.Metadata[key] = value
+}
+
+type annotation struct {
+	start int
+	key   string
+	value string
+}
+
+// findAnnotations コメント中のアノテーションを出現順に返す（値は次のアノテーションまでのテキスト）
+func findAnnotations(comment string) []annotation {
+	annotations := []annotation{}
+	for _, m := range annotationRe.FindAllStringSubmatchIndex(comment, -1) {
+		// @key の直後は空白か末尾のみ（@key: のような記述は対象外）
+		if r, _ := utf8.DecodeRuneInString(comment[m[1]:]); m[1] < len(comment) && !unicode.IsSpace(r) {
+			continue
+		}
+		annotations = append(annotations, annotation{start: m[2] - 1, key: comment[m[2]:m[3]]})
+	}
+	for i := range annotations {
+		end := len(comment)
+		if i+1 < len(annotations) {
+			end = annotations[i+1].start
+		}
+		valueStart := annotations[i].start + len("@"+annotations[i].key)
+		annotations[i].value = strings.TrimSpace(comment[valueStart:end])
+	}
+	return annotations
+}
+
+// IsAnnotationComment コメントがアノテーション形式かを判定
+func IsAnnotationComment(comment string) bool {
+	return NewAnnotationParser().CanParse(comment)
+}
+
+// formatAnnotationComment CommentDataをアノテーション形式のコメント文字列に変換
+func formatAnnotationComment(data *CommentData, delimiter string) (string, error) {
+	if len(data.LogicalNames) > 0 || len(data.Descriptions) > 0 {
+		return "", fmt.Errorf("%w: annotation format does not support localized names and descriptions", ErrLossyCommentConversion)
+	}
+	metadata := data.UserMetadata()
+	values := []string{data.LogicalName, data.Description}
+	annotationValues := append([]string{}, data.Tags...)
+	for k, v := range metadata {
+		if !annotationKeyRe.MatchString(k) || k == "tag" {
+			return "", fmt.Errorf("%w: metadata key %q cannot be an annotation", ErrLossyCommentConversion, k)
+		}
+		annotationValues = append(annotationValues, v)
+	}
+	for _, v := range annotationValues {
+		// 値が @ で始まるアノテーションは解析時にエラーとなる
+		if strings.HasPrefix(v, "@") {
+			return "", fmt.Errorf("%w: %q starts with @", ErrLossyCommentConversion, v)
+		}
+	}
+	values = append(values, annotationValues...)
+	for _, v := range values {
+		if len(findAnnotations(v)) > 0 {
+			return "", fmt.Errorf("%w: %q contains an annotation", ErrLossyCommentConversion, v)
+		}
+	}
+	for _, t := range data.Tags {
+		if strings.ContainsFunc(t, func(r rune) bool { return r == ',' || unicode.IsSpace(r) }) {
+			return "", fmt.Errorf("%w: tag %q contains a separator", ErrLossyCommentConversion, t)
+		}
+	}
+
+	parts := []string{}
+	if data.LogicalName != "" || data.Description != "" {
+		legacy, err := formatLegacyComment(&CommentData{LogicalName: data.LogicalName, Description: data.Description}, delimiter)
+		if err != nil {
+			return "", err
+		}
+		parts = append(parts, legacy)
+	}
+	if len(data.Tags) > 0 {
+		parts = append(parts, "@tags "+strings.Join(data.Tags, ","))
+	}
+	if data.Priority != 0 {
+		parts = append(parts, fmt.Sprintf("@priority %d", data.Priority))
+	}
+	if data.Deprecated {
+		if reason := metadata[MetadataKeyDeprecatedReason]; reason != "" && strings.ToLower(reason) != "true" && strings.ToLower(reason) != "false" {
+			parts = append(parts, "@deprecated "+reason)
+			delete(metadata, MetadataKeyDeprecatedReason)
+		} else {
+			parts = append(parts, "@deprecated")
+		}
+	}
+	keys := make([]string, 0, len(metadata))
+	for k := range metadata {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	for _, k := range keys {
+		v := metadata[k]
+		// 値のないアノテーションはタグとして解析されるため空の値は表現できない
+		if v == "" {
+			return "", fmt.Errorf("%w: metadata %q has an empty value", ErrLossyCommentConversion, k)
+		}
+		parts = append(parts, "@"+k+" "+v)
+	}
+	return strings.Join(parts, " "), nil
+}
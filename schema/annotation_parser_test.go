@@ -0,0 +1,204 @@
+package schema
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestAnnotationParser_CanParse(t *testing.T) {
+	parser := NewAnnotationParser()
+
+	tests := []struct {
+		name    string
+		comment string
+		want    bool
+	}{
+		{"空文字列", "", false},
+		{"アノテーションのみ", "@pii", true},
+		{"テキストとアノテーション", "User email @pii @owner identity-team", true},
+		{"メールアドレス", "問い合わせ先 support@example.com", false},
+		{"コロン付き", "@owner: identity-team", false},
+		{"従来形式", "ユーザー|ユーザー情報", false},
+		{"JSON", `{"name": "ユーザー"}`, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := parser.CanParse(tt.comment); got != tt.want {
+				t.Errorf("CanParse() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestAnnotationParser_ParseComment(t *testing.T) {
+	parser := NewAnnotationParser()
+
+	tests := []struct {
+		name    string
+		comment string
+		want    *CommentData
+		wantErr error
+	}{
+		{
+			name:    "flags and values",
+			comment: "User email @pii @owner identity-team @deprecated use contact_email",
+			want: &CommentData{
+				LogicalName: "User email",
+				Tags:        []string{"pii"},
+				Deprecated:  true,
+				Metadata: map[string]string{
+					"owner":                     "identity-team",
+					MetadataKeyDeprecatedReason: "use contact_email",
+				},
+			},
+		},
+		{
+			name:    "legacy text with delimiter",
+			comment: "ユーザー|ユーザー情報 @tags core, auth @priority 1",
+			want: &CommentData{
+				LogicalName: "ユーザー",
+				Description: "ユーザー情報",
+				Tags:        []string{"core", "auth"},
+				Priority:    1,
+			},
+		},
+		{
+			name:    "name and description annotations",
+			comment: "@name ユーザー @description 会員情報\n退会者を含む @tag core @tag pii @deprecated",
+			want: &CommentData{
+				LogicalName: "ユーザー",
+				Description: "会員情報\n退会者を含む",
+				Tags:        []string{"core", "pii"},
+				Deprecated:  true,
+			},
+		},
+		{
+			name:    "email is not an annotation",
+			comment: "連絡先 @contact support@example.com",
+			want: &CommentData{
+				LogicalName: "連絡先",
+				Metadata:    map[string]string{"contact": "support@example.com"},
+			},
+		},
+		{
+			name:    "invalid priority",
+			comment: "ユーザー @priority high",
+			wantErr: ErrInvalidCommentFormat,
+		},
+		{
+			name:    "no annotation",
+			comment: "ユーザー|ユーザー情報",
+			wantErr: ErrInvalidCommentFormat,
+		},
+		{
+			name:    "value starts with @",
+			comment: "User email @pii @owner:team",
+			wantErr: ErrInvalidCommentFormat,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := parser.ParseComment(tt.comment, "|")
+			if tt.wantErr != nil {
+				if !errors.Is(err, tt.wantErr) {
+					t.Errorf("got %v, want %v", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			got.Source = ""
+			if diff := cmp.Diff(tt.want, got); diff != "" {
+				t.Error(diff)
+			}
+		})
+	}
+}
+
+func TestAnnotationCommentConversion(t *testing.T) {
+	tests := []struct {
+		name    string
+		comment string
+		from    string
+		want    string
+		wantErr error
+	}{
+		{
+			name:    "json to annotation",
+			comment: `{"name": "メール", "description": "連絡先", "tags": ["pii"], "deprecated": true, "deprecated_reason": "use contact_email", "owner": "identity-team", "masked": true}`,
+			from:    CommentFormatJSON,
+			want:    "メール|連絡先 @tags pii @deprecated use contact_email @masked true @owner identity-team",
+		},
+		{
+			name:    "annotation to json",
+			comment: "User email @pii @priority 2",
+			from:    CommentFormatAnnotation,
+			want:    `{"name":"User email","tags":["pii"],"priority":2}`,
+		},
+		{
+			name:    "value contains annotation",
+			comment: `{"name": "メール", "note2": "see @owner"}`,
+			from:    CommentFormatJSON,
+			wantErr: ErrLossyCommentConversion,
+		},
+		{
+			name:    "value starts with @",
+			comment: `{"name": "メール", "owner": "@owner:team"}`,
+			from:    CommentFormatJSON,
+			wantErr: ErrLossyCommentConversion,
+		},
+		{
+			name:    "invalid metadata key",
+			comment: `{"name": "メール", "owner team": "auth"}`,
+			from:    CommentFormatJSON,
+			wantErr: ErrLossyCommentConversion,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			to := CommentFormatAnnotation
+			if tt.from == CommentFormatAnnotation {
+				to = CommentFormatJSON
+			}
+			got, err := ConvertComment(tt.comment, tt.from, to, "|")
+			if tt.wantErr != nil {
+				if !errors.Is(err, tt.wantErr) {
+					t.Errorf("got %v, want %v", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got != tt.want {
+				t.Errorf("got %q, want %q", got, tt.want)
+			}
+		})
+	}
+
+	data := &CommentData{
+		LogicalName: "ユーザー",
+		Description: "ユーザー情報",
+		Tags:        []string{"core", "pii"},
+		Priority:    1,
+		Deprecated:  true,
+		Metadata:    map[string]string{"owner": "auth-team", "pii": "true"},
+	}
+	comment, err := FormatComment(data, CommentFormatAnnotation, "|")
+	if err != nil {
+		t.Fatal(err)
+	}
+	got, err := ParseCommentAs(comment, CommentFormatAuto, "|")
+	if err != nil {
+		t.Fatal(err)
+	}
+	got.Source = ""
+	if diff := cmp.Diff(data, got); diff != "" {
+		t.Error(diff)
+	}
+}
@@ -0,0 +1,167 @@
+package schema
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// MetadataKeyClassification 拡張コメントのメタデータでデータ分類を指定するキー（例: "pii:email"）
+const MetadataKeyClassification = "classification"
+
+// ClassificationLevel データ分類の区分
+type ClassificationLevel string
+
+const (
+	// ClassificationPublic 公開可能なデータ
+	ClassificationPublic ClassificationLevel = "public"
+	// ClassificationInternal 社内向けのデータ
+	ClassificationInternal ClassificationLevel = "internal"
+	// ClassificationConfidential 機密データ
+	ClassificationConfidential ClassificationLevel = "confidential"
+	// ClassificationPII 個人情報
+	ClassificationPII ClassificationLevel = "pii"
+)
+
+// ClassificationLevels 機密度の低い順のデータ分類の区分
+var ClassificationLevels = []ClassificationLevel{
+	ClassificationPublic,
+	ClassificationInternal,
+	ClassificationConfidential,
+	ClassificationPII,
+}
+
+// データ分類の設定元
+const (
+	ClassificationSourceComment   = "comment"
+	ClassificationSourceConfig    = "config"
+	ClassificationSourceHeuristic = "heuristic"
+)
+
+// Classification カラムのデータ分類
+type Classification struct {
+	// Level 分類の区分
+	Level ClassificationLevel `json:"level" yaml:"level"`
+	// Category 分類の詳細（例: email, phone）
+	Category string `json:"category,omitempty" yaml:"category,omitempty"`
+	// Source 分類の設定元（comment, config, heuristic）
+	Source string `json:"source" yaml:"source"`
+}
+
+// ParseClassificationLevel 文字列をデータ分類の区分に変換（大文字小文字は区別しない）
+func ParseClassificationLevel(s string) (ClassificationLevel, error) {
+	for _, l := range ClassificationLevels {
+		if strings.EqualFold(s, string(l)) {
+			return l, nil
+		}
+	}
+	return "", fmt.Errorf("invalid classification level: %q", s)
+}
+
+// ParseClassification "level" または "level:category" 形式の文字列をデータ分類に変換
+func ParseClassification(s, source string) (*Classification, error) {
+	level, category, _ := strings.Cut(strings.TrimSpace(s), ":")
+	l, err := ParseClassificationLevel(strings.TrimSpace(level))
+	if err != nil {
+		return nil, err
+	}
+	return &Classification{Level: l, Category: strings.TrimSpace(category), Source: source}, nil
+}
+
+// Rank 機密度の順位（publicが0で、機密度が高いほど大きい）
+func (l ClassificationLevel) Rank() int {
+	for i, ll := range ClassificationLevels {
+		if l == ll {
+			return i
+		}
+	}
+	return -1
+}
+
+// String 分類を "level" または "level:category" 形式で返す
+func (c *Classification) String() string {
+	if c.Category == "" {
+		return string(c.Level)
+	}
+	return fmt.Sprintf("%s:%s", c.Level, c.Category)
+}
+
+// ClassificationFromComment 拡張コメントのメタデータに指定されたデータ分類を返す（指定がない場合はnil）
+func (c *Column) ClassificationFromComment() (*Classification, error) {
+	v, ok := c.EnhancedCommentData.UserMetadata()[MetadataKeyClassification]
+	if !ok {
+		return nil, nil
+	}
+	return ParseClassification(v, ClassificationSourceComment)
+}
+
+// piiHeuristic カラム名から個人情報を推定するパターン
+type piiHeuristic struct {
+	level    ClassificationLevel
+	category string
+	name     *regexp.Regexp
+	types    []string
+}
+
+// piiHeuristics カラム名（スネークケース）と型から個人情報を推定するパターン（先頭から順に評価するため、具体的なパターンを先に置く）
+var piiHeuristics = []piiHeuristic{
+	{ClassificationPII, "email", regexp.MustCompile(`(^|_)e?mail(_?address)?$`), nil},
+	{ClassificationPII, "ip_address", regexp.MustCompile(`(^|_)(ip|ip_?address|remote_?addr(ess)?)$`), []string{"inet", "cidr"}},
+	{ClassificationPII, "phone", regexp.MustCompile(`(^|_)(phone|tel|telephone|mobile|fax)(_?(number|no))?$`), nil},
+	// mac_address等を除外するため、住所を表す接頭辞のみ許可する
+	{ClassificationPII, "address", regexp.MustCompile(`^((home|work|office|billing|shipping|delivery|mailing|postal|contact|residential|street)_)?(address(_?line_?\d)?|street|zip(_?code)?|postal_?code|post_?code)$`), nil},
+	{ClassificationPII, "name", regexp.MustCompile(`^(first|last|full|middle|family|given|real)_?name(_?kana)?$`), nil},
+	{ClassificationPII, "birth_date", regexp.MustCompile(`(^|_)(birth_?(day|date)|date_of_birth|dob)$`), nil},
+	{ClassificationPII, "gender", regexp.MustCompile(`^(gender|sex)$`), nil},
+	{ClassificationPII, "national_id", regexp.MustCompile(`(^|_)(ssn|social_security_number|my_?number|passport(_?(number|no))?|national_id|tax_id)$`), nil},
+	{ClassificationPII, "credit_card", regexp.MustCompile(`(^|_)(credit_?card(_?(number|no))?|card_?(number|no)|cc_?number)$`), nil},
+	{ClassificationConfidential, "credential", regexp.MustCompile(`(^|_)(password|passwd|password_?(hash|digest)|secret|api_?key|access_?token|refresh_?token)$`), nil},
+}
+
+var snakeCaseRe = regexp.MustCompile(`([a-z0-9])([A-Z])`)
+
+// GuessClassification カラム名と型から推定したデータ分類を返す（推定できない場合はnil）
+func GuessClassification(c *Column) *Classification {
+	typ := strings.ToLower(c.Type)
+	// フラグ（email_verified等）は対象外
+	if strings.HasPrefix(typ, "bool") || typ == "bit" || typ == "tinyint(1)" {
+		return nil
+	}
+	name := strings.ToLower(snakeCaseRe.ReplaceAllString(c.Name, "${1}_${2}"))
+	for _, h := range piiHeuristics {
+		matched := h.name.MatchString(name)
+		for _, t := range h.types {
+			if typ == t {
+				matched = true
+			}
+		}
+		if matched {
+			return &Classification{Level: h.level, Category: h.category, Source: ClassificationSourceHeuristic}
+		}
+	}
+	return nil
+}
+
+// HasClassifiedColumns データ分類が設定されたカラムが存在するかを返す
+func (s *Schema) HasClassifiedColumns() bool {
+	for _, t := range s.Tables {
+		if t.Classification() != nil {
+			return true
+		}
+	}
+	return false
+}
+
+// Classification テーブルのカラムのうち最も機密度の高いデータ分類を返す（分類されたカラムがない場合はnil）
+func (t *Table) Classification() *Classification {
+	var highest *Classification
+	for _, c := range t.Columns {
+		if c.Classification == nil {
+			continue
+		}
+		if highest == nil || c.Classification.Level.Rank() > highest.Level.Rank() {
+			highest = c.Classification
+		}
+	}
+	return highest
+}
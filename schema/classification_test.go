@@ -0,0 +1,118 @@
+package schema
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestParseClassification(t *testing.T) {
+	tests := []struct {
+		in      string
+		want    *Classification
+		wantErr bool
+	}{
+		{"pii", &Classification{Level: ClassificationPII, Source: ClassificationSourceComment}, false},
+		{"PII:email", &Classification{Level: ClassificationPII, Category: "email", Source: ClassificationSourceComment}, false},
+		{" confidential : salary ", &Classification{Level: ClassificationConfidential, Category: "salary", Source: ClassificationSourceComment}, false},
+		{"secret", nil, true},
+		{"", nil, true},
+	}
+	for _, tt := range tests {
+		got, err := ParseClassification(tt.in, ClassificationSourceComment)
+		if err != nil {
+			if !tt.wantErr {
+				t.Errorf("%q: %s", tt.in, err)
+			}
+			continue
+		}
+		if tt.wantErr {
+			t.Errorf("%q: want error", tt.in)
+		}
+		if diff := cmp.Diff(tt.want, got); diff != "" {
+			t.Errorf("%q: %s", tt.in, diff)
+		}
+	}
+}
+
+func TestGuessClassification(t *testing.T) {
+	tests := []struct {
+		name string
+		typ  string
+		want string
+	}{
+		{"email", "varchar(255)", "pii:email"},
+		{"contact_mail_address", "text", "pii:email"},
+		{"phoneNumber", "varchar(20)", "pii:phone"},
+		{"zip_code", "char(7)", "pii:address"},
+		{"billing_address_line1", "text", "pii:address"},
+		{"shipping_zip", "text", "pii:address"},
+		{"ip_address", "varchar(45)", "pii:ip_address"},
+		{"last_login_ip_address", "varchar(45)", "pii:ip_address"},
+		{"mac_address", "varchar(17)", ""},
+		{"server_address", "text", ""},
+		{"last_name", "text", "pii:name"},
+		{"birthday", "date", "pii:birth_date"},
+		{"remote_addr", "varchar(45)", "pii:ip_address"},
+		{"client", "inet", "pii:ip_address"},
+		{"password_hash", "text", "confidential:credential"},
+		{"email_verified", "boolean", ""},
+		{"emails_count", "int", ""},
+		{"name", "text", ""},
+		{"telemetry", "text", ""},
+	}
+	for _, tt := range tests {
+		got := ""
+		if c := GuessClassification(&Column{Name: tt.name, Type: tt.typ}); c != nil {
+			if c.Source != ClassificationSourceHeuristic {
+				t.Errorf("%s: got source %s", tt.name, c.Source)
+			}
+			got = c.String()
+		}
+		if got != tt.want {
+			t.Errorf("%s: got %q, want %q", tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestClassificationJSON(t *testing.T) {
+	want := &Column{
+		Name:           "email",
+		Type:           "text",
+		Classification: &Classification{Level: ClassificationPII, Category: "email", Source: ClassificationSourceConfig},
+	}
+	b, err := json.Marshal(want)
+	if err != nil {
+		t.Fatal(err)
+	}
+	got := &Column{}
+	if err := json.Unmarshal(b, got); err != nil {
+		t.Fatal(err)
+	}
+	if diff := cmp.Diff(want.Classification, got.Classification); diff != "" {
+		t.Error(diff)
+	}
+}
+
+func TestTableClassification(t *testing.T) {
+	tbl := &Table{
+		Name: "users",
+		Columns: []*Column{
+			{Name: "id"},
+			{Name: "email", Classification: &Classification{Level: ClassificationPII, Category: "email"}},
+			{Name: "plan", Classification: &Classification{Level: ClassificationInternal}},
+		},
+	}
+	if got := tbl.Classification(); got == nil || got.Level != ClassificationPII {
+		t.Errorf("got %v", got)
+	}
+	s := &Schema{Tables: []*Table{{Name: "logs", Columns: []*Column{{Name: "id"}}}}}
+	if s.HasClassifiedColumns() {
+		t.Error("want no classified columns")
+	}
+	s.Tables = append(s.Tables, tbl)
+	if !s.HasClassifiedColumns() {
+		t.Error("want classified columns")
+	}
+}
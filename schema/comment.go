@@ -10,21 +10,44 @@ type SplitCommentParts struct {
 
 // SplitComment は指定された区切り文字でコメントを論理名とコメント部分に分割します
 // 区切り文字が見つからない場合、全体を論理名として扱います
+// バックスラッシュでエスケープされた区切り文字（\|）では分割せず、区切り文字そのものとして扱います
 func SplitComment(comment string, delimiter string) SplitCommentParts {
 	if comment == "" {
 		return SplitCommentParts{}
 	}
-
-	parts := strings.SplitN(comment, delimiter, 2)
-	result := SplitCommentParts{
-		LogicalName: strings.TrimSpace(parts[0]),
+	if delimiter == "" {
+		parts := strings.SplitN(comment, delimiter, 2)
+		result := SplitCommentParts{LogicalName: strings.TrimSpace(parts[0])}
+		if len(parts) > 1 {
+			result.Comment = strings.TrimSpace(parts[1])
+		}
+		return result
 	}
 
-	if len(parts) > 1 {
-		result.Comment = strings.TrimSpace(parts[1])
+	unescaper := strings.NewReplacer(`\`+delimiter, delimiter)
+	i := indexUnescaped(comment, delimiter)
+	if i < 0 {
+		return SplitCommentParts{LogicalName: strings.TrimSpace(unescaper.Replace(comment))}
+	}
+	return SplitCommentParts{
+		LogicalName: strings.TrimSpace(unescaper.Replace(comment[:i])),
+		Comment:     strings.TrimSpace(unescaper.Replace(comment[i+len(delimiter):])),
 	}
+}
 
-	return result
+// indexUnescaped エスケープされていない最初の区切り文字の位置を返す（見つからない場合は-1）
+func indexUnescaped(s, delimiter string) int {
+	for i := 0; i < len(s); {
+		if s[i] == '\\' && strings.HasPrefix(s[i+1:], delimiter) {
+			i += 1 + len(delimiter)
+			continue
+		}
+		if strings.HasPrefix(s[i:], delimiter) {
+			return i
+		}
+		i++
+	}
+	return -1
 }
 
 // ExtractLogicalName はコメントから論理名を抽出します
@@ -0,0 +1,112 @@
+package schema
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// CheckComments 拡張コメント処理と同じパーサー・バリデーター・メタデータ定義でオブジェクトのコメントを検査し、
+// 解析エラー・検証エラー・エラーからの回復をreporterに報告する（厳格モードに関わらず全ての問題を報告する）
+func (p *EnhancedCommentProcessor) CheckComments(objects []*CommentObject, delimiter string, reporter ErrorReporter) {
+	if delimiter == "" {
+		delimiter = p.config.DefaultDelimiter
+	}
+	recovery := NewErrorRecoveryManager()
+	for _, o := range objects {
+		p.checkComment(o, delimiter, recovery, reporter)
+	}
+}
+
+// checkComment オブジェクト1件のコメントを検査（従来形式のコメントはメタデータ定義の必須キーのみ検査）
+func (p *EnhancedCommentProcessor) checkComment(o *CommentObject, delimiter string, recovery *ErrorRecoveryManager, reporter ErrorReporter) {
+	parser := p.structuredParser(o.Comment)
+	if parser == nil {
+		p.checkMetadata(o, nil, reporter)
+		return
+	}
+	data, err := parser.ParseComment(o.Comment, delimiter)
+	if err != nil {
+		e := NewParsingError(parser.Name(), o.Comment, err)
+		e.Message = fmt.Sprintf("%s: %v", e.Message, err)
+		e.ObjectType, e.ObjectName = o.Type, o.FullName()
+		reporter.ReportError(e)
+		if recovered, err := recovery.TryRecover(e, o.Comment); err == nil {
+			reporter.ReportRecovery(e, recovered)
+		}
+		return
+	}
+	data.Localize(p.lang)
+
+	result := data
+	if p.config.EnableSanitization && p.validator != nil {
+		result = p.validator.Sanitize(data)
+	}
+	if p.config.EnableValidation && p.validator != nil {
+		if err := p.validator.Validate(result); err != nil {
+			e := NewValidationError(err.Error(), o.Type, o.FullName())
+			e.SourceComment = o.Comment
+			e.InnerError = err
+			if errors.Is(err, ErrInvalidCharacters) {
+				e.ErrorCode = ErrorCodeUnsafeContent
+			}
+			if p.config.StrictMode {
+				// 厳格モードでは検証エラーで処理が失敗する
+				e.Severity = ErrorSeverityError
+			}
+			reporter.ReportError(e)
+			if p.config.EnableSanitization {
+				if recovered, err := recovery.TryRecover(e, data); err == nil {
+					reporter.ReportRecovery(e, recovered)
+				}
+			}
+		}
+	}
+
+	p.checkMetadata(o, result, reporter)
+}
+
+// checkMetadata メタデータ定義への違反をreporterに報告
+func (p *EnhancedCommentProcessor) checkMetadata(o *CommentObject, data *CommentData, reporter ErrorReporter) {
+	var mse *MetadataSchemaError
+	if err := p.metadataSchema.Validate(o.Type, data); errors.As(err, &mse) {
+		for _, v := range mse.Violations {
+			reporter.ReportError(newMetadataViolationError(o, v))
+		}
+	}
+}
+
+// structuredParser コメントを解析するパーサーを返す（従来形式のコメントの場合はnil）
+// { で始まるがJSONとして解析できないコメントは、壊れたJSONコメントとしてJSONパーサーを返す
+func (p *EnhancedCommentProcessor) structuredParser(comment string) CommentParser {
+	if !IsStructuredComment(comment) {
+		if strings.HasPrefix(strings.TrimSpace(comment), "{") {
+			return p.GetParser(CommentFormatJSON)
+		}
+		return nil
+	}
+	for _, parser := range p.registry.GetParsers() {
+		if parser.Name() != CommentFormatLegacy && parser.CanParse(comment) {
+			return parser
+		}
+	}
+	return nil
+}
+
+// newMetadataViolationError メタデータ定義への違反をエラー情報に変換
+func newMetadataViolationError(o *CommentObject, v *MetadataViolation) *EnhancedCommentError {
+	suggestion := fmt.Sprintf("メタデータ '%s' の値を定義に合わせてください", v.Key)
+	if v.Value == "" && v.Reason == "is required" {
+		suggestion = fmt.Sprintf("メタデータ '%s' を追加してください", v.Key)
+	}
+	return NewErrorBuilder().
+		WithMessage(fmt.Sprintf("metadata key '%s' %s", v.Key, v.Reason)).
+		WithSeverity(ErrorSeverityError).
+		WithCategory(ErrorCategoryValidation).
+		WithErrorCode(ErrorCodeMetadataSchemaViolation).
+		WithObjectInfo(o.Type, o.FullName()).
+		WithSourceComment(o.Comment).
+		WithContext("key", v.Key).
+		WithSuggestion(suggestion).
+		Build()
+}
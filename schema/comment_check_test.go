@@ -0,0 +1,120 @@
+package schema
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestCheckComments(t *testing.T) {
+	s := &Schema{
+		Tables: []*Table{
+			{
+				Name:    "users",
+				Type:    "BASE TABLE",
+				Comment: `{"name": "ユーザー", "owner": "auth-team"}`,
+				Columns: []*Column{
+					{Name: "id", Comment: "ID|識別子"},
+					{Name: "email", Comment: `{"name": "メール", "description": "連絡先"`},
+					{Name: "status", Comment: "状態 @priority high"},
+				},
+			},
+			{
+				Name:    "posts",
+				Type:    "BASE TABLE",
+				Comment: `{"name": "投稿"}`,
+			},
+		},
+	}
+	ms, err := NewMetadataSchema(map[ObjectType][]*MetadataField{
+		ObjectTypeTable: {{Key: "owner", Required: true}},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	p := NewEnhancedCommentProcessor()
+	p.SetMetadataSchema(ms)
+	reporter := NewDefaultErrorReporter()
+	p.CheckComments(s.CommentObjects("|", false), "|", reporter)
+	got := []string{}
+	for _, e := range reporter.Errors() {
+		got = append(got, e.Severity.String()+" "+string(e.ObjectType)+" "+e.ObjectName+" "+e.ErrorCode)
+		if len(e.Suggestions) == 0 && e.ErrorCode != ErrorCodeRecoverySuccess {
+			t.Errorf("%s: no suggestion", e.ErrorCode)
+		}
+	}
+	want := []string{
+		"ERROR column users.email E_JSON_PARSE_FAILED",
+		"INFO column users.email E_RECOVERY_SUCCESS",
+		"ERROR column users.status E_ANNOTATION_PARSE_FAILED",
+		"INFO column users.status E_RECOVERY_SUCCESS",
+		"ERROR table posts E_METADATA_SCHEMA_VIOLATION",
+	}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Error(diff)
+	}
+}
+
+func TestCheckCommentsValidation(t *testing.T) {
+	objects := []*CommentObject{
+		{Type: ObjectTypeTable, Name: "users", Comment: `{"name": "ユーザー; DROP TABLE users"}`},
+	}
+	tests := []struct {
+		name   string
+		config func(*ProcessingConfig)
+		want   []string
+	}{
+		{
+			name:   "sanitization recovers unsafe content",
+			config: func(c *ProcessingConfig) {},
+			want:   []string{"WARNING E_UNSAFE_CONTENT", "INFO E_RECOVERY_SUCCESS"},
+		},
+		{
+			name:   "strict mode",
+			config: func(c *ProcessingConfig) { c.StrictMode = true },
+			want:   []string{"ERROR E_UNSAFE_CONTENT", "INFO E_RECOVERY_SUCCESS"},
+		},
+		{
+			name:   "without sanitization",
+			config: func(c *ProcessingConfig) { c.EnableSanitization = false },
+			want:   []string{"WARNING E_UNSAFE_CONTENT"},
+		},
+		{
+			name:   "without validation",
+			config: func(c *ProcessingConfig) { c.EnableValidation = false },
+			want:   []string{},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			config := DefaultProcessingConfig()
+			tt.config(config)
+			reporter := NewDefaultErrorReporter()
+			NewEnhancedCommentProcessorWithConfig(config).CheckComments(objects, "|", reporter)
+			got := []string{}
+			for _, e := range reporter.Errors() {
+				got = append(got, e.Severity.String()+" "+e.ErrorCode)
+			}
+			if diff := cmp.Diff(tt.want, got); diff != "" {
+				t.Error(diff)
+			}
+		})
+	}
+}
+
+func TestParseErrorSeverity(t *testing.T) {
+	for _, s := range []string{"info", "WARNING", "Error", "critical"} {
+		sv, err := ParseErrorSeverity(s)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !strings.EqualFold(sv.String(), s) {
+			t.Errorf("got %s, want %s", sv, s)
+		}
+	}
+	if _, err := ParseErrorSeverity("fatal"); err == nil {
+		t.Error("want error")
+	}
+}
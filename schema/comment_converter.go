@@ -0,0 +1,264 @@
+package schema
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// コメント形式
+const (
+	// CommentFormatAuto 自動判定（JSON -> YAML -> Annotation -> Legacyの順で解析）
+	CommentFormatAuto = "auto"
+	// CommentFormatLegacy 区切り文字ベースの従来形式（論理名|説明）
+	CommentFormatLegacy = "legacy"
+	// CommentFormatJSON JSON形式
+	CommentFormatJSON = "json"
+	// CommentFormatYAML YAML形式
+	CommentFormatYAML = "yaml"
+	// CommentFormatAnnotation アノテーション形式（@tag / @key value）
+	CommentFormatAnnotation = "annotation"
+)
+
+// SupportCommentFormats 変換可能なコメント形式
+var SupportCommentFormats = []string{CommentFormatLegacy, CommentFormatJSON, CommentFormatYAML, CommentFormatAnnotation}
+
+// ErrLossyCommentConversion 変換先の形式で表現できない情報が含まれている
+var ErrLossyCommentConversion = errors.New("comment cannot be converted without loss")
+
+// MetadataKeyObjectType プロセッサーが処理時に付与するオブジェクトタイプのメタデータキー
+const MetadataKeyObjectType = "object_type"
+
+// UserMetadata プロセッサーが付与したキーを除いた、コメントに記述されたメタデータを返す
+func (cd *CommentData) UserMetadata() map[string]string {
+	if cd == nil || len(cd.Metadata) == 0 {
+		return nil
+	}
+	metadata := map[string]string{}
+	for k, v := range cd.Metadata {
+		if k == MetadataKeyObjectType {
+			continue
+		}
+		metadata[k] = v
+	}
+	return metadata
+}
+
+// reservedCommentKeys JSON/YAMLパーサーがメタデータ以外として扱うキー
+var reservedCommentKeys = map[string]bool{
+	"name": true, "logical_name": true, "logicalName": true, "title": true, "label": true, "display_name": true,
+	"description": true, "desc": true, "comment": true, "note": true, "summary": true, "details": true,
+	"tags": true, "priority": true, "deprecated": true,
+}
+
+// IsStructuredComment コメントがJSON/YAML/アノテーション形式の構造化コメントかを判定
+func IsStructuredComment(comment string) bool {
+	return isStructuredDataComment(comment) || IsAnnotationComment(comment)
+}
+
+// isStructuredDataComment コメントがJSON/YAML形式の構造化コメントかを判定
+// "key: value" 形式の従来コメントと区別するため、YAMLは予約キー（name, description等）を含むマッピングのみ構造化コメントとみなす
+func isStructuredDataComment(comment string) bool {
+	if IsValidJSON(comment) {
+		return true
+	}
+	if !strings.Contains(comment, ":") || !IsValidYAML(comment) {
+		return false
+	}
+	var m map[string]any
+	if err := yaml.Unmarshal([]byte(comment), &m); err != nil {
+		return false
+	}
+	for k := range m {
+		if reservedCommentKeys[k] {
+			return true
+		}
+	}
+	return false
+}
+
+// ParseCommentAs 指定された形式としてコメントを解析
+func ParseCommentAs(comment, format, delimiter string) (*CommentData, error) {
+	if strings.TrimSpace(comment) == "" {
+		return &CommentData{Source: comment}, nil
+	}
+	var parser CommentParser
+	switch format {
+	case CommentFormatLegacy:
+		parser = NewLegacyParser()
+	case CommentFormatJSON:
+		parser = NewJSONParser()
+	case CommentFormatYAML:
+		parser = NewYAMLParser()
+	case CommentFormatAnnotation:
+		parser = NewAnnotationParser()
+	case CommentFormatAuto, "":
+		registry := NewDefaultParserRegistry()
+		registry.RegisterParser(NewJSONParser())
+		registry.RegisterParser(NewYAMLParser())
+		registry.RegisterParser(NewAnnotationParser())
+		registry.RegisterParser(NewLegacyParser())
+		return registry.ParseWithFallback(comment, delimiter)
+	default:
+		return nil, fmt.Errorf("%w: %s", ErrUnsupportedFormat, format)
+	}
+	if !parser.CanParse(comment) {
+		return nil, NewCommentParseError(parser.Name(), comment, fmt.Sprintf("not a valid %s format", format), ErrInvalidCommentFormat)
+	}
+	data, err := parser.ParseComment(comment, delimiter)
+	if err != nil {
+		return nil, err
+	}
+	data.Source = comment
+	return data, nil
+}
+
+// FormatComment CommentDataを指定された形式のコメント文字列に変換
+// legacy形式は論理名と説明のみを表現できるため、タグ等が含まれる場合はErrLossyCommentConversionを返す
+func FormatComment(data *CommentData, format, delimiter string) (string, error) {
+	if data == nil || (data.IsEmpty() && data.Priority == 0 && !data.Deprecated) {
+		return "", nil
+	}
+	for k := range data.UserMetadata() {
+		if reservedCommentKeys[k] {
+			return "", fmt.Errorf("%w: metadata key %q conflicts with reserved key", ErrLossyCommentConversion, k)
+		}
+	}
+	switch format {
+	case CommentFormatLegacy:
+		return formatLegacyComment(data, delimiter)
+	case CommentFormatJSON:
+		return formatJSONComment(data)
+	case CommentFormatYAML:
+		return formatYAMLComment(data)
+	case CommentFormatAnnotation:
+		return formatAnnotationComment(data, delimiter)
+	default:
+		return "", fmt.Errorf("%w: %s", ErrUnsupportedFormat, format)
+	}
+}
+
+// ConvertComment コメントをfromの形式からtoの形式に変換
+func ConvertComment(comment, from, to, delimiter string) (string, error) {
+	data, err := ParseCommentAs(comment, from, delimiter)
+	if err != nil {
+		return "", err
+	}
+	return FormatComment(data, to, delimiter)
+}
+
+func formatLegacyComment(data *CommentData, delimiter string) (string, error) {
+	if len(data.Tags) > 0 || len(data.UserMetadata()) > 0 || data.Priority != 0 || data.Deprecated || len(data.LogicalNames) > 0 || len(data.Descriptions) > 0 {
+		return "", fmt.Errorf("%w: legacy format supports only logical name and description", ErrLossyCommentConversion)
+	}
+	if delimiter == "" {
+		delimiter = "|"
+	}
+	escaper := strings.NewReplacer(delimiter, `\`+delimiter)
+	name := escaper.Replace(data.LogicalName)
+	if data.Description == "" {
+		return name, nil
+	}
+	return name + delimiter + escaper.Replace(data.Description), nil
+}
+
+// commentField 出力順を保持するためのフィールド
+type commentField struct {
+	key   string
+	value any
+}
+
+// commentFields CommentDataをパーサーが解釈できるキーの並びに変換（メタデータはトップレベルに展開）
+func commentFields(data *CommentData) []commentField {
+	fields := []commentField{}
+	switch {
+	case len(data.LogicalNames) > 0:
+		fields = append(fields, commentField{"name", data.LogicalNames})
+	case data.LogicalName != "":
+		fields = append(fields, commentField{"name", data.LogicalName})
+	}
+	switch {
+	case len(data.Descriptions) > 0:
+		fields = append(fields, commentField{"description", data.Descriptions})
+	case data.Description != "":
+		fields = append(fields, commentField{"description", data.Description})
+	}
+	if len(data.Tags) > 0 {
+		fields = append(fields, commentField{"tags", data.Tags})
+	}
+	if data.Priority != 0 {
+		fields = append(fields, commentField{"priority", data.Priority})
+	}
+	if data.Deprecated {
+		fields = append(fields, commentField{"deprecated", true})
+	}
+	metadata := data.UserMetadata()
+	keys := make([]string, 0, len(metadata))
+	for k := range metadata {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	for _, k := range keys {
+		fields = append(fields, commentField{k, metadata[k]})
+	}
+	return fields
+}
+
+func formatJSONComment(data *CommentData) (string, error) {
+	out := new(bytes.Buffer)
+	out.WriteString("{")
+	for i, f := range commentFields(data) {
+		if i > 0 {
+			out.WriteString(",")
+		}
+		k, err := marshalJSONWithoutEscape(f.key)
+		if err != nil {
+			return "", err
+		}
+		v, err := marshalJSONWithoutEscape(f.value)
+		if err != nil {
+			return "", err
+		}
+		out.Write(k)
+		out.WriteString(":")
+		out.Write(v)
+	}
+	out.WriteString("}")
+	return out.String(), nil
+}
+
+// marshalJSONWithoutEscape HTMLエスケープせずにJSONに変換（コメント中の<>&をそのまま残すため）
+func marshalJSONWithoutEscape(v any) ([]byte, error) {
+	buf := new(bytes.Buffer)
+	enc := json.NewEncoder(buf)
+	enc.SetEscapeHTML(false)
+	if err := enc.Encode(v); err != nil {
+		return nil, err
+	}
+	return bytes.TrimSuffix(buf.Bytes(), []byte("\n")), nil
+}
+
+func formatYAMLComment(data *CommentData) (string, error) {
+	node := &yaml.Node{Kind: yaml.MappingNode}
+	for _, f := range commentFields(data) {
+		k := &yaml.Node{}
+		if err := k.Encode(f.key); err != nil {
+			return "", err
+		}
+		v := &yaml.Node{}
+		if err := v.Encode(f.value); err != nil {
+			return "", err
+		}
+		node.Content = append(node.Content, k, v)
+	}
+	b, err := yaml.Marshal(node)
+	if err != nil {
+		return "", err
+	}
+	return strings.TrimSuffix(string(b), "\n"), nil
+}
@@ -0,0 +1,280 @@
+package schema
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestConvertComment(t *testing.T) {
+	tests := []struct {
+		name    string
+		comment string
+		from    string
+		to      string
+		want    string
+		wantErr error
+	}{
+		{
+			name:    "legacy to json",
+			comment: "ユーザー|ユーザー情報を管理するテーブル",
+			from:    CommentFormatLegacy,
+			to:      CommentFormatJSON,
+			want:    `{"name":"ユーザー","description":"ユーザー情報を管理するテーブル"}`,
+		},
+		{
+			name:    "legacy without description to yaml",
+			comment: "ユーザー",
+			from:    CommentFormatLegacy,
+			to:      CommentFormatYAML,
+			want:    "name: ユーザー",
+		},
+		{
+			name:    "json to yaml",
+			comment: `{"name": "注文", "description": "注文情報", "tags": ["core"], "deprecated": true, "owner": "sales"}`,
+			from:    CommentFormatJSON,
+			to:      CommentFormatYAML,
+			want:    "name: 注文\ndescription: 注文情報\ntags:\n    - core\ndeprecated: true\nowner: sales",
+		},
+		{
+			name:    "yaml to json",
+			comment: "name: 注文\ndescription: a < b\npriority: 2",
+			from:    CommentFormatYAML,
+			to:      CommentFormatJSON,
+			want:    `{"name":"注文","description":"a < b","priority":2}`,
+		},
+		{
+			name:    "json to legacy escapes delimiter",
+			comment: `{"name": "A|B", "description": "説明"}`,
+			from:    CommentFormatJSON,
+			to:      CommentFormatLegacy,
+			want:    `A\|B|説明`,
+		},
+		{
+			name:    "auto",
+			comment: `{"name": "ユーザー"}`,
+			from:    CommentFormatAuto,
+			to:      CommentFormatLegacy,
+			want:    "ユーザー",
+		},
+		{
+			name:    "empty",
+			comment: "",
+			from:    CommentFormatLegacy,
+			to:      CommentFormatJSON,
+			want:    "",
+		},
+		{
+			name:    "json to legacy with tags",
+			comment: `{"name": "ユーザー", "tags": ["core"]}`,
+			from:    CommentFormatJSON,
+			to:      CommentFormatLegacy,
+			wantErr: ErrLossyCommentConversion,
+		},
+		{
+			name:    "not json",
+			comment: "ユーザー|説明",
+			from:    CommentFormatJSON,
+			to:      CommentFormatYAML,
+			wantErr: ErrInvalidCommentFormat,
+		},
+		{
+			name:    "unsupported format",
+			comment: "ユーザー",
+			from:    CommentFormatLegacy,
+			to:      "xml",
+			wantErr: ErrUnsupportedFormat,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ConvertComment(tt.comment, tt.from, tt.to, "|")
+			if tt.wantErr != nil {
+				if !errors.Is(err, tt.wantErr) {
+					t.Errorf("got %v, want %v", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got != tt.want {
+				t.Errorf("got %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestConvertCommentRoundTrip(t *testing.T) {
+	data := &CommentData{
+		LogicalName: "ユーザー",
+		Description: "ユーザー情報: 会員と退会者を含む",
+		Tags:        []string{"core", "pii"},
+		Priority:    1,
+		Deprecated:  true,
+		Metadata:    map[string]string{"owner": "auth-team", "since": "2020"},
+	}
+	for _, format := range []string{CommentFormatJSON, CommentFormatYAML} {
+		t.Run(format, func(t *testing.T) {
+			comment, err := FormatComment(data, format, "|")
+			if err != nil {
+				t.Fatal(err)
+			}
+			got, err := ParseCommentAs(comment, format, "|")
+			if err != nil {
+				t.Fatal(err)
+			}
+			got.Source = ""
+			if diff := cmp.Diff(data, got); diff != "" {
+				t.Error(diff)
+			}
+		})
+	}
+
+	legacy := "ユーザー|説明\\|補足"
+	for _, format := range []string{CommentFormatJSON, CommentFormatYAML} {
+		t.Run("legacy via "+format, func(t *testing.T) {
+			converted, err := ConvertComment(legacy, CommentFormatLegacy, format, "|")
+			if err != nil {
+				t.Fatal(err)
+			}
+			got, err := ConvertComment(converted, format, CommentFormatLegacy, "|")
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got != legacy {
+				t.Errorf("got %q, want %q", got, legacy)
+			}
+		})
+	}
+	// the converted comment is read back by the drivers
+	converted, err := ConvertComment(`{"name": "A|B", "description": "説明"}`, CommentFormatJSON, CommentFormatLegacy, "|")
+	if err != nil {
+		t.Fatal(err)
+	}
+	c := &Column{Name: "ab", Comment: converted}
+	c.SetLogicalNameFromComment("|", false)
+	if c.LogicalName != "A|B" || c.Comment != "説明" {
+		t.Errorf("got %q and %q, want %q and %q", c.LogicalName, c.Comment, "A|B", "説明")
+	}
+	o := (&Schema{Tables: []*Table{{Name: "t", Columns: []*Column{c}}}}).CommentObjects("|", false)[0]
+	if o.Comment != converted {
+		t.Errorf("got %q, want %q", o.Comment, converted)
+	}
+}
+
+func TestIsStructuredComment(t *testing.T) {
+	tests := []struct {
+		comment string
+		want    bool
+	}{
+		{`{"name": "ユーザー"}`, true},
+		{"name: ユーザー\ndescription: 説明", true},
+		{"ユーザー|説明", false},
+		{"ユーザー|説明 @pii @owner auth-team", true},
+		{"問い合わせ先 support@example.com", false},
+		{"ID: primary key|説明", false},
+		{"", false},
+	}
+	for _, tt := range tests {
+		if got := IsStructuredComment(tt.comment); got != tt.want {
+			t.Errorf("IsStructuredComment(%q) = %v, want %v", tt.comment, got, tt.want)
+		}
+	}
+
+	c := &Column{Name: "id", Comment: `{"name": "ID", "description": "識別子"}`}
+	c.SetLogicalNameFromComment("|", false)
+	if c.LogicalName != "" || c.Comment != `{"name": "ID", "description": "識別子"}` {
+		t.Errorf("structured comment should not be split: %q %q", c.LogicalName, c.Comment)
+	}
+}
+
+func TestSetLogicalNameFromStructuredComment(t *testing.T) {
+	tests := []struct {
+		comment         string
+		wantLogicalName string
+		wantComment     string
+	}{
+		{"担当者|問い合わせは @support まで", "担当者", "問い合わせは @support まで"},
+		{"ユーザー|説明", "ユーザー", "説明"},
+		{`{"name": "ID", "description": "see @owner"}`, `{"name": "ID", "description": "see @owner"}`, ""},
+		{`{"name": "ID|識別子"}`, `{"name": "ID`, `識別子"}`},
+	}
+	for _, tt := range tests {
+		c := &Column{Name: "staff", Comment: tt.comment}
+		// ドライバーは構造化コメントを分割しない
+		c.SetLogicalNameFromComment("|", false)
+		// 拡張コメント処理を行わない場合はドライバーがこれまで分割していたとおりに分割する
+		c.SetLogicalNameFromStructuredComment("|", false)
+		if c.LogicalName != tt.wantLogicalName || c.Comment != tt.wantComment {
+			t.Errorf("%q: got %q %q, want %q %q", tt.comment, c.LogicalName, c.Comment, tt.wantLogicalName, tt.wantComment)
+		}
+	}
+}
+
+func TestLocalizedValue(t *testing.T) {
+	values := map[string]string{"ja-JP": "ユーザー", "en": "User", "fr": "Utilisateur"}
+	tests := []struct {
+		lang string
+		want string
+	}{
+		{"ja-JP", "ユーザー"},
+		{"ja", "ユーザー"},
+		{"EN-us", "User"},
+		{"fr-CA", "Utilisateur"},
+		{"de", "User"},
+		{"", "User"},
+	}
+	for _, tt := range tests {
+		if got := LocalizedValue(values, tt.lang); got != tt.want {
+			t.Errorf("LocalizedValue(%q) = %q, want %q", tt.lang, got, tt.want)
+		}
+	}
+	if got := LocalizedValue(map[string]string{"ja": "ユーザー", "zh": "用户"}, "de"); got != "ユーザー" {
+		t.Errorf("got %q, want first locale in order", got)
+	}
+}
+
+func TestLocalizedComment(t *testing.T) {
+	for _, comment := range []string{
+		`{"name": {"ja": "ユーザー", "en": "User"}, "description": {"ja": "会員情報", "en": "Members"}}`,
+		"name:\n  ja: ユーザー\n  en: User\ndescription:\n  ja: 会員情報\n  en: Members",
+	} {
+		got, err := ParseCommentAs(comment, CommentFormatAuto, "|")
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got.LogicalName != "User" || got.Description != "Members" {
+			t.Errorf("default locale: got %q %q", got.LogicalName, got.Description)
+		}
+		got.Localize("ja")
+		if got.LogicalName != "ユーザー" || got.Description != "会員情報" {
+			t.Errorf("ja: got %q %q", got.LogicalName, got.Description)
+		}
+		if len(got.UserMetadata()) != 0 {
+			t.Errorf("localized values should not be metadata: %v", got.Metadata)
+		}
+
+		converted, err := FormatComment(got, CommentFormatJSON, "|")
+		if err != nil {
+			t.Fatal(err)
+		}
+		if want := `{"name":{"en":"User","ja":"ユーザー"},"description":{"en":"Members","ja":"会員情報"}}`; converted != want {
+			t.Errorf("got %q, want %q", converted, want)
+		}
+		if _, err := FormatComment(got, CommentFormatLegacy, "|"); !errors.Is(err, ErrLossyCommentConversion) {
+			t.Errorf("got %v, want ErrLossyCommentConversion", err)
+		}
+	}
+
+	p := NewEnhancedCommentProcessor()
+	p.SetLang("ja")
+	got, err := p.ProcessCommentWithValidation(`{"name": {"ja": "ユーザー", "en": "User"}}`, "|", ObjectTypeTable)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got.LogicalName != "ユーザー" {
+		t.Errorf("got %q", got.LogicalName)
+	}
+}
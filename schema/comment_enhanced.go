@@ -1,5 +1,11 @@
 package schema
 
+import (
+	"maps"
+	"slices"
+	"strings"
+)
+
 // ObjectType はコメント処理対象のデータベースオブジェクトタイプを表す
 type ObjectType string
 
@@ -20,6 +26,8 @@ const (
 	ObjectTypeFunction ObjectType = "function"
 	// ObjectTypeEnum 列挙型
 	ObjectTypeEnum ObjectType = "enum"
+	// ObjectTypeRelation リレーション
+	ObjectTypeRelation ObjectType = "relation"
 )
 
 // CommentData は解析されたコメントデータを統一的に表現する構造体
@@ -36,6 +44,10 @@ type CommentData struct {
 	Priority int `json:"priority,omitempty" yaml:"priority,omitempty"`
 	// Deprecated 非推奨フラグ
 	Deprecated bool `json:"deprecated,omitempty" yaml:"deprecated,omitempty"`
+	// LogicalNames ロケールごとの論理名（例: {"ja": "ユーザー", "en": "User"}）
+	LogicalNames map[string]string `json:"names,omitempty" yaml:"names,omitempty"`
+	// Descriptions ロケールごとの説明文
+	Descriptions map[string]string `json:"descriptions,omitempty" yaml:"descriptions,omitempty"`
 	// Source 元のコメント文字列（デバッグ用）
 	Source string `json:"-" yaml:"-"`
 }
@@ -46,7 +58,9 @@ func (cd *CommentData) IsEmpty() bool {
 		(cd.LogicalName == "" &&
 			cd.Description == "" &&
 			len(cd.Tags) == 0 &&
-			len(cd.Metadata) == 0)
+			len(cd.Metadata) == 0 &&
+			len(cd.LogicalNames) == 0 &&
+			len(cd.Descriptions) == 0)
 }
 
 // HasLogicalName 論理名が設定されているかを判定
@@ -122,6 +136,14 @@ func (cd *CommentData) Clone() *CommentData {
 		Source:      cd.Source,
 	}
 	
+	// ロケールごとの論理名・説明のコピー
+	if len(cd.LogicalNames) > 0 {
+		clone.LogicalNames = maps.Clone(cd.LogicalNames)
+	}
+	if len(cd.Descriptions) > 0 {
+		clone.Descriptions = maps.Clone(cd.Descriptions)
+	}
+	
 	// Tagsのコピー
 	if len(cd.Tags) > 0 {
 		clone.Tags = make([]string, len(cd.Tags))
@@ -161,6 +183,24 @@ func (cd *CommentData) Merge(other *CommentData) *CommentData {
 		merged.Description = other.Description
 	}
 	
+	// ロケールごとの論理名・説明はマージ（thisが優先）
+	for k, v := range other.LogicalNames {
+		if _, exists := merged.LogicalNames[k]; !exists {
+			if merged.LogicalNames == nil {
+				merged.LogicalNames = make(map[string]string)
+			}
+			merged.LogicalNames[k] = v
+		}
+	}
+	for k, v := range other.Descriptions {
+		if _, exists := merged.Descriptions[k]; !exists {
+			if merged.Descriptions == nil {
+				merged.Descriptions = make(map[string]string)
+			}
+			merged.Descriptions[k] = v
+		}
+	}
+	
 	// Tagsはマージ（重複除去）
 	if len(other.Tags) > 0 {
 		tagSet := make(map[string]bool)
@@ -188,4 +228,69 @@ func (cd *CommentData) Merge(other *CommentData) *CommentData {
 	}
 	
 	return merged
-}
\ No newline at end of file
+}
+// DefaultLang ロケールの指定がない場合に優先するロケール
+const DefaultLang = "en"
+
+// Localize ロケールごとの論理名・説明から指定されたロケールの値をLogicalName/Descriptionに設定
+func (cd *CommentData) Localize(lang string) {
+	if cd == nil {
+		return
+	}
+	if v := LocalizedValue(cd.LogicalNames, lang); v != "" {
+		cd.LogicalName = v
+	}
+	if v := LocalizedValue(cd.Descriptions, lang); v != "" {
+		cd.Description = v
+	}
+}
+
+// LocalizedValue ロケールごとの値から指定されたロケールの値を取得
+// 完全一致 -> 言語部分が一致（ja-JP -> ja, ja -> ja-JP）-> DefaultLang -> ロケール名順で最初の値 の順にフォールバックする
+func LocalizedValue(values map[string]string, lang string) string {
+	keys := slices.Sorted(maps.Keys(values))
+	find := func(match func(k string) bool) string {
+		for _, k := range keys {
+			if values[k] != "" && match(k) {
+				return values[k]
+			}
+		}
+		return ""
+	}
+	base := func(l string) string {
+		b, _, _ := strings.Cut(l, "-")
+		return b
+	}
+	if lang != "" {
+		if v := find(func(k string) bool { return strings.EqualFold(k, lang) }); v != "" {
+			return v
+		}
+		if v := find(func(k string) bool { return strings.EqualFold(k, base(lang)) }); v != "" {
+			return v
+		}
+		if v := find(func(k string) bool { return strings.EqualFold(base(k), base(lang)) }); v != "" {
+			return v
+		}
+	}
+	if v := find(func(k string) bool { return strings.EqualFold(k, DefaultLang) }); v != "" {
+		return v
+	}
+	return find(func(string) bool { return true })
+}
+
+// localizedStrings ロケールをキーとするマップ（{"ja": "ユーザー", "en": "User"}）を文字列のマップに変換
+func localizedStrings(value any) (map[string]string, bool) {
+	m, ok := value.(map[string]any)
+	if !ok || len(m) == 0 {
+		return nil, false
+	}
+	result := make(map[string]string, len(m))
+	for k, v := range m {
+		str, ok := v.(string)
+		if !ok {
+			return nil, false
+		}
+		result[k] = str
+	}
+	return result, true
+}
@@ -0,0 +1,132 @@
+package schema
+
+import "slices"
+
+// InheritedComment 外部キーの親カラムから継承したコメントの項目
+// 継承した値はカラムのコメントや論理名には書き込まず、ここに保持して表示時に参照する
+type InheritedComment struct {
+	// From 継承元のカラム（例: users.id）
+	From string `json:"from" yaml:"from"`
+	// LogicalName 継承した論理名
+	LogicalName string `json:"logical_name,omitempty" yaml:"logicalName,omitempty"`
+	// Description 継承した説明
+	Description string `json:"description,omitempty" yaml:"description,omitempty"`
+	// Tags 継承したタグ
+	Tags []string `json:"tags,omitempty" yaml:"tags,omitempty"`
+}
+
+// InheritCommentsFromParents 外部キーのカラムで論理名・説明・タグが空の項目に、リレーションの親カラムの値を継承する
+// 親カラム自身も継承する場合（orders.user_id -> users.id -> accounts.id）は親カラムの継承を先に行う
+// 継承した値はColumn.Inheritedに記録する
+func (s *Schema) InheritCommentsFromParents() {
+	done := map[*Column]bool{}
+	for _, t := range s.Tables {
+		for _, c := range t.Columns {
+			c.inheritFromParent(done, map[*Column]bool{})
+		}
+	}
+}
+
+func (c *Column) inheritFromParent(done, visiting map[*Column]bool) {
+	if done[c] || visiting[c] {
+		return
+	}
+	visiting[c] = true
+	defer func() { done[c] = true }()
+
+	pt, pc := c.parentColumn()
+	if pc == nil {
+		return
+	}
+	pc.inheritFromParent(done, visiting)
+
+	inherited := &InheritedComment{From: pt.Name + "." + pc.Name}
+	if !c.hasOwnLogicalName() {
+		inherited.LogicalName = pc.inheritableLogicalName()
+	}
+	if c.description() == "" {
+		inherited.Description = pc.inheritableDescription()
+	}
+	if len(c.ownTags()) == 0 {
+		inherited.Tags = slices.Clone(pc.GetTags())
+	}
+	if inherited.LogicalName != "" || inherited.Description != "" || len(inherited.Tags) > 0 {
+		c.Inherited = inherited
+	}
+}
+
+// parentColumn リレーションで対応する親カラムとそのテーブルを返す（複合外部キーの場合は同じ位置のカラム）
+func (c *Column) parentColumn() (*Table, *Column) {
+	for _, r := range c.ParentRelations {
+		i := slices.Index(r.Columns, c)
+		if i < 0 || i >= len(r.ParentColumns) || r.ParentTable == nil {
+			continue
+		}
+		return r.ParentTable, r.ParentColumns[i]
+	}
+	return nil, nil
+}
+
+// hasOwnLogicalName 論理名が設定されているか（fallbackToNameでカラム名が設定されている場合は除く）
+func (c *Column) hasOwnLogicalName() bool {
+	return c.ownLogicalName() != ""
+}
+
+// ownLogicalName カラム自身の論理名（拡張コメント優先、fallbackToNameで設定されたカラム名は除く）
+func (c *Column) ownLogicalName() string {
+	if c.EnhancedCommentData != nil && c.EnhancedCommentData.LogicalName != "" {
+		return c.EnhancedCommentData.LogicalName
+	}
+	if c.LogicalName != c.Name {
+		return c.LogicalName
+	}
+	return ""
+}
+
+// inheritableLogicalName 子カラムに継承する論理名（親カラム自身が継承した論理名を含む）
+func (c *Column) inheritableLogicalName() string {
+	if name := c.ownLogicalName(); name != "" {
+		return name
+	}
+	if c.Inherited != nil {
+		return c.Inherited.LogicalName
+	}
+	return ""
+}
+
+// ownTags カラム自身のタグ
+func (c *Column) ownTags() []string {
+	if c.EnhancedCommentData != nil {
+		return c.EnhancedCommentData.Tags
+	}
+	return nil
+}
+
+// hasStructuredComment 構造化コメントを拡張コメント処理済みか
+func (c *Column) hasStructuredComment() bool {
+	return c.EnhancedCommentData != nil && IsStructuredComment(c.Comment)
+}
+
+// description カラム自身の説明（構造化コメントの場合は拡張コメントの説明）
+func (c *Column) description() string {
+	if c.hasStructuredComment() {
+		return c.EnhancedCommentData.Description
+	}
+	return c.Comment
+}
+
+// inheritableDescription 子カラムに継承する説明（親カラム自身が継承した説明を含む）
+func (c *Column) inheritableDescription() string {
+	if desc := c.description(); desc != "" {
+		return desc
+	}
+	if c.Inherited != nil {
+		return c.Inherited.Description
+	}
+	return ""
+}
+
+// IsCommentInherited コメントを持たず、親カラムから継承した説明を表示しているかを返す
+func (c *Column) IsCommentInherited() bool {
+	return c.Inherited != nil && c.Inherited.Description != "" && !IsStructuredComment(c.Comment)
+}
@@ -0,0 +1,114 @@
+package schema
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestInheritCommentsFromParents(t *testing.T) {
+	accountsID := &Column{Name: "id", LogicalName: "アカウントID", Comment: "アカウントの識別子"}
+	usersID := &Column{Name: "id", LogicalName: "id"}
+	usersTenant := &Column{Name: "tenant_id", LogicalName: "テナントID", Comment: `{"name": "テナントID", "tags": ["tenant"]}`, EnhancedCommentData: &CommentData{LogicalName: "テナントID", Tags: []string{"tenant"}}}
+	ordersUserID := &Column{Name: "user_id"}
+	ordersTenantID := &Column{Name: "tenant_id", LogicalName: "注文テナント", Comment: `{"name": "注文テナント"}`, EnhancedCommentData: &CommentData{LogicalName: "注文テナント"}}
+	ordersNote := &Column{Name: "note", Comment: "備考"}
+	accounts := &Table{Name: "accounts", Columns: []*Column{accountsID}}
+	users := &Table{Name: "users", Columns: []*Column{usersID, usersTenant}}
+	orders := &Table{Name: "orders", Columns: []*Column{ordersUserID, ordersTenantID, ordersNote}}
+	s := &Schema{
+		Tables: []*Table{orders, users, accounts},
+		Relations: []*Relation{
+			{Table: orders, Columns: []*Column{ordersUserID, ordersTenantID}, ParentTable: users, ParentColumns: []*Column{usersID, usersTenant}},
+			{Table: users, Columns: []*Column{usersID}, ParentTable: accounts, ParentColumns: []*Column{accountsID}},
+		},
+	}
+	if err := s.Repair(); err != nil {
+		t.Fatal(err)
+	}
+	s.InheritCommentsFromParents()
+
+	tests := []struct {
+		column          *Column
+		wantLogicalName string
+		wantDescription string
+		wantTags        []string
+		wantInherited   *InheritedComment
+	}{
+		{usersID, "アカウントID", "アカウントの識別子", nil, &InheritedComment{From: "accounts.id", LogicalName: "アカウントID", Description: "アカウントの識別子"}},
+		// inherited values of users.id are inherited again
+		{ordersUserID, "アカウントID", "アカウントの識別子", nil, &InheritedComment{From: "users.id", LogicalName: "アカウントID", Description: "アカウントの識別子"}},
+		// the logical name of the structured comment is kept
+		{ordersTenantID, "注文テナント", `{"name": "注文テナント"}`, []string{"tenant"}, &InheritedComment{From: "users.tenant_id", Tags: []string{"tenant"}}},
+		{ordersNote, "", "備考", nil, nil},
+	}
+	for _, tt := range tests {
+		c := tt.column
+		if got := c.GetEnhancedLogicalNameOrFallback(false); got != tt.wantLogicalName {
+			t.Errorf("%s: got logical name %q, want %q", c.Name, got, tt.wantLogicalName)
+		}
+		if got := c.GetDescription(); got != tt.wantDescription {
+			t.Errorf("%s: got description %q, want %q", c.Name, got, tt.wantDescription)
+		}
+		if diff := cmp.Diff(tt.wantTags, c.GetTags()); diff != "" {
+			t.Errorf("%s: %s", c.Name, diff)
+		}
+		if diff := cmp.Diff(tt.wantInherited, c.Inherited); diff != "" {
+			t.Errorf("%s: %s", c.Name, diff)
+		}
+	}
+	// inherited values are not written back to the comments of the columns
+	if ordersUserID.Comment != "" || ordersUserID.LogicalName != "" || ordersTenantID.EnhancedCommentData.Tags != nil {
+		t.Errorf("got %q %q %v", ordersUserID.Comment, ordersUserID.LogicalName, ordersTenantID.EnhancedCommentData.Tags)
+	}
+	if !ordersUserID.IsCommentInherited() {
+		t.Error("orders.user_id should have an inherited comment")
+	}
+	if ordersTenantID.IsCommentInherited() {
+		t.Error("orders.tenant_id has its own comment")
+	}
+}
+
+func TestInheritCommentsFromParentsCycle(t *testing.T) {
+	a := &Column{Name: "b_id"}
+	b := &Column{Name: "a_id", LogicalName: "A"}
+	ta := &Table{Name: "a", Columns: []*Column{a}}
+	tb := &Table{Name: "b", Columns: []*Column{b}}
+	s := &Schema{
+		Tables: []*Table{ta, tb},
+		Relations: []*Relation{
+			{Table: ta, Columns: []*Column{a}, ParentTable: tb, ParentColumns: []*Column{b}},
+			{Table: tb, Columns: []*Column{b}, ParentTable: ta, ParentColumns: []*Column{a}},
+		},
+	}
+	if err := s.Repair(); err != nil {
+		t.Fatal(err)
+	}
+	s.InheritCommentsFromParents()
+	if a.GetEnhancedLogicalNameOrFallback(false) != "A" || b.GetEnhancedLogicalNameOrFallback(false) != "A" || b.Inherited != nil {
+		t.Errorf("got %q %q %v", a.GetEnhancedLogicalNameOrFallback(false), b.GetEnhancedLogicalNameOrFallback(false), b.Inherited)
+	}
+}
+
+func TestInheritedCommentJSON(t *testing.T) {
+	want := &Column{
+		Name:      "user_id",
+		Type:      "bigint",
+		Inherited: &InheritedComment{From: "users.id", LogicalName: "ユーザーID", Description: "ユーザーの識別子", Tags: []string{"pk"}},
+	}
+	b, err := json.Marshal(want)
+	if err != nil {
+		t.Fatal(err)
+	}
+	got := &Column{}
+	if err := json.Unmarshal(b, got); err != nil {
+		t.Fatal(err)
+	}
+	if diff := cmp.Diff(want.Inherited, got.Inherited); diff != "" {
+		t.Error(diff)
+	}
+	if !got.IsCommentInherited() || got.GetDescription() != "ユーザーの識別子" {
+		t.Errorf("got %q", got.GetDescription())
+	}
+}
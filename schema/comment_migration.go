@@ -0,0 +1,300 @@
+package schema
+
+import (
+	"fmt"
+	"strings"
+)
+
+// CommentMigrationScript スキーマのコメントをデータベースのカタログに書き戻すDDLを生成
+// formatが空の場合はコメントを（ドライバーが分割した論理名を付け直して）そのまま書き戻し、legacy/json/yamlの場合は拡張コメントデータをその形式に変換して書き戻す
+func CommentMigrationScript(s *Schema, driverName, format, delimiter string, fallbackToName bool) (string, error) {
+	var stmt func(o *CommentObject, comment string) (string, error)
+	switch driverName {
+	case "postgres", "postgresql", "redshift":
+		stmt = postgresCommentStatement
+	case "mysql", "mariadb":
+		mariadb := driverName == "mariadb"
+		stmt = func(o *CommentObject, comment string) (string, error) {
+			return mysqlCommentStatement(o, comment, mariadb)
+		}
+	case "mssql", "sqlserver":
+		stmt = mssqlCommentStatement
+	case "sqlite", "sqlite3":
+		stmt = func(o *CommentObject, comment string) (string, error) {
+			return "", fmt.Errorf("SQLite does not support comments on database objects")
+		}
+	default:
+		return "", fmt.Errorf("unsupported driver for comment migration: %s", driverName)
+	}
+
+	var b strings.Builder
+	_, _ = fmt.Fprintf(&b, "-- Comment migration script for %s\n", driverName)
+	for _, o := range s.CommentObjects(delimiter, fallbackToName) {
+		comment := o.Comment
+		if format != "" {
+			data := o.Data
+			if data == nil {
+				var err error
+				data, err = ParseCommentAs(o.Comment, CommentFormatAuto, delimiter)
+				if err != nil {
+					return "", fmt.Errorf("%s %s: %w", o.Type, o.FullName(), err)
+				}
+			}
+			c, err := FormatComment(data, format, delimiter)
+			if err != nil {
+				return "", fmt.Errorf("%s %s: %w", o.Type, o.FullName(), err)
+			}
+			comment = c
+		}
+		if comment == "" {
+			continue
+		}
+		st, err := stmt(o, comment)
+		if err != nil {
+			// 書き戻せないオブジェクトはスクリプト内に理由を残してスキップ
+			_, _ = fmt.Fprintf(&b, "-- skip %s %s: %s\n", o.Type, o.FullName(), err)
+			continue
+		}
+		b.WriteString(st)
+		b.WriteString("\n")
+	}
+	return b.String(), nil
+}
+
+// splitTableName テーブル名をスキーマ名とテーブル名に分割
+func splitTableName(name string) (string, string) {
+	schemaName, tableName, found := strings.Cut(name, ".")
+	if !found {
+		return "", name
+	}
+	return schemaName, tableName
+}
+
+func postgresCommentStatement(o *CommentObject, comment string) (string, error) {
+	schemaName, tableName := splitTableName(o.Table.Name)
+	table := quotePostgresIdent(tableName)
+	if schemaName != "" {
+		table = quotePostgresIdent(schemaName) + "." + table
+	}
+	lit := quotePostgresLiteral(comment)
+	switch o.Type {
+	case ObjectTypeTable, ObjectTypeView:
+		target := "TABLE"
+		switch strings.ToUpper(o.Table.Type) {
+		case "VIEW":
+			target = "VIEW"
+		case "MATERIALIZED VIEW":
+			target = "MATERIALIZED VIEW"
+		case "FOREIGN TABLE":
+			target = "FOREIGN TABLE"
+		}
+		return fmt.Sprintf("COMMENT ON %s %s IS %s;", target, table, lit), nil
+	case ObjectTypeColumn:
+		return fmt.Sprintf("COMMENT ON COLUMN %s.%s IS %s;", table, quotePostgresIdent(o.Name), lit), nil
+	case ObjectTypeIndex:
+		index := quotePostgresIdent(o.Name)
+		if schemaName != "" {
+			index = quotePostgresIdent(schemaName) + "." + index
+		}
+		return fmt.Sprintf("COMMENT ON INDEX %s IS %s;", index, lit), nil
+	case ObjectTypeConstraint:
+		return fmt.Sprintf("COMMENT ON CONSTRAINT %s ON %s IS %s;", quotePostgresIdent(o.Name), table, lit), nil
+	case ObjectTypeTrigger:
+		return fmt.Sprintf("COMMENT ON TRIGGER %s ON %s IS %s;", quotePostgresIdent(o.Name), table, lit), nil
+	}
+	return "", fmt.Errorf("unsupported object type: %s", o.Type)
+}
+
+func quotePostgresIdent(s string) string {
+	return `"` + strings.ReplaceAll(s, `"`, `""`) + `"`
+}
+
+func quotePostgresLiteral(s string) string {
+	return "'" + strings.ReplaceAll(s, "'", "''") + "'"
+}
+
+func mysqlCommentStatement(o *CommentObject, comment string, mariadb bool) (string, error) {
+	table := quoteMySQLIdent(o.Table.Name)
+	lit := quoteMySQLLiteral(comment)
+	switch o.Type {
+	case ObjectTypeTable:
+		return fmt.Sprintf("ALTER TABLE %s COMMENT = %s;", table, lit), nil
+	case ObjectTypeColumn:
+		def, err := mysqlColumnDefinition(o.Table, o.Column, mariadb)
+		if err != nil {
+			return "", err
+		}
+		return fmt.Sprintf("ALTER TABLE %s MODIFY COLUMN %s %s COMMENT %s;", table, quoteMySQLIdent(o.Name), def, lit), nil
+	case ObjectTypeView:
+		return "", fmt.Errorf("MySQL does not support comments on views")
+	case ObjectTypeIndex:
+		return "", fmt.Errorf("MySQL does not support changing index comments without recreating the index")
+	}
+	return "", fmt.Errorf("MySQL does not support comments on %ss", o.Type)
+}
+
+// mysqlColumnDefinition MODIFY COLUMNで既存の定義を維持するためのカラム定義を生成
+// テーブル定義（SHOW CREATE TABLE）があればその定義をそのまま使用する
+// テーブル定義がない文字列型のカラムは、CHARACTER SET/COLLATEを維持できないためエラーを返す
+func mysqlColumnDefinition(t *Table, c *Column, mariadb bool) (string, error) {
+	if def, ok := mysqlColumnDefinitionFromTableDef(t.Def, c.Name); ok {
+		return def, nil
+	}
+	if isMySQLCharacterType(c.Type) {
+		return "", fmt.Errorf("the table definition is required to preserve CHARACTER SET and COLLATE of the column")
+	}
+	return mysqlColumnDefinitionFromColumn(c, mariadb), nil
+}
+
+// mysqlColumnDefinitionFromTableDef SHOW CREATE TABLEの結果からCOMMENT句を除いたカラム定義を取得
+func mysqlColumnDefinitionFromTableDef(tableDef, name string) (string, bool) {
+	prefix := quoteMySQLIdent(name) + " "
+	for _, line := range strings.Split(tableDef, "\n") {
+		line = strings.TrimSpace(line)
+		if !strings.HasPrefix(line, prefix) {
+			continue
+		}
+		def := strings.TrimSuffix(strings.TrimPrefix(line, prefix), ",")
+		return strings.TrimSpace(removeMySQLCommentClause(def)), true
+	}
+	return "", false
+}
+
+// removeMySQLCommentClause カラム定義からCOMMENT句を除去（文字列リテラル内は対象外）
+func removeMySQLCommentClause(def string) string {
+	const clause = " COMMENT '"
+	inQuote := false
+	for i := 0; i < len(def); i++ {
+		switch {
+		case inQuote && def[i] == '\\':
+			i++
+		case def[i] == '\'':
+			inQuote = !inQuote
+		case !inQuote && strings.HasPrefix(def[i:], clause):
+			end := len(def)
+			for j := i + len(clause); j < len(def); j++ {
+				if def[j] == '\\' {
+					j++
+					continue
+				}
+				if def[j] == '\'' {
+					if j+1 < len(def) && def[j+1] == '\'' {
+						j++
+						continue
+					}
+					end = j + 1
+					break
+				}
+			}
+			return def[:i] + def[end:]
+		}
+	}
+	return def
+}
+
+// isMySQLCharacterType CHARACTER SET/COLLATEを持つ文字列型かを判定
+func isMySQLCharacterType(typ string) bool {
+	base, _, _ := strings.Cut(strings.ToLower(strings.TrimSpace(typ)), "(")
+	switch strings.TrimSpace(base) {
+	case "char", "varchar", "tinytext", "text", "mediumtext", "longtext", "enum", "set":
+		return true
+	}
+	return false
+}
+
+// mysqlColumnDefinitionFromColumn カラムの情報からカラム定義を生成
+func mysqlColumnDefinitionFromColumn(c *Column, mariadb bool) string {
+	def := []string{c.Type}
+	extra := strings.ToLower(c.ExtraDef)
+	generated := strings.HasPrefix(extra, "generated always as")
+	if generated {
+		def = append(def, c.ExtraDef)
+	}
+	if c.Nullable {
+		def = append(def, "NULL")
+	} else {
+		def = append(def, "NOT NULL")
+	}
+	if c.Default.Valid && !generated {
+		def = append(def, "DEFAULT "+mysqlColumnDefault(c, mariadb))
+	}
+	if strings.Contains(extra, "auto_increment") {
+		def = append(def, "AUTO_INCREMENT")
+	}
+	if i := strings.Index(extra, "on update "); i >= 0 {
+		def = append(def, "ON UPDATE "+c.ExtraDef[i+len("on update "):])
+	}
+	return strings.Join(def, " ")
+}
+
+// mysqlColumnDefault information_schema.columns.column_default をDEFAULT句の値に変換
+func mysqlColumnDefault(c *Column, mariadb bool) string {
+	v := c.Default.String
+	if mariadb {
+		// MariaDBのcolumn_defaultはリテラルがクォート済み
+		return v
+	}
+	upper := strings.ToUpper(v)
+	switch {
+	case strings.HasPrefix(upper, "CURRENT_TIMESTAMP"), strings.HasPrefix(upper, "NOW("), strings.HasPrefix(upper, "LOCALTIME"):
+		return v
+	case strings.Contains(strings.ToLower(c.ExtraDef), "default_generated"):
+		return "(" + v + ")"
+	case strings.HasPrefix(v, "b'"):
+		return v
+	}
+	return quoteMySQLLiteral(v)
+}
+
+func quoteMySQLIdent(s string) string {
+	return "`" + strings.ReplaceAll(s, "`", "``") + "`"
+}
+
+func quoteMySQLLiteral(s string) string {
+	return "'" + strings.NewReplacer(`\`, `\\`, "'", "''").Replace(s) + "'"
+}
+
+func mssqlCommentStatement(o *CommentObject, comment string) (string, error) {
+	schemaName, tableName := splitTableName(o.Table.Name)
+	if schemaName == "" {
+		schemaName = "dbo"
+	}
+	level1type := "TABLE"
+	if o.Type == ObjectTypeView || strings.ToUpper(o.Table.Type) == "VIEW" {
+		level1type = "VIEW"
+	}
+	levels := []string{"SCHEMA", schemaName, level1type, tableName}
+	switch o.Type {
+	case ObjectTypeTable, ObjectTypeView:
+	case ObjectTypeColumn:
+		levels = append(levels, "COLUMN", o.Name)
+	case ObjectTypeIndex:
+		levels = append(levels, "INDEX", o.Name)
+	case ObjectTypeConstraint:
+		levels = append(levels, "CONSTRAINT", o.Name)
+	case ObjectTypeTrigger:
+		levels = append(levels, "TRIGGER", o.Name)
+	default:
+		return "", fmt.Errorf("unsupported object type: %s", o.Type)
+	}
+	args := []string{}
+	params := []string{}
+	for i, l := range levels {
+		lit := quoteMSSQLLiteral(l)
+		args = append(args, lit)
+		key := "type"
+		if i%2 == 1 {
+			key = "name"
+		}
+		params = append(params, fmt.Sprintf("@level%d%s = %s", i/2, key, lit))
+	}
+	for len(args) < 6 {
+		args = append(args, "NULL")
+	}
+	property := fmt.Sprintf("@name = N'MS_Description', @value = %s, %s", quoteMSSQLLiteral(comment), strings.Join(params, ", "))
+	return fmt.Sprintf("IF EXISTS (SELECT 1 FROM sys.fn_listextendedproperty(N'MS_Description', %s))\n    EXEC sp_updateextendedproperty %s;\nELSE\n    EXEC sp_addextendedproperty %s;", strings.Join(args, ", "), property, property), nil
+}
+
+func quoteMSSQLLiteral(s string) string {
+	return "N'" + strings.ReplaceAll(s, "'", "''") + "'"
+}
@@ -0,0 +1,190 @@
+package schema
+
+import (
+	"database/sql"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func newCommentMigrationTestSchema(tableName string) *Schema {
+	return &Schema{
+		Tables: []*Table{
+			{
+				Name:    tableName,
+				Type:    "BASE TABLE",
+				Comment: "ユーザー|ユーザー's table",
+				Columns: []*Column{
+					{Name: "id", Type: "bigint", ExtraDef: "auto_increment", Comment: "ID"},
+					{Name: "email", Type: "varchar(255)", Nullable: true, Default: sql.NullString{String: "a\\b", Valid: true}, Comment: "メール"},
+					{Name: "updated", Type: "timestamp", Default: sql.NullString{String: "CURRENT_TIMESTAMP", Valid: true}, ExtraDef: "DEFAULT_GENERATED on update CURRENT_TIMESTAMP", Comment: "更新日時"},
+					{Name: "name", Type: "text"},
+				},
+				Indexes:  []*Index{{Name: "users_email_idx", Comment: "メール検索"}},
+				Triggers: []*Trigger{{Name: "update_users", Comment: "更新"}},
+			},
+		},
+	}
+}
+
+func TestCommentMigrationScript(t *testing.T) {
+	tests := []struct {
+		driver    string
+		tableName string
+		want      string
+	}{
+		{
+			"postgres",
+			"public.users",
+			`-- Comment migration script for postgres
+COMMENT ON TABLE "public"."users" IS 'ユーザー|ユーザー''s table';
+COMMENT ON COLUMN "public"."users"."id" IS 'ID';
+COMMENT ON COLUMN "public"."users"."email" IS 'メール';
+COMMENT ON COLUMN "public"."users"."updated" IS '更新日時';
+COMMENT ON INDEX "public"."users_email_idx" IS 'メール検索';
+COMMENT ON TRIGGER "update_users" ON "public"."users" IS '更新';
+`,
+		},
+		{
+			"mysql",
+			"users",
+			"-- Comment migration script for mysql\n" +
+				"ALTER TABLE `users` COMMENT = 'ユーザー|ユーザー''s table';\n" +
+				"ALTER TABLE `users` MODIFY COLUMN `id` bigint NOT NULL AUTO_INCREMENT COMMENT 'ID';\n" +
+				"-- skip column users.email: the table definition is required to preserve CHARACTER SET and COLLATE of the column\n" +
+				"ALTER TABLE `users` MODIFY COLUMN `updated` timestamp NOT NULL DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP COMMENT '更新日時';\n" +
+				"-- skip index users.users_email_idx: MySQL does not support changing index comments without recreating the index\n" +
+				"-- skip trigger users.update_users: MySQL does not support comments on triggers\n",
+		},
+		{
+			"sqlserver",
+			"users",
+			`-- Comment migration script for sqlserver
+IF EXISTS (SELECT 1 FROM sys.fn_listextendedproperty(N'MS_Description', N'SCHEMA', N'dbo', N'TABLE', N'users', NULL, NULL))
+    EXEC sp_updateextendedproperty @name = N'MS_Description', @value = N'ユーザー|ユーザー''s table', @level0type = N'SCHEMA', @level0name = N'dbo', @level1type = N'TABLE', @level1name = N'users';
+ELSE
+    EXEC sp_addextendedproperty @name = N'MS_Description', @value = N'ユーザー|ユーザー''s table', @level0type = N'SCHEMA', @level0name = N'dbo', @level1type = N'TABLE', @level1name = N'users';
+IF EXISTS (SELECT 1 FROM sys.fn_listextendedproperty(N'MS_Description', N'SCHEMA', N'dbo', N'TABLE', N'users', N'COLUMN', N'id'))
+    EXEC sp_updateextendedproperty @name = N'MS_Description', @value = N'ID', @level0type = N'SCHEMA', @level0name = N'dbo', @level1type = N'TABLE', @level1name = N'users', @level2type = N'COLUMN', @level2name = N'id';
+ELSE
+    EXEC sp_addextendedproperty @name = N'MS_Description', @value = N'ID', @level0type = N'SCHEMA', @level0name = N'dbo', @level1type = N'TABLE', @level1name = N'users', @level2type = N'COLUMN', @level2name = N'id';
+IF EXISTS (SELECT 1 FROM sys.fn_listextendedproperty(N'MS_Description', N'SCHEMA', N'dbo', N'TABLE', N'users', N'COLUMN', N'email'))
+    EXEC sp_updateextendedproperty @name = N'MS_Description', @value = N'メール', @level0type = N'SCHEMA', @level0name = N'dbo', @level1type = N'TABLE', @level1name = N'users', @level2type = N'COLUMN', @level2name = N'email';
+ELSE
+    EXEC sp_addextendedproperty @name = N'MS_Description', @value = N'メール', @level0type = N'SCHEMA', @level0name = N'dbo', @level1type = N'TABLE', @level1name = N'users', @level2type = N'COLUMN', @level2name = N'email';
+IF EXISTS (SELECT 1 FROM sys.fn_listextendedproperty(N'MS_Description', N'SCHEMA', N'dbo', N'TABLE', N'users', N'COLUMN', N'updated'))
+    EXEC sp_updateextendedproperty @name = N'MS_Description', @value = N'更新日時', @level0type = N'SCHEMA', @level0name = N'dbo', @level1type = N'TABLE', @level1name = N'users', @level2type = N'COLUMN', @level2name = N'updated';
+ELSE
+    EXEC sp_addextendedproperty @name = N'MS_Description', @value = N'更新日時', @level0type = N'SCHEMA', @level0name = N'dbo', @level1type = N'TABLE', @level1name = N'users', @level2type = N'COLUMN', @level2name = N'updated';
+IF EXISTS (SELECT 1 FROM sys.fn_listextendedproperty(N'MS_Description', N'SCHEMA', N'dbo', N'TABLE', N'users', N'INDEX', N'users_email_idx'))
+    EXEC sp_updateextendedproperty @name = N'MS_Description', @value = N'メール検索', @level0type = N'SCHEMA', @level0name = N'dbo', @level1type = N'TABLE', @level1name = N'users', @level2type = N'INDEX', @level2name = N'users_email_idx';
+ELSE
+    EXEC sp_addextendedproperty @name = N'MS_Description', @value = N'メール検索', @level0type = N'SCHEMA', @level0name = N'dbo', @level1type = N'TABLE', @level1name = N'users', @level2type = N'INDEX', @level2name = N'users_email_idx';
+IF EXISTS (SELECT 1 FROM sys.fn_listextendedproperty(N'MS_Description', N'SCHEMA', N'dbo', N'TABLE', N'users', N'TRIGGER', N'update_users'))
+    EXEC sp_updateextendedproperty @name = N'MS_Description', @value = N'更新', @level0type = N'SCHEMA', @level0name = N'dbo', @level1type = N'TABLE', @level1name = N'users', @level2type = N'TRIGGER', @level2name = N'update_users';
+ELSE
+    EXEC sp_addextendedproperty @name = N'MS_Description', @value = N'更新', @level0type = N'SCHEMA', @level0name = N'dbo', @level1type = N'TABLE', @level1name = N'users', @level2type = N'TRIGGER', @level2name = N'update_users';
+`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.driver, func(t *testing.T) {
+			s := newCommentMigrationTestSchema(tt.tableName)
+			got, err := CommentMigrationScript(s, tt.driver, "", "|", false)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if diff := cmp.Diff(tt.want, got); diff != "" {
+				t.Error(diff)
+			}
+		})
+	}
+}
+
+func TestCommentMigrationScriptWithFormat(t *testing.T) {
+	s := &Schema{
+		Tables: []*Table{
+			{
+				Name:    "public.users",
+				Type:    "VIEW",
+				Comment: "ユーザー|ユーザー一覧",
+				Columns: []*Column{
+					{Name: "id", Comment: "ID", EnhancedCommentData: &CommentData{LogicalName: "ID", Tags: []string{"pk"}}},
+				},
+			},
+		},
+	}
+	got, err := CommentMigrationScript(s, "postgres", CommentFormatJSON, "|", false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := `-- Comment migration script for postgres
+COMMENT ON VIEW "public"."users" IS '{"name":"ユーザー","description":"ユーザー一覧"}';
+COMMENT ON COLUMN "public"."users"."id" IS '{"name":"ID","tags":["pk"]}';
+`
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Error(diff)
+	}
+
+	if _, err := CommentMigrationScript(s, "oracle", "", "|", false); err == nil {
+		t.Error("want error")
+	}
+}
+
+func TestCommentMigrationScriptWithSplitLogicalNames(t *testing.T) {
+	newSchema := func() *Schema {
+		tbl := &Table{
+			Name:    "users",
+			Type:    "BASE TABLE",
+			Comment: "ユーザー|ユーザー情報",
+			Def: "CREATE TABLE `users` (\n" +
+				"  `id` bigint NOT NULL AUTO_INCREMENT,\n" +
+				"  `user_name` varchar(64) CHARACTER SET utf8mb4 COLLATE utf8mb4_bin NOT NULL COMMENT 'ユーザー名|ログインに使う',\n" +
+				"  `email` varchar(255) DEFAULT 'x COMMENT ''y''' COMMENT 'メール',\n" +
+				"  PRIMARY KEY (`id`)\n" +
+				") ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COMMENT='ユーザー|ユーザー情報'",
+			Columns: []*Column{
+				{Name: "id", Type: "bigint", ExtraDef: "auto_increment"},
+				{Name: "user_name", Type: "varchar(64)", Comment: "ユーザー名|ログインに使う"},
+				{Name: "email", Type: "varchar(255)", Nullable: true, Default: sql.NullString{String: "x COMMENT 'y'", Valid: true}, Comment: "メール"},
+			},
+		}
+		// ドライバーと同様に論理名を分割する
+		tbl.SetLogicalNameFromComment("|", true)
+		for _, c := range tbl.Columns {
+			c.SetLogicalNameFromComment("|", true)
+		}
+		return &Schema{Tables: []*Table{tbl}}
+	}
+	tests := []struct {
+		driver string
+		want   string
+	}{
+		{
+			"postgres",
+			`-- Comment migration script for postgres
+COMMENT ON TABLE "users" IS 'ユーザー|ユーザー情報';
+COMMENT ON COLUMN "users"."user_name" IS 'ユーザー名|ログインに使う';
+COMMENT ON COLUMN "users"."email" IS 'メール';
+`,
+		},
+		{
+			"mysql",
+			"-- Comment migration script for mysql\n" +
+				"ALTER TABLE `users` COMMENT = 'ユーザー|ユーザー情報';\n" +
+				"ALTER TABLE `users` MODIFY COLUMN `user_name` varchar(64) CHARACTER SET utf8mb4 COLLATE utf8mb4_bin NOT NULL COMMENT 'ユーザー名|ログインに使う';\n" +
+				"ALTER TABLE `users` MODIFY COLUMN `email` varchar(255) DEFAULT 'x COMMENT ''y''' COMMENT 'メール';\n",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.driver, func(t *testing.T) {
+			got, err := CommentMigrationScript(newSchema(), tt.driver, "", "|", true)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if diff := cmp.Diff(tt.want, got); diff != "" {
+				t.Error(diff)
+			}
+		})
+	}
+}
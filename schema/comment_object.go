@@ -0,0 +1,93 @@
+package schema
+
+import "strings"
+
+// CommentObject コメントを持つデータベースオブジェクト
+type CommentObject struct {
+	// Type オブジェクトタイプ
+	Type ObjectType
+	// Table オブジェクトが属するテーブル（テーブル/ビュー自身の場合はそのテーブル）
+	Table *Table
+	// Name オブジェクト名（テーブル/ビューの場合はテーブル名）
+	Name string
+	// Comment コメント（テーブル/カラムはドライバーが論理名を分割する前の形式に復元したもの）
+	Comment string
+	// Column カラムの場合はそのカラム
+	Column *Column
+	// Data 拡張コメントデータ（未処理の場合はnil）
+	Data *CommentData
+}
+
+// FullName テーブル名を含むオブジェクト名（ex. users.id）
+func (o *CommentObject) FullName() string {
+	if o.Type == ObjectTypeTable || o.Type == ObjectTypeView {
+		return o.Name
+	}
+	return o.Table.Name + "." + o.Name
+}
+
+// CommentObjects スキーマ内のコメントを持つ全オブジェクトをテーブル順に返す（コメントが空のオブジェクトは除く）
+// テーブル/カラムのコメントはdelimiterで論理名を付け直した元のコメントを返す
+func (s *Schema) CommentObjects(delimiter string, fallbackToName bool) []*CommentObject {
+	return s.commentObjects(delimiter, fallbackToName, false)
+}
+
+// AllCommentObjects スキーマ内の全オブジェクトをコメントが空のオブジェクトも含めてテーブル順に返す
+func (s *Schema) AllCommentObjects(delimiter string, fallbackToName bool) []*CommentObject {
+	return s.commentObjects(delimiter, fallbackToName, true)
+}
+
+func (s *Schema) commentObjects(delimiter string, fallbackToName, withEmpty bool) []*CommentObject {
+	objects := []*CommentObject{}
+	add := func(o *CommentObject) {
+		if !withEmpty && strings.TrimSpace(o.Comment) == "" {
+			return
+		}
+		objects = append(objects, o)
+	}
+	for _, t := range s.Tables {
+		typ := ObjectTypeTable
+		if strings.Contains(strings.ToUpper(t.Type), "VIEW") {
+			typ = ObjectTypeView
+		}
+		add(&CommentObject{Type: typ, Table: t, Name: t.Name, Comment: sourceComment(t.Name, t.LogicalName, t.Comment, t.EnhancedCommentData, delimiter, fallbackToName, true), Data: t.EnhancedCommentData})
+		for _, c := range t.Columns {
+			add(&CommentObject{Type: ObjectTypeColumn, Table: t, Name: c.Name, Comment: sourceComment(c.Name, c.LogicalName, c.Comment, c.EnhancedCommentData, delimiter, fallbackToName, false), Column: c, Data: c.EnhancedCommentData})
+		}
+		for _, i := range t.Indexes {
+			add(&CommentObject{Type: ObjectTypeIndex, Table: t, Name: i.Name, Comment: i.Comment, Data: i.EnhancedCommentData})
+		}
+		for _, c := range t.Constraints {
+			add(&CommentObject{Type: ObjectTypeConstraint, Table: t, Name: c.Name, Comment: c.Comment, Data: c.EnhancedCommentData})
+		}
+		for _, tr := range t.Triggers {
+			add(&CommentObject{Type: ObjectTypeTrigger, Table: t, Name: tr.Name, Comment: tr.Comment, Data: tr.EnhancedCommentData})
+		}
+	}
+	return objects
+}
+
+// sourceComment ドライバーがSetLogicalNameFromCommentで分割した論理名とコメントから元のコメントを復元
+// 拡張コメント処理済みの場合はパーサーが受け取った元のコメントをそのまま返す
+// 構造化データ（JSON/YAML）のコメントと、フォールバックで設定された論理名（物理名と同じ）は付け直さない
+// テーブルは区切り文字がないコメントを論理名として扱わないため、コメントが空でも区切り文字を残す
+func sourceComment(name, logicalName, comment string, data *CommentData, delimiter string, fallbackToName, table bool) string {
+	if data != nil && data.Source != "" {
+		return data.Source
+	}
+	if fallbackToName && logicalName == name {
+		logicalName = ""
+	}
+	if logicalName == "" || isStructuredDataComment(comment) {
+		return comment
+	}
+	if delimiter == "" {
+		delimiter = "|"
+	}
+	// 区切り文字を含む論理名はエスケープされていたもの
+	logicalName = strings.ReplaceAll(logicalName, delimiter, `\`+delimiter)
+	if comment == "" && !table {
+		return logicalName
+	}
+	return logicalName + delimiter + comment
+}
@@ -0,0 +1,140 @@
+package schema
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestCommentObjects(t *testing.T) {
+	s := &Schema{
+		Tables: []*Table{
+			{
+				Name:    "users",
+				Type:    "BASE TABLE",
+				Comment: "ユーザー",
+				Columns: []*Column{
+					{Name: "id", Comment: "ID"},
+					{Name: "name"},
+				},
+				Indexes:     []*Index{{Name: "users_name_idx", Comment: "名前"}},
+				Constraints: []*Constraint{{Name: "users_pk"}},
+				Triggers:    []*Trigger{{Name: "update_users", Comment: "更新"}},
+			},
+			{
+				Name:    "user_view",
+				Type:    "VIEW",
+				Comment: "ビュー",
+			},
+		},
+	}
+	got := []string{}
+	for _, o := range s.CommentObjects("|", false) {
+		got = append(got, string(o.Type)+" "+o.FullName()+" "+o.Comment)
+	}
+	want := []string{
+		"table users ユーザー",
+		"column users.id ID",
+		"index users.users_name_idx 名前",
+		"trigger users.update_users 更新",
+		"view user_view ビュー",
+	}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Error(diff)
+	}
+}
+
+func TestCommentObjectsRestoreLogicalName(t *testing.T) {
+	tests := []struct {
+		name           string
+		fallbackToName bool
+		want           []string
+	}{
+		{
+			"without fallback",
+			false,
+			[]string{
+				`table users {"name":"ユーザー","description":"ユーザー情報"}`,
+				`column users.user_name {"name":"ユーザー名","description":"ログインに使う"}`,
+				`column users.email {"name":"メール"}`,
+				`column users.note {"name":"備考"}`,
+			},
+		},
+		{
+			"with fallback",
+			true,
+			[]string{
+				`table users {"name":"ユーザー","description":"ユーザー情報"}`,
+				`column users.user_name {"name":"ユーザー名","description":"ログインに使う"}`,
+				`column users.email {"name":"メール"}`,
+				`column users.note {"name":"備考"}`,
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tbl := &Table{
+				Name:    "users",
+				Type:    "BASE TABLE",
+				Comment: "ユーザー|ユーザー情報",
+				Columns: []*Column{
+					{Name: "id"},
+					{Name: "user_name", Comment: "ユーザー名|ログインに使う"},
+					{Name: "email", Comment: "メール"},
+					{Name: "note", Comment: `{"name": "備考"}`},
+				},
+			}
+			// ドライバーと同様に論理名を分割する
+			tbl.SetLogicalNameFromComment("|", tt.fallbackToName)
+			for _, c := range tbl.Columns {
+				c.SetLogicalNameFromComment("|", tt.fallbackToName)
+			}
+			s := &Schema{Tables: []*Table{tbl}}
+			got := []string{}
+			for _, o := range s.CommentObjects("|", tt.fallbackToName) {
+				converted, err := ConvertComment(o.Comment, CommentFormatAuto, CommentFormatJSON, "|")
+				if err != nil {
+					t.Fatal(err)
+				}
+				got = append(got, string(o.Type)+" "+o.FullName()+" "+converted)
+			}
+			if diff := cmp.Diff(tt.want, got); diff != "" {
+				t.Error(diff)
+			}
+		})
+	}
+}
+
+func TestCommentObjectsEscapedDelimiter(t *testing.T) {
+	tbl := &Table{
+		Name:    "orders",
+		Type:    "BASE TABLE",
+		Comment: `受注\|出荷|受注と出荷`,
+		Columns: []*Column{
+			{Name: "status", Comment: `状態\|区分`},
+		},
+	}
+	// ドライバーと同様に論理名を分割する
+	tbl.SetLogicalNameFromComment("|", false)
+	for _, c := range tbl.Columns {
+		c.SetLogicalNameFromComment("|", false)
+	}
+	if tbl.LogicalName != "受注|出荷" || tbl.Comment != "受注と出荷" {
+		t.Errorf("got %q %q", tbl.LogicalName, tbl.Comment)
+	}
+	if got := tbl.Columns[0].LogicalName; got != "状態|区分" {
+		t.Errorf("got %q", got)
+	}
+	s := &Schema{Tables: []*Table{tbl}}
+	got := []string{}
+	for _, o := range s.CommentObjects("|", false) {
+		got = append(got, string(o.Type)+" "+o.FullName()+" "+o.Comment)
+	}
+	want := []string{
+		`table orders 受注\|出荷|受注と出荷`,
+		`column orders.status 状態\|区分`,
+	}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Error(diff)
+	}
+}
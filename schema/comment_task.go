@@ -0,0 +1,54 @@
+package schema
+
+import (
+	"runtime"
+	"sync"
+)
+
+// CommentTask 拡張コメント処理の対象オブジェクトと処理内容
+type CommentTask struct {
+	// ObjectType オブジェクトタイプ
+	ObjectType ObjectType
+	// Name エラーメッセージ用のオブジェクト名（例: users.email）
+	Name string
+	// Process コメントを処理してオブジェクトに結果を設定する関数
+	Process func() error
+}
+
+// RunCommentTasks タスクを最大workers並列で処理し、タスクと同じ順序でエラーを返す
+// workersが0以下の場合はGOMAXPROCS、1の場合は逐次処理
+// 各タスクは別々のオブジェクトを更新するため、タスク間の排他は不要
+func RunCommentTasks(tasks []CommentTask, workers int) []error {
+	errs := make([]error, len(tasks))
+	if workers <= 0 {
+		workers = runtime.GOMAXPROCS(0)
+	}
+	if workers > len(tasks) {
+		workers = len(tasks)
+	}
+	if workers <= 1 {
+		for i, task := range tasks {
+			errs[i] = task.Process()
+		}
+		return errs
+	}
+
+	indexes := make(chan int)
+	var wg sync.WaitGroup
+	for range workers {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range indexes {
+				// 結果はインデックスごとに書き込むため、完了順に関わらずエラーの順序は一定
+				errs[i] = tasks[i].Process()
+			}
+		}()
+	}
+	for i := range tasks {
+		indexes <- i
+	}
+	close(indexes)
+	wg.Wait()
+	return errs
+}
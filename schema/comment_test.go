@@ -54,6 +54,24 @@ func TestSplitComment(t *testing.T) {
 				Comment:     "主キー|自動採番",
 			},
 		},
+		{
+			name:      "エスケープされた区切り文字",
+			comment:   `A\|B|説明\|補足`,
+			delimiter: "|",
+			want: SplitCommentParts{
+				LogicalName: "A|B",
+				Comment:     "説明|補足",
+			},
+		},
+		{
+			name:      "エスケープされた区切り文字のみ",
+			comment:   `A\|B`,
+			delimiter: "|",
+			want: SplitCommentParts{
+				LogicalName: "A|B",
+				Comment:     "",
+			},
+		},
 		{
 			name:      "異なる区切り文字",
 			comment:   "ユーザーID;ユーザーの一意識別子",
@@ -138,6 +138,14 @@ func (v *DefaultCommentValidator) Sanitize(data *CommentData) *CommentData {
 	// 説明のサニタイゼーション
 	sanitized.Description = v.sanitizeString(sanitized.Description)
 
+	// ロケールごとの論理名・説明のサニタイゼーション
+	for k, value := range sanitized.LogicalNames {
+		sanitized.LogicalNames[k] = v.sanitizeString(value)
+	}
+	for k, value := range sanitized.Descriptions {
+		sanitized.Descriptions[k] = v.sanitizeString(value)
+	}
+
 	// タグのサニタイゼーション
 	if len(sanitized.Tags) > 0 {
 		sanitizedTags := make([]string, 0, len(sanitized.Tags))
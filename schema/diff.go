@@ -0,0 +1,543 @@
+package schema
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+)
+
+// DiffType is the type of change between two schemas.
+type DiffType string
+
+const (
+	DiffAdded    DiffType = "added"
+	DiffRemoved  DiffType = "removed"
+	DiffModified DiffType = "modified"
+	DiffRenamed  DiffType = "renamed"
+)
+
+// SchemaDiff is the typed result of Diff.
+type SchemaDiff struct { // nolint: revive
+	Changes []*Change `json:"changes"`
+	// From and To are the compared schemas
+	From *Schema `json:"-" yaml:"-"`
+	To   *Schema `json:"-" yaml:"-"`
+}
+
+// Change is the struct for a single change of a database object.
+type Change struct {
+	Type       DiffType       `json:"type"`
+	ObjectType ObjectType     `json:"object_type" yaml:"object_type"`
+	Table      string         `json:"table,omitempty" yaml:"table,omitempty"`
+	Name       string         `json:"name"`
+	From       string         `json:"from,omitempty" yaml:"from,omitempty"`
+	Severity   Severity       `json:"severity,omitempty" yaml:"severity,omitempty"`
+	Before     any            `json:"before,omitempty" yaml:"before,omitempty"`
+	After      any            `json:"after,omitempty" yaml:"after,omitempty"`
+	Fields     []*FieldChange `json:"fields,omitempty" yaml:"fields,omitempty"`
+}
+
+// FieldChange is the struct for a modified field of a database object.
+type FieldChange struct {
+	Field  string `json:"field"`
+	Before any    `json:"before"`
+	After  any    `json:"after"`
+}
+
+// String returns the text representation of the field change. ex) type: "varchar(255)" -> "varchar(50)"
+func (f *FieldChange) String() string {
+	return fmt.Sprintf("%s: %s -> %s", f.Field, formatDiffValue(f.Before), formatDiffValue(f.After))
+}
+
+func formatDiffValue(v any) string {
+	switch vv := v.(type) {
+	case nil:
+		return "null"
+	case *string:
+		if vv == nil {
+			return "null"
+		}
+		return fmt.Sprintf("%q", *vv)
+	case string:
+		return fmt.Sprintf("%q", vv)
+	case []string:
+		return fmt.Sprintf("[%s]", strings.Join(vv, ", "))
+	case []any:
+		// values decoded from JSON
+		s := []string{}
+		for _, e := range vv {
+			s = append(s, fmt.Sprintf("%v", e))
+		}
+		return fmt.Sprintf("[%s]", strings.Join(s, ", "))
+	default:
+		return fmt.Sprintf("%v", vv)
+	}
+}
+
+// HasChanges returns whether the diff has any change.
+func (d *SchemaDiff) HasChanges() bool {
+	return d != nil && len(d.Changes) > 0
+}
+
+// Path returns the dotted path of the changed object.
+func (c *Change) Path() string {
+	if c.Table == "" || c.ObjectType == ObjectTypeTable || c.ObjectType == ObjectTypeRelation {
+		return c.Name
+	}
+	return fmt.Sprintf("%s.%s", c.Table, c.Name)
+}
+
+// Field returns the field change by field name.
+func (c *Change) Field(name string) (*FieldChange, bool) {
+	for _, f := range c.Fields {
+		if f.Field == name {
+			return f, true
+		}
+	}
+	return nil, false
+}
+
+// Diff compares schema a (before) with schema b (after) and returns typed change records.
+func Diff(a, b *Schema) *SchemaDiff {
+	return DiffWithOption(a, b, DefaultDiffOption())
+}
+
+// DiffWithOption compares schema a (before) with schema b (after) using DiffOption.
+func DiffWithOption(a, b *Schema, opt *DiffOption) *SchemaDiff {
+	if opt == nil {
+		opt = &DiffOption{}
+	}
+	d := &SchemaDiff{
+		Changes: []*Change{},
+		From:    a,
+		To:      b,
+	}
+	changes, renamed := diffTables(a.Tables, b.Tables, opt)
+	d.Changes = append(d.Changes, changes...)
+	d.Changes = append(d.Changes, diffRelations(a.Relations, b.Relations, renamed)...)
+	d.Changes = append(d.Changes, diffFunctions(a.Functions, b.Functions)...)
+	d.Changes = append(d.Changes, diffEnums(a.Enums, b.Enums)...)
+	d.Classify()
+	return d
+}
+
+// diffTables returns changes of tables and renamed names (old table name or "table.column" -> new name).
+func diffTables(as, bs []*Table, opt *DiffOption) ([]*Change, map[string]string) {
+	changes := []*Change{}
+	renamed := map[string]string{}
+	am := map[string]*Table{}
+	bm := map[string]*Table{}
+	for _, t := range as {
+		am[t.Name] = t
+	}
+	for _, t := range bs {
+		bm[t.Name] = t
+	}
+	removed, added := onlyKeys(am, bm)
+	pairs := matchRenames(removed, added, opt.tableRenames(), opt.DetectRenames, opt.RenameThreshold, func(from, to string) float64 {
+		return tableSimilarity(am[from], bm[to])
+	})
+	to2from := map[string]string{}
+	for from, to := range pairs {
+		to2from[to] = from
+		renamed[from] = to
+	}
+	for _, name := range unionKeys(am, bm) {
+		if _, ok := pairs[name]; ok {
+			continue
+		}
+		if from, ok := to2from[name]; ok {
+			fields, sub, cr := diffTable(from, name, am[from], bm[name], opt)
+			changes = append(changes, &Change{Type: DiffRenamed, ObjectType: ObjectTypeTable, Table: name, Name: name, From: from, Fields: fields})
+			changes = append(changes, sub...)
+			for k, v := range cr {
+				renamed[k] = v
+			}
+			continue
+		}
+		ta, inA := am[name]
+		tb, inB := bm[name]
+		switch {
+		case !inB:
+			tj := ta.ToJSONObject()
+			changes = append(changes, &Change{Type: DiffRemoved, ObjectType: ObjectTypeTable, Table: name, Name: name, Before: &tj})
+		case !inA:
+			tj := tb.ToJSONObject()
+			changes = append(changes, &Change{Type: DiffAdded, ObjectType: ObjectTypeTable, Table: name, Name: name, After: &tj})
+		default:
+			fields, sub, cr := diffTable(name, name, ta, tb, opt)
+			if len(fields) > 0 {
+				changes = append(changes, &Change{Type: DiffModified, ObjectType: ObjectTypeTable, Table: name, Name: name, Fields: fields})
+			}
+			changes = append(changes, sub...)
+			for k, v := range cr {
+				renamed[k] = v
+			}
+		}
+	}
+	return changes, renamed
+}
+
+// diffTable returns modified fields of the table, changes of the table's objects and renamed columns.
+func diffTable(from, name string, ta, tb *Table, opt *DiffOption) ([]*FieldChange, []*Change, map[string]string) {
+	fields := []*FieldChange{}
+	fields = appendFieldChange(fields, "type", ta.Type, tb.Type)
+	fields = appendFieldChange(fields, "comment", ta.Comment, tb.Comment)
+	fields = appendFieldChange(fields, "logical_name", ta.LogicalName, tb.LogicalName)
+	fields = appendFieldChange(fields, "def", ta.Def, tb.Def)
+	fields = appendFieldChange(fields, "labels", labelNames(ta.Labels), labelNames(tb.Labels))
+	changes, renamed := diffColumns(from, name, ta, tb, opt)
+	changes = append(changes, diffIndexes(name, ta.Indexes, tb.Indexes)...)
+	changes = append(changes, diffConstraints(name, ta.Constraints, tb.Constraints)...)
+	changes = append(changes, diffTriggers(name, ta.Triggers, tb.Triggers)...)
+	return fields, changes, renamed
+}
+
+func diffColumns(from, table string, ta, tb *Table, opt *DiffOption) ([]*Change, map[string]string) {
+	as := ta.Columns
+	bs := tb.Columns
+	changes := []*Change{}
+	renamed := map[string]string{}
+	am := map[string]*Column{}
+	bm := map[string]*Column{}
+	for _, c := range as {
+		am[c.Name] = c
+	}
+	for _, c := range bs {
+		bm[c.Name] = c
+	}
+	removed, added := onlyKeys(am, bm)
+	pairs := matchRenames(removed, added, opt.columnRenames(from, table), opt.DetectRenames, opt.RenameThreshold, func(f, t string) float64 {
+		return columnSimilarity(ta, tb, am[f], bm[t])
+	})
+	to2from := map[string]string{}
+	for f, t := range pairs {
+		to2from[t] = f
+		renamed[fmt.Sprintf("%s.%s", from, f)] = t
+	}
+	for _, name := range unionKeys(am, bm) {
+		if _, ok := pairs[name]; ok {
+			continue
+		}
+		if f, ok := to2from[name]; ok {
+			ja := am[f].ToJSONObject()
+			jb := bm[name].ToJSONObject()
+			changes = append(changes, &Change{Type: DiffRenamed, ObjectType: ObjectTypeColumn, Table: table, Name: name, From: f, Before: &ja, After: &jb, Fields: columnFieldChanges(&ja, &jb)})
+			continue
+		}
+		ca, inA := am[name]
+		cb, inB := bm[name]
+		switch {
+		case !inB:
+			cj := ca.ToJSONObject()
+			changes = append(changes, &Change{Type: DiffRemoved, ObjectType: ObjectTypeColumn, Table: table, Name: name, Before: &cj})
+		case !inA:
+			cj := cb.ToJSONObject()
+			changes = append(changes, &Change{Type: DiffAdded, ObjectType: ObjectTypeColumn, Table: table, Name: name, After: &cj})
+		default:
+			ja := ca.ToJSONObject()
+			jb := cb.ToJSONObject()
+			if fields := columnFieldChanges(&ja, &jb); len(fields) > 0 {
+				changes = append(changes, &Change{Type: DiffModified, ObjectType: ObjectTypeColumn, Table: table, Name: name, Before: &ja, After: &jb, Fields: fields})
+			}
+		}
+	}
+	return changes, renamed
+}
+
+func columnFieldChanges(ja, jb *ColumnJSON) []*FieldChange {
+	fields := []*FieldChange{}
+	fields = appendFieldChange(fields, "type", ja.Type, jb.Type)
+	fields = appendFieldChange(fields, "nullable", ja.Nullable, jb.Nullable)
+	fields = appendFieldChange(fields, "default", ja.Default, jb.Default)
+	fields = appendFieldChange(fields, "extra_def", ja.ExtraDef, jb.ExtraDef)
+	fields = appendFieldChange(fields, "comment", ja.Comment, jb.Comment)
+	fields = appendFieldChange(fields, "logical_name", ja.LogicalName, jb.LogicalName)
+	fields = appendFieldChange(fields, "labels", labelNames(ja.Labels), labelNames(jb.Labels))
+	return fields
+}
+
+func diffIndexes(table string, as, bs []*Index) []*Change {
+	changes := []*Change{}
+	am := map[string]*Index{}
+	bm := map[string]*Index{}
+	for _, i := range as {
+		am[i.Name] = i
+	}
+	for _, i := range bs {
+		bm[i.Name] = i
+	}
+	for _, name := range unionKeys(am, bm) {
+		ia, inA := am[name]
+		ib, inB := bm[name]
+		switch {
+		case !inB:
+			changes = append(changes, &Change{Type: DiffRemoved, ObjectType: ObjectTypeIndex, Table: table, Name: name, Before: ia})
+		case !inA:
+			changes = append(changes, &Change{Type: DiffAdded, ObjectType: ObjectTypeIndex, Table: table, Name: name, After: ib})
+		default:
+			fields := []*FieldChange{}
+			fields = appendFieldChange(fields, "def", ia.Def, ib.Def)
+			fields = appendFieldChange(fields, "columns", ia.Columns, ib.Columns)
+			fields = appendFieldChange(fields, "comment", ia.Comment, ib.Comment)
+			if len(fields) > 0 {
+				changes = append(changes, &Change{Type: DiffModified, ObjectType: ObjectTypeIndex, Table: table, Name: name, Before: ia, After: ib, Fields: fields})
+			}
+		}
+	}
+	return changes
+}
+
+func diffConstraints(table string, as, bs []*Constraint) []*Change {
+	changes := []*Change{}
+	am := map[string]*Constraint{}
+	bm := map[string]*Constraint{}
+	for _, c := range as {
+		am[c.Name] = c
+	}
+	for _, c := range bs {
+		bm[c.Name] = c
+	}
+	for _, name := range unionKeys(am, bm) {
+		ca, inA := am[name]
+		cb, inB := bm[name]
+		switch {
+		case !inB:
+			changes = append(changes, &Change{Type: DiffRemoved, ObjectType: ObjectTypeConstraint, Table: table, Name: name, Before: ca})
+		case !inA:
+			changes = append(changes, &Change{Type: DiffAdded, ObjectType: ObjectTypeConstraint, Table: table, Name: name, After: cb})
+		default:
+			fields := []*FieldChange{}
+			fields = appendFieldChange(fields, "type", ca.Type, cb.Type)
+			fields = appendFieldChange(fields, "def", ca.Def, cb.Def)
+			fields = appendFieldChange(fields, "columns", ca.Columns, cb.Columns)
+			fields = appendFieldChange(fields, "referenced_table", ca.ReferencedTable, cb.ReferencedTable)
+			fields = appendFieldChange(fields, "referenced_columns", ca.ReferencedColumns, cb.ReferencedColumns)
+			fields = appendFieldChange(fields, "comment", ca.Comment, cb.Comment)
+			if len(fields) > 0 {
+				changes = append(changes, &Change{Type: DiffModified, ObjectType: ObjectTypeConstraint, Table: table, Name: name, Before: ca, After: cb, Fields: fields})
+			}
+		}
+	}
+	return changes
+}
+
+func diffTriggers(table string, as, bs []*Trigger) []*Change {
+	changes := []*Change{}
+	am := map[string]*Trigger{}
+	bm := map[string]*Trigger{}
+	for _, t := range as {
+		am[t.Name] = t
+	}
+	for _, t := range bs {
+		bm[t.Name] = t
+	}
+	for _, name := range unionKeys(am, bm) {
+		ta, inA := am[name]
+		tb, inB := bm[name]
+		switch {
+		case !inB:
+			changes = append(changes, &Change{Type: DiffRemoved, ObjectType: ObjectTypeTrigger, Table: table, Name: name, Before: ta})
+		case !inA:
+			changes = append(changes, &Change{Type: DiffAdded, ObjectType: ObjectTypeTrigger, Table: table, Name: name, After: tb})
+		default:
+			fields := []*FieldChange{}
+			fields = appendFieldChange(fields, "def", ta.Def, tb.Def)
+			fields = appendFieldChange(fields, "comment", ta.Comment, tb.Comment)
+			if len(fields) > 0 {
+				changes = append(changes, &Change{Type: DiffModified, ObjectType: ObjectTypeTrigger, Table: table, Name: name, Before: ta, After: tb, Fields: fields})
+			}
+		}
+	}
+	return changes
+}
+
+// diffRelations compares relations. Relations of schema a are keyed by renamed names so that renames do not show up as removed and added relations.
+func diffRelations(as, bs []*Relation, renamed map[string]string) []*Change {
+	changes := []*Change{}
+	am := map[string]*Relation{}
+	bm := map[string]*Relation{}
+	for _, r := range as {
+		am[renamedRelationKey(r, renamed)] = r
+	}
+	for _, r := range bs {
+		bm[relationKey(r)] = r
+	}
+	for _, key := range unionKeys(am, bm) {
+		ra, inA := am[key]
+		rb, inB := bm[key]
+		switch {
+		case !inB:
+			rj := ra.ToJSONObject()
+			changes = append(changes, &Change{Type: DiffRemoved, ObjectType: ObjectTypeRelation, Table: ra.Table.Name, Name: relationKey(ra), Before: &rj})
+		case !inA:
+			rj := rb.ToJSONObject()
+			changes = append(changes, &Change{Type: DiffAdded, ObjectType: ObjectTypeRelation, Table: rb.Table.Name, Name: key, After: &rj})
+		default:
+			ja := ra.ToJSONObject()
+			jb := rb.ToJSONObject()
+			fields := []*FieldChange{}
+			fields = appendFieldChange(fields, "cardinality", ja.Cardinality, jb.Cardinality)
+			fields = appendFieldChange(fields, "parent_cardinality", ja.ParentCardinality, jb.ParentCardinality)
+			fields = appendFieldChange(fields, "def", ja.Def, jb.Def)
+			fields = appendFieldChange(fields, "virtual", ja.Virtual, jb.Virtual)
+			if len(fields) > 0 {
+				changes = append(changes, &Change{Type: DiffModified, ObjectType: ObjectTypeRelation, Table: rb.Table.Name, Name: key, Before: &ja, After: &jb, Fields: fields})
+			}
+		}
+	}
+	return changes
+}
+
+func diffFunctions(as, bs []*Function) []*Change {
+	changes := []*Change{}
+	am := map[string]*Function{}
+	bm := map[string]*Function{}
+	for _, f := range as {
+		am[functionKey(f)] = f
+	}
+	for _, f := range bs {
+		bm[functionKey(f)] = f
+	}
+	for _, key := range unionKeys(am, bm) {
+		fa, inA := am[key]
+		fb, inB := bm[key]
+		switch {
+		case !inB:
+			changes = append(changes, &Change{Type: DiffRemoved, ObjectType: ObjectTypeFunction, Name: key, Before: fa})
+		case !inA:
+			changes = append(changes, &Change{Type: DiffAdded, ObjectType: ObjectTypeFunction, Name: key, After: fb})
+		default:
+			fields := []*FieldChange{}
+			fields = appendFieldChange(fields, "return_type", fa.ReturnType, fb.ReturnType)
+			fields = appendFieldChange(fields, "type", fa.Type, fb.Type)
+			if len(fields) > 0 {
+				changes = append(changes, &Change{Type: DiffModified, ObjectType: ObjectTypeFunction, Name: key, Before: fa, After: fb, Fields: fields})
+			}
+		}
+	}
+	return changes
+}
+
+func diffEnums(as, bs []*Enum) []*Change {
+	changes := []*Change{}
+	am := map[string]*Enum{}
+	bm := map[string]*Enum{}
+	for _, e := range as {
+		am[e.Name] = e
+	}
+	for _, e := range bs {
+		bm[e.Name] = e
+	}
+	for _, name := range unionKeys(am, bm) {
+		ea, inA := am[name]
+		eb, inB := bm[name]
+		switch {
+		case !inB:
+			changes = append(changes, &Change{Type: DiffRemoved, ObjectType: ObjectTypeEnum, Name: name, Before: ea})
+		case !inA:
+			changes = append(changes, &Change{Type: DiffAdded, ObjectType: ObjectTypeEnum, Name: name, After: eb})
+		default:
+			va := sortedCopy(ea.Values)
+			vb := sortedCopy(eb.Values)
+			fields := appendFieldChange([]*FieldChange{}, "values", va, vb)
+			if len(fields) > 0 {
+				changes = append(changes, &Change{Type: DiffModified, ObjectType: ObjectTypeEnum, Name: name, Before: ea, After: eb, Fields: fields})
+			}
+		}
+	}
+	return changes
+}
+
+func appendFieldChange(fields []*FieldChange, field string, before, after any) []*FieldChange {
+	if reflect.DeepEqual(before, after) {
+		return fields
+	}
+	// nil and empty slices are the same value in the JSON schema
+	if sa, ok := before.([]string); ok {
+		if sb, ok := after.([]string); ok && len(sa) == 0 && len(sb) == 0 {
+			return fields
+		}
+	}
+	return append(fields, &FieldChange{
+		Field:  field,
+		Before: before,
+		After:  after,
+	})
+}
+
+func unionKeys[T any](a, b map[string]T) []string {
+	keys := []string{}
+	for k := range a {
+		keys = append(keys, k)
+	}
+	for k := range b {
+		if _, ok := a[k]; !ok {
+			keys = append(keys, k)
+		}
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// onlyKeys returns sorted keys only in a and sorted keys only in b.
+func onlyKeys[T any](a, b map[string]T) ([]string, []string) {
+	removed := []string{}
+	added := []string{}
+	for _, k := range unionKeys(a, b) {
+		_, inA := a[k]
+		_, inB := b[k]
+		switch {
+		case !inB:
+			removed = append(removed, k)
+		case !inA:
+			added = append(added, k)
+		}
+	}
+	return removed, added
+}
+
+func relationKey(r *Relation) string {
+	return renamedRelationKey(r, nil)
+}
+
+func renamedRelationKey(r *Relation, renamed map[string]string) string {
+	names := func(t *Table, columns []*Column) (string, []string) {
+		var cs []string
+		for _, c := range columns {
+			n := c.Name
+			if to, ok := renamed[fmt.Sprintf("%s.%s", t.Name, c.Name)]; ok {
+				n = to
+			}
+			cs = append(cs, n)
+		}
+		tn := t.Name
+		if to, ok := renamed[tn]; ok {
+			tn = to
+		}
+		return tn, cs
+	}
+	t, cs := names(r.Table, r.Columns)
+	pt, pcs := names(r.ParentTable, r.ParentColumns)
+	return fmt.Sprintf("%s(%s) -> %s(%s)", t, strings.Join(cs, ", "), pt, strings.Join(pcs, ", "))
+}
+
+func functionKey(f *Function) string {
+	return fmt.Sprintf("%s(%s)", f.Name, f.Arguments)
+}
+
+func labelNames(labels Labels) []string {
+	names := []string{}
+	for _, l := range labels {
+		names = append(names, l.Name)
+	}
+	return names
+}
+
+func sortedCopy(in []string) []string {
+	out := make([]string, len(in))
+	copy(out, in)
+	sort.Strings(out)
+	return out
+}
@@ -0,0 +1,227 @@
+package schema
+
+import (
+	"sort"
+	"strings"
+)
+
+// DefaultRenameThreshold is the default similarity threshold for rename detection.
+const DefaultRenameThreshold = 0.8
+
+// DiffOption is the option for DiffWithOption.
+type DiffOption struct {
+	// DetectRenames enables heuristic rename detection of tables and columns.
+	DetectRenames bool
+	// RenameThreshold is the minimum similarity (0.0 - 1.0) to detect a rename.
+	RenameThreshold float64
+	// Renames is the list of known renames. They take precedence over heuristic detection.
+	Renames []*Rename
+}
+
+// Rename is a known rename of a table or column.
+type Rename struct {
+	// Table is the table name of the renamed column (old or new name). Empty for a table rename.
+	Table string `json:"table,omitempty"`
+	From  string `json:"from"`
+	To    string `json:"to"`
+}
+
+// DefaultDiffOption returns the default DiffOption.
+func DefaultDiffOption() *DiffOption {
+	return &DiffOption{
+		DetectRenames:   true,
+		RenameThreshold: DefaultRenameThreshold,
+	}
+}
+
+func (opt *DiffOption) tableRenames() map[string]string {
+	renames := map[string]string{}
+	for _, r := range opt.Renames {
+		if r.Table == "" {
+			renames[r.From] = r.To
+		}
+	}
+	return renames
+}
+
+func (opt *DiffOption) columnRenames(tableFrom, tableTo string) map[string]string {
+	renames := map[string]string{}
+	for _, r := range opt.Renames {
+		if r.Table != "" && (r.Table == tableFrom || r.Table == tableTo) {
+			renames[r.From] = r.To
+		}
+	}
+	return renames
+}
+
+// matchRenames pairs removed names with added names. It returns the map of old name to new name.
+func matchRenames(removed, added []string, known map[string]string, detect bool, threshold float64, score func(from, to string) float64) map[string]string {
+	pairs := map[string]string{}
+	used := map[string]struct{}{}
+	for _, from := range removed {
+		to, ok := known[from]
+		if !ok || !contains(added, to) {
+			continue
+		}
+		if _, ok := used[to]; ok {
+			continue
+		}
+		pairs[from] = to
+		used[to] = struct{}{}
+	}
+	if !detect {
+		return pairs
+	}
+	type candidate struct {
+		from  string
+		to    string
+		score float64
+	}
+	candidates := []candidate{}
+	for _, from := range removed {
+		if _, ok := pairs[from]; ok {
+			continue
+		}
+		for _, to := range added {
+			if _, ok := used[to]; ok {
+				continue
+			}
+			// objects that are not similar at all ( e.g. of different types ) are never paired
+			s := score(from, to)
+			if s > 0 && s >= threshold {
+				candidates = append(candidates, candidate{from: from, to: to, score: s})
+			}
+		}
+	}
+	sort.SliceStable(candidates, func(i, j int) bool {
+		if candidates[i].score != candidates[j].score {
+			return candidates[i].score > candidates[j].score
+		}
+		if candidates[i].from != candidates[j].from {
+			return candidates[i].from < candidates[j].from
+		}
+		return candidates[i].to < candidates[j].to
+	})
+	for _, c := range candidates {
+		if _, ok := pairs[c.from]; ok {
+			continue
+		}
+		if _, ok := used[c.to]; ok {
+			continue
+		}
+		pairs[c.from] = c.to
+		used[c.to] = struct{}{}
+	}
+	return pairs
+}
+
+// tableSimilarity returns the similarity of two tables. Tables of different types are not similar.
+func tableSimilarity(a, b *Table) float64 {
+	if a.Type != b.Type {
+		return 0
+	}
+	as := map[string]struct{}{}
+	for _, c := range a.Columns {
+		as[c.Name+" "+c.Type] = struct{}{}
+	}
+	union := len(as)
+	common := 0
+	for _, c := range b.Columns {
+		if _, ok := as[c.Name+" "+c.Type]; ok {
+			common++
+		} else {
+			union++
+		}
+	}
+	columns := 1.0
+	if union > 0 {
+		columns = float64(common) / float64(union)
+	}
+	s := 0.6*columns + 0.2*nameSimilarity(a.Name, b.Name)
+	if sameComment(a.Comment, b.Comment) {
+		s += 0.2
+	}
+	return s
+}
+
+// columnSimilarity returns the similarity of two columns by type, position, comment, constraints and name.
+// Columns of different types are not similar.
+func columnSimilarity(ta, tb *Table, a, b *Column) float64 {
+	if a.Type != b.Type {
+		return 0
+	}
+	// name similarity weighs enough to detect a rename of a column without comment ( e.g. user_name -> username )
+	s := 0.2
+	if columnPosition(ta, a) == columnPosition(tb, b) {
+		s += 0.15
+	}
+	if sameComment(a.Comment, b.Comment) {
+		s += 0.15
+	}
+	if a.Nullable == b.Nullable && a.Default == b.Default && a.ExtraDef == b.ExtraDef &&
+		strings.Join(columnConstraintTypes(ta, a), ",") == strings.Join(columnConstraintTypes(tb, b), ",") {
+		s += 0.15
+	}
+	s += 0.35 * nameSimilarity(a.Name, b.Name)
+	return s
+}
+
+// sameComment reports whether two comments are the same. Empty comments are not evidence of a rename.
+func sameComment(a, b string) bool {
+	return a != "" && a == b
+}
+
+func columnPosition(t *Table, c *Column) int {
+	for i, cc := range t.Columns {
+		if cc == c {
+			return i
+		}
+	}
+	return -1
+}
+
+// columnConstraintTypes returns sorted types of constraints the column belongs to.
+func columnConstraintTypes(t *Table, c *Column) []string {
+	types := []string{}
+	for _, cs := range t.Constraints {
+		if contains(cs.Columns, c.Name) {
+			types = append(types, cs.Type)
+		}
+	}
+	sort.Strings(types)
+	return types
+}
+
+// nameSimilarity returns 1 - normalized Levenshtein distance.
+func nameSimilarity(a, b string) float64 {
+	ra := []rune(strings.ToLower(a))
+	rb := []rune(strings.ToLower(b))
+	l := len(ra)
+	if len(rb) > l {
+		l = len(rb)
+	}
+	if l == 0 {
+		return 1
+	}
+	return 1 - float64(levenshtein(ra, rb))/float64(l)
+}
+
+func levenshtein(a, b []rune) int {
+	prev := make([]int, len(b)+1)
+	curr := make([]int, len(b)+1)
+	for j := range prev {
+		prev[j] = j
+	}
+	for i := 1; i <= len(a); i++ {
+		curr[0] = i
+		for j := 1; j <= len(b); j++ {
+			cost := 1
+			if a[i-1] == b[j-1] {
+				cost = 0
+			}
+			curr[j] = min(prev[j]+1, curr[j-1]+1, prev[j-1]+cost)
+		}
+		prev, curr = curr, prev
+	}
+	return prev[len(b)]
+}
@@ -0,0 +1,278 @@
+package schema
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// Severity is the backward compatibility level of a change.
+type Severity string
+
+const (
+	SeveritySafe     Severity = "safe"
+	SeverityRisky    Severity = "risky"
+	SeverityBreaking Severity = "breaking"
+)
+
+var severityLevels = map[Severity]int{
+	SeveritySafe:     0,
+	SeverityRisky:    1,
+	SeverityBreaking: 2,
+}
+
+// ToSeverity convert string to Severity.
+func ToSeverity(s string) (Severity, error) {
+	sv := Severity(strings.ToLower(s))
+	if _, ok := severityLevels[sv]; !ok {
+		return SeveritySafe, fmt.Errorf("unsupported severity '%s'", s)
+	}
+	return sv, nil
+}
+
+// Level returns the numeric level of severity. The higher, the more severe.
+func (sv Severity) Level() int {
+	return severityLevels[sv]
+}
+
+// AtLeast returns whether sv is equal to or more severe than other.
+func (sv Severity) AtLeast(other Severity) bool {
+	return sv.Level() >= other.Level()
+}
+
+// MaxSeverity returns the most severe level in the diff.
+func (d *SchemaDiff) MaxSeverity() Severity {
+	max := SeveritySafe
+	if d == nil {
+		return max
+	}
+	for _, c := range d.Changes {
+		if c.Severity.Level() > max.Level() {
+			max = c.Severity
+		}
+	}
+	return max
+}
+
+// Classify set Severity of all changes.
+func (d *SchemaDiff) Classify() {
+	for _, c := range d.Changes {
+		c.Severity = classifyChange(c)
+	}
+}
+
+func classifyChange(c *Change) Severity {
+	switch c.ObjectType {
+	case ObjectTypeTable:
+		switch c.Type {
+		case DiffAdded:
+			return SeveritySafe
+		case DiffRemoved, DiffRenamed:
+			return SeverityBreaking
+		}
+		return maxFieldSeverity(c, map[string]Severity{
+			"type": SeverityRisky,
+			"def":  SeverityRisky,
+		})
+	case ObjectTypeColumn:
+		switch c.Type {
+		case DiffAdded:
+			cj, ok := c.After.(*ColumnJSON)
+			if ok && !cj.Nullable && cj.Default == nil && cj.ExtraDef == "" {
+				// existing INSERT statements without the column will fail
+				return SeverityBreaking
+			}
+			return SeveritySafe
+		case DiffRemoved, DiffRenamed:
+			// queries referring to the old name will fail
+			return SeverityBreaking
+		}
+		sv := maxFieldSeverity(c, map[string]Severity{
+			"default":   SeverityRisky,
+			"extra_def": SeverityRisky,
+		})
+		if f, ok := c.Field("nullable"); ok {
+			if before, _ := f.Before.(bool); before {
+				sv = SeverityBreaking
+			}
+		}
+		if f, ok := c.Field("type"); ok {
+			before, _ := f.Before.(string)
+			after, _ := f.After.(string)
+			sv = maxSeverity(sv, classifyTypeChange(before, after))
+		}
+		return sv
+	case ObjectTypeIndex:
+		switch c.Type {
+		case DiffAdded:
+			if i, ok := c.After.(*Index); ok && strings.Contains(strings.ToUpper(i.Def), "UNIQUE") {
+				return SeverityRisky
+			}
+			return SeveritySafe
+		}
+		if c.Type == DiffModified && onlyFieldsChanged(c, "comment") {
+			return SeveritySafe
+		}
+		return SeverityRisky
+	case ObjectTypeConstraint, ObjectTypeTrigger:
+		if c.Type == DiffModified && onlyFieldsChanged(c, "comment") {
+			return SeveritySafe
+		}
+		return SeverityRisky
+	case ObjectTypeRelation:
+		if rj, ok := c.After.(*RelationJSON); ok && rj.Virtual {
+			return SeveritySafe
+		}
+		if rj, ok := c.Before.(*RelationJSON); ok && rj.Virtual && c.Type != DiffModified {
+			return SeveritySafe
+		}
+		return SeverityRisky
+	case ObjectTypeFunction:
+		if c.Type == DiffAdded {
+			return SeveritySafe
+		}
+		return SeverityBreaking
+	case ObjectTypeEnum:
+		switch c.Type {
+		case DiffAdded:
+			return SeveritySafe
+		case DiffRemoved:
+			return SeverityBreaking
+		}
+		if f, ok := c.Field("values"); ok {
+			before, _ := f.Before.([]string)
+			after, _ := f.After.([]string)
+			for _, v := range before {
+				if !contains(after, v) {
+					return SeverityBreaking
+				}
+			}
+		}
+		return SeveritySafe
+	}
+	return SeverityRisky
+}
+
+// maxFieldSeverity returns the most severe level of modified fields. Fields not in severities are safe.
+func maxFieldSeverity(c *Change, severities map[string]Severity) Severity {
+	sv := SeveritySafe
+	for _, f := range c.Fields {
+		if fs, ok := severities[f.Field]; ok {
+			sv = maxSeverity(sv, fs)
+		}
+	}
+	return sv
+}
+
+func maxSeverity(a, b Severity) Severity {
+	if b.Level() > a.Level() {
+		return b
+	}
+	return a
+}
+
+func onlyFieldsChanged(c *Change, fields ...string) bool {
+	for _, f := range c.Fields {
+		if !contains(fields, f.Field) {
+			return false
+		}
+	}
+	return true
+}
+
+func contains(s []string, e string) bool {
+	for _, v := range s {
+		if v == e {
+			return true
+		}
+	}
+	return false
+}
+
+var typeRe = regexp.MustCompile(`^\s*([^(]+)\(([^)]*)\)\s*(.*)$`)
+
+// integerTypeRanks is the order of integer types from narrow to wide.
+var integerTypeRanks = map[string]int{
+	"tinyint":   1,
+	"smallint":  2,
+	"int2":      2,
+	"mediumint": 3,
+	"int":       4,
+	"integer":   4,
+	"int4":      4,
+	"serial":    4,
+	"bigint":    5,
+	"int8":      5,
+	"bigserial": 5,
+}
+
+// wideningTypes is the set of type changes that do not lose data.
+var wideningTypes = map[string][]string{
+	"char":              {"varchar", "character varying", "text", "nvarchar"},
+	"character":         {"varchar", "character varying", "text"},
+	"varchar":           {"text", "nvarchar", "mediumtext", "longtext"},
+	"character varying": {"text"},
+	"nchar":             {"nvarchar"},
+	"tinytext":          {"text", "mediumtext", "longtext"},
+	"text":              {"mediumtext", "longtext"},
+	"mediumtext":        {"longtext"},
+	"float":             {"double", "double precision", "real"},
+	"real":              {"double precision", "float8"},
+	"float4":            {"float8", "double precision"},
+	"date":              {"datetime", "timestamp", "timestamp without time zone"},
+}
+
+// classifyTypeChange classifies column type change. ex) varchar(255) -> varchar(50) is risky.
+func classifyTypeChange(before, after string) Severity {
+	bBase, bParams, bSuffix := parseColumnType(before)
+	aBase, aParams, aSuffix := parseColumnType(after)
+	if bBase == aBase {
+		if bSuffix != aSuffix {
+			// ex) int -> int unsigned
+			return SeverityRisky
+		}
+		for i := range bParams {
+			if i >= len(aParams) {
+				return SeverityRisky
+			}
+			if aParams[i] < bParams[i] {
+				return SeverityRisky
+			}
+		}
+		return SeveritySafe
+	}
+	br, bInt := integerTypeRanks[bBase]
+	ar, aInt := integerTypeRanks[aBase]
+	if bInt && aInt {
+		if ar < br {
+			return SeverityRisky
+		}
+		return SeveritySafe
+	}
+	if contains(wideningTypes[bBase], aBase) {
+		return SeveritySafe
+	}
+	if contains(wideningTypes[aBase], bBase) {
+		return SeverityRisky
+	}
+	return SeverityBreaking
+}
+
+func parseColumnType(t string) (string, []int, string) {
+	m := typeRe.FindStringSubmatch(strings.ToLower(t))
+	if m == nil {
+		return strings.TrimSpace(strings.ToLower(t)), nil, ""
+	}
+	params := []int{}
+	if m[2] != "" {
+		for _, p := range strings.Split(m[2], ",") {
+			v, err := strconv.Atoi(strings.TrimSpace(p))
+			if err != nil {
+				continue
+			}
+			params = append(params, v)
+		}
+	}
+	return strings.TrimSpace(m[1]), params, strings.TrimSpace(m[3])
+}
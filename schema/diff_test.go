@@ -0,0 +1,308 @@
+package schema
+
+import (
+	"database/sql"
+	"fmt"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestDiff(t *testing.T) {
+	a := newTestSchema(t)
+	b := newTestSchema(t)
+
+	ta, err := b.FindTableByName("a")
+	if err != nil {
+		t.Fatal(err)
+	}
+	ta.Comment = "table a (modified)"
+	ta.Columns = append(ta.Columns, &Column{
+		Name:     "a3",
+		Type:     "TEXT",
+		Nullable: true,
+	})
+	a2, err := ta.FindColumnByName("a2")
+	if err != nil {
+		t.Fatal(err)
+	}
+	a2.Type = "VARCHAR(10)"
+	a2.Default = sql.NullString{String: "x", Valid: true}
+	b.Tables = append(b.Tables, &Table{
+		Name: "c",
+		Type: "BASE TABLE",
+	})
+
+	d := Diff(a, b)
+	if !d.HasChanges() {
+		t.Fatal("want changes")
+	}
+
+	tests := []struct {
+		typ        DiffType
+		objectType ObjectType
+		path       string
+		fields     []string
+	}{
+		{DiffModified, ObjectTypeTable, "a", []string{"comment"}},
+		{DiffModified, ObjectTypeColumn, "a.a2", []string{"type", "default"}},
+		{DiffAdded, ObjectTypeColumn, "a.a3", nil},
+		{DiffAdded, ObjectTypeTable, "c", nil},
+	}
+	if len(d.Changes) != len(tests) {
+		t.Fatalf("got %d changes\nwant %d", len(d.Changes), len(tests))
+	}
+	for i, tt := range tests {
+		got := d.Changes[i]
+		if got.Type != tt.typ || got.ObjectType != tt.objectType || got.Path() != tt.path {
+			t.Errorf("got %s %s %s\nwant %s %s %s", got.Type, got.ObjectType, got.Path(), tt.typ, tt.objectType, tt.path)
+		}
+		for _, f := range tt.fields {
+			if _, ok := got.Field(f); !ok {
+				t.Errorf("%s: want field change '%s'", got.Path(), f)
+			}
+		}
+	}
+}
+
+func TestDiffNoChanges(t *testing.T) {
+	a := newTestSchema(t)
+	b, err := a.Clone()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if d := Diff(a, b); d.HasChanges() {
+		t.Errorf("got %d changes\nwant 0", len(d.Changes))
+	}
+}
+
+func TestClassifyChange(t *testing.T) {
+	def := "0"
+	tests := []struct {
+		name string
+		c    *Change
+		want Severity
+	}{
+		{
+			"drop column",
+			&Change{Type: DiffRemoved, ObjectType: ObjectTypeColumn},
+			SeverityBreaking,
+		},
+		{
+			"add nullable column",
+			&Change{Type: DiffAdded, ObjectType: ObjectTypeColumn, After: &ColumnJSON{Nullable: true}},
+			SeveritySafe,
+		},
+		{
+			"add not null column without default",
+			&Change{Type: DiffAdded, ObjectType: ObjectTypeColumn, After: &ColumnJSON{Nullable: false}},
+			SeverityBreaking,
+		},
+		{
+			"add not null column with default",
+			&Change{Type: DiffAdded, ObjectType: ObjectTypeColumn, After: &ColumnJSON{Nullable: false, Default: &def}},
+			SeveritySafe,
+		},
+		{
+			"nullable to not null",
+			&Change{Type: DiffModified, ObjectType: ObjectTypeColumn, Fields: []*FieldChange{{Field: "nullable", Before: true, After: false}}},
+			SeverityBreaking,
+		},
+		{
+			"not null to nullable",
+			&Change{Type: DiffModified, ObjectType: ObjectTypeColumn, Fields: []*FieldChange{{Field: "nullable", Before: false, After: true}}},
+			SeveritySafe,
+		},
+		{
+			"narrow varchar",
+			&Change{Type: DiffModified, ObjectType: ObjectTypeColumn, Fields: []*FieldChange{{Field: "type", Before: "varchar(255)", After: "varchar(50)"}}},
+			SeverityRisky,
+		},
+		{
+			"widen varchar",
+			&Change{Type: DiffModified, ObjectType: ObjectTypeColumn, Fields: []*FieldChange{{Field: "type", Before: "varchar(50)", After: "varchar(255)"}}},
+			SeveritySafe,
+		},
+		{
+			"varchar to text",
+			&Change{Type: DiffModified, ObjectType: ObjectTypeColumn, Fields: []*FieldChange{{Field: "type", Before: "varchar(255)", After: "text"}}},
+			SeveritySafe,
+		},
+		{
+			"bigint to int",
+			&Change{Type: DiffModified, ObjectType: ObjectTypeColumn, Fields: []*FieldChange{{Field: "type", Before: "bigint", After: "int"}}},
+			SeverityRisky,
+		},
+		{
+			"int to text",
+			&Change{Type: DiffModified, ObjectType: ObjectTypeColumn, Fields: []*FieldChange{{Field: "type", Before: "int", After: "text"}}},
+			SeverityBreaking,
+		},
+		{
+			"column comment",
+			&Change{Type: DiffModified, ObjectType: ObjectTypeColumn, Fields: []*FieldChange{{Field: "comment", Before: "a", After: "b"}}},
+			SeveritySafe,
+		},
+		{
+			"drop table",
+			&Change{Type: DiffRemoved, ObjectType: ObjectTypeTable},
+			SeverityBreaking,
+		},
+		{
+			"add unique index",
+			&Change{Type: DiffAdded, ObjectType: ObjectTypeIndex, After: &Index{Def: "CREATE UNIQUE INDEX a_idx ON a (a1)"}},
+			SeverityRisky,
+		},
+		{
+			"remove enum value",
+			&Change{Type: DiffModified, ObjectType: ObjectTypeEnum, Fields: []*FieldChange{{Field: "values", Before: []string{"a", "b"}, After: []string{"a"}}}},
+			SeverityBreaking,
+		},
+		{
+			"add enum value",
+			&Change{Type: DiffModified, ObjectType: ObjectTypeEnum, Fields: []*FieldChange{{Field: "values", Before: []string{"a"}, After: []string{"a", "b"}}}},
+			SeveritySafe,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := classifyChange(tt.c); got != tt.want {
+				t.Errorf("got %v\nwant %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestMaxSeverity(t *testing.T) {
+	d := &SchemaDiff{
+		Changes: []*Change{
+			{Severity: SeveritySafe},
+			{Severity: SeverityRisky},
+		},
+	}
+	if got := d.MaxSeverity(); got != SeverityRisky {
+		t.Errorf("got %v\nwant %v", got, SeverityRisky)
+	}
+	if d.MaxSeverity().AtLeast(SeverityBreaking) {
+		t.Error("risky should not be at least breaking")
+	}
+}
+
+func TestDiffRename(t *testing.T) {
+	a := newTestSchema(t)
+	b := newTestSchema(t)
+
+	tb, err := b.FindTableByName("b")
+	if err != nil {
+		t.Fatal(err)
+	}
+	cb, err := tb.FindColumnByName("b")
+	if err != nil {
+		t.Fatal(err)
+	}
+	cb.Name = "bb"
+	ta, err := b.FindTableByName("a")
+	if err != nil {
+		t.Fatal(err)
+	}
+	ta.Name = "aa"
+
+	tests := []struct {
+		name string
+		opt  *DiffOption
+		want []string
+	}{
+		{
+			"heuristic",
+			DefaultDiffOption(),
+			[]string{"renamed table aa from a", "renamed column b.bb from b"},
+		},
+		{
+			"disabled",
+			&DiffOption{},
+			[]string{"removed table a", "added table aa", "removed column b.b", "added column b.bb", "removed relation a(a) -> b(b)", "added relation aa(a) -> b(bb)"},
+		},
+		{
+			"known renames",
+			&DiffOption{Renames: []*Rename{{From: "a", To: "aa"}, {Table: "b", From: "b", To: "bb"}}},
+			[]string{"renamed table aa from a", "renamed column b.bb from b"},
+		},
+		{
+			"threshold",
+			&DiffOption{DetectRenames: true, RenameThreshold: 1.0, Renames: []*Rename{{From: "a", To: "aa"}}},
+			[]string{"renamed table aa from a", "removed column b.b", "added column b.bb", "removed relation a(a) -> b(b)", "added relation aa(a) -> b(bb)"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			d := DiffWithOption(a, b, tt.opt)
+			got := []string{}
+			for _, c := range d.Changes {
+				s := fmt.Sprintf("%s %s %s", c.Type, c.ObjectType, c.Path())
+				if c.Type == DiffRenamed {
+					s = fmt.Sprintf("%s from %s", s, c.From)
+				}
+				got = append(got, s)
+			}
+			if diff := cmp.Diff(got, tt.want); diff != "" {
+				t.Error(diff)
+			}
+		})
+	}
+}
+
+func TestDiffRenameWithoutComments(t *testing.T) {
+	a := newTestSchema(t)
+	b := newTestSchema(t)
+	for _, s := range []*Schema{a, b} {
+		tb, err := s.FindTableByName("b")
+		if err != nil {
+			t.Fatal(err)
+		}
+		tb.Columns[1].Comment = ""
+	}
+	tb, err := b.FindTableByName("b")
+	if err != nil {
+		t.Fatal(err)
+	}
+	// columns without comments are not renamed just because their types and positions are the same
+	tb.Columns[1].Name = "b3"
+
+	d := DiffWithOption(a, b, DefaultDiffOption())
+	got := []string{}
+	for _, c := range d.Changes {
+		got = append(got, fmt.Sprintf("%s %s %s", c.Type, c.ObjectType, c.Path()))
+	}
+	want := []string{"removed column b.b2", "added column b.b3"}
+	if diff := cmp.Diff(got, want); diff != "" {
+		t.Error(diff)
+	}
+}
+
+func TestDiffRenameBySimilarName(t *testing.T) {
+	a := newTestSchema(t)
+	b := newTestSchema(t)
+	for _, s := range []*Schema{a, b} {
+		tb, err := s.FindTableByName("b")
+		if err != nil {
+			t.Fatal(err)
+		}
+		tb.Columns[1].Name = "user_name"
+		tb.Columns[1].Comment = ""
+	}
+	tb, err := b.FindTableByName("b")
+	if err != nil {
+		t.Fatal(err)
+	}
+	tb.Columns[1].Name = "username"
+
+	d := DiffWithOption(a, b, DefaultDiffOption())
+	got := []string{}
+	for _, c := range d.Changes {
+		got = append(got, fmt.Sprintf("%s %s %s from %s", c.Type, c.ObjectType, c.Path(), c.From))
+	}
+	want := []string{"renamed column b.username from user_name"}
+	if diff := cmp.Diff(got, want); diff != "" {
+		t.Error(diff)
+	}
+}
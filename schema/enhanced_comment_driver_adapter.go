@@ -1,6 +1,7 @@
 package schema
 
 import (
+	"errors"
 	"fmt"
 )
 
@@ -8,6 +9,7 @@ import (
 type EnhancedCommentDriverAdapter struct {
 	processor CommentProcessor
 	config    *ProcessingConfig
+	reporter  ErrorReporter
 }
 
 // NewEnhancedCommentDriverAdapter 新しいドライバーアダプターを作成
@@ -26,10 +28,22 @@ func NewEnhancedCommentDriverAdapter(config *ProcessingConfig) *EnhancedCommentD
 	return &EnhancedCommentDriverAdapter{
 		processor: NewEnhancedCommentProcessorWithConfig(config),
 		config:    config,
+		reporter:  NewDefaultErrorReporter(),
 	}
 }
 
+// SetErrorReporter 非厳密モードで処理に失敗したコメントの報告先を設定
+func (adapter *EnhancedCommentDriverAdapter) SetErrorReporter(reporter ErrorReporter) {
+	adapter.reporter = reporter
+}
+
+// GetErrorReporter 非厳密モードで処理に失敗したコメントの報告先を取得
+func (adapter *EnhancedCommentDriverAdapter) GetErrorReporter() ErrorReporter {
+	return adapter.reporter
+}
+
 // ProcessSchemaComments スキーマ全体のコメントを拡張処理
+// バッチ処理が有効な場合はワーカープールで並列に処理する
 func (adapter *EnhancedCommentDriverAdapter) ProcessSchemaComments(schema *Schema) error {
 	if schema == nil {
 		return fmt.Errorf("schema cannot be nil")
@@ -39,66 +53,96 @@ func (adapter *EnhancedCommentDriverAdapter) ProcessSchemaComments(schema *Schem
 	// 注意: 現在のSchemaオブジェクトにはCommentフィールドが存在しないため、
 	// 将来的にスキーマレベルのコメント処理が必要な場合のプレースホルダー
 
-	// テーブルレベルの処理
+	tasks := []CommentTask{}
 	for _, table := range schema.Tables {
-		if err := adapter.processTableComments(table); err != nil {
-			return fmt.Errorf("table %s processing failed: %w", table.Name, err)
+		tasks = append(tasks, adapter.tableCommentTasks(table)...)
+	}
+	workers := 1
+	if adapter.config.EnableBatchProcessing {
+		workers = adapter.config.MaxWorkers
+	}
+	errs := RunCommentTasks(tasks, workers)
+	if !adapter.config.StrictMode {
+		// 非厳密モードでは失敗したコメントをスキーマ上のオブジェクト順に報告して処理を続ける
+		for i, err := range errs {
+			if err != nil && adapter.reporter != nil {
+				adapter.reporter.ReportError(newCommentTaskError(tasks[i], err))
+			}
+		}
+		return nil
+	}
+
+	// エラーはスキーマ上のオブジェクト順にまとめる
+	failed := []error{}
+	for i, err := range errs {
+		if err != nil {
+			failed = append(failed, fmt.Errorf("%s %s comment processing failed: %w", tasks[i].ObjectType, tasks[i].Name, err))
 		}
 	}
+	return errors.Join(failed...)
+}
 
-	return nil
+// newCommentTaskError 処理に失敗したタスクのエラーを警告として報告するエラー情報に変換
+func newCommentTaskError(task CommentTask, err error) *EnhancedCommentError {
+	var ece *EnhancedCommentError
+	if errors.As(err, &ece) {
+		e := *ece
+		e.Severity = ErrorSeverityWarning
+		e.ObjectType, e.ObjectName = task.ObjectType, task.Name
+		return &e
+	}
+	b := NewErrorBuilder().
+		WithMessage(fmt.Sprintf("comment processing failed: %v", err)).
+		WithSeverity(ErrorSeverityWarning).
+		WithCategory(ErrorCategoryProcessing).
+		WithErrorCode(ErrorCodeInternalError).
+		WithObjectInfo(task.ObjectType, task.Name).
+		WithInnerError(err)
+	if errors.Is(err, ErrMetadataSchemaViolation) {
+		b = b.WithCategory(ErrorCategoryValidation).WithErrorCode(ErrorCodeMetadataSchemaViolation)
+	}
+	return b.Build()
 }
 
-// processTableComments テーブルとその関連オブジェクトのコメント処理
-func (adapter *EnhancedCommentDriverAdapter) processTableComments(table *Table) error {
+// tableCommentTasks テーブルとその関連オブジェクトのコメント処理タスクを作成
+func (adapter *EnhancedCommentDriverAdapter) tableCommentTasks(table *Table) []CommentTask {
 	delimiter := adapter.config.DefaultDelimiter
 	fallback := adapter.config.FallbackToLegacy
 
 	// テーブルコメント処理
-	if err := table.ProcessEnhancedComment(adapter.processor, delimiter, fallback); err != nil {
-		if adapter.config.StrictMode {
-			return fmt.Errorf("table comment processing failed: %w", err)
-		}
-		// 非厳密モードではログ出力のみ（実装は呼び出し側に依存）
-	}
+	tasks := []CommentTask{{ObjectType: ObjectTypeTable, Name: table.Name, Process: func() error {
+		return table.ProcessEnhancedComment(adapter.processor, delimiter, fallback)
+	}}}
 
 	// カラムコメント処理
 	for _, column := range table.Columns {
-		if err := column.ProcessEnhancedComment(adapter.processor, delimiter, fallback); err != nil {
-			if adapter.config.StrictMode {
-				return fmt.Errorf("column %s comment processing failed: %w", column.Name, err)
-			}
-		}
+		tasks = append(tasks, CommentTask{ObjectType: ObjectTypeColumn, Name: table.Name + "." + column.Name, Process: func() error {
+			return column.ProcessEnhancedComment(adapter.processor, delimiter, fallback)
+		}})
 	}
 
 	// インデックスコメント処理
 	for _, index := range table.Indexes {
-		if err := index.ProcessEnhancedComment(adapter.processor, delimiter); err != nil {
-			if adapter.config.StrictMode {
-				return fmt.Errorf("index %s comment processing failed: %w", index.Name, err)
-			}
-		}
+		tasks = append(tasks, CommentTask{ObjectType: ObjectTypeIndex, Name: table.Name + "." + index.Name, Process: func() error {
+			return index.ProcessEnhancedComment(adapter.processor, delimiter)
+		}})
 	}
 
 	// 制約コメント処理
 	for _, constraint := range table.Constraints {
-		if err := constraint.ProcessEnhancedComment(adapter.processor, delimiter); err != nil {
-			if adapter.config.StrictMode {
-				return fmt.Errorf("constraint %s comment processing failed: %w", constraint.Name, err)
-			}
-		}
+		tasks = append(tasks, CommentTask{ObjectType: ObjectTypeConstraint, Name: table.Name + "." + constraint.Name, Process: func() error {
+			return constraint.ProcessEnhancedComment(adapter.processor, delimiter)
+		}})
 	}
 
 	// トリガーコメント処理
 	for _, trigger := range table.Triggers {
-		if err := trigger.ProcessEnhancedComment(adapter.processor, delimiter); err != nil {
-			if adapter.config.StrictMode {
-				return fmt.Errorf("trigger %s comment processing failed: %w", trigger.Name, err)
-			}
-		}
+		tasks = append(tasks, CommentTask{ObjectType: ObjectTypeTrigger, Name: table.Name + "." + trigger.Name, Process: func() error {
+			return trigger.ProcessEnhancedComment(adapter.processor, delimiter)
+		}})
 	}
 
-	return nil
+	return tasks
 }
 
 // GetProcessingStatistics 処理統計情報を取得
@@ -259,11 +303,9 @@ func (helper *DriverIntegrationHelper) WrapAnalyzeWithEnhancedComments(
 	}
 }
 
-// CreateMigrationScript 拡張コメント対応移行スクリプト作成
+// CreateMigrationScript スキーマのコメントをデータベースに書き戻すDDLを作成
 func (helper *DriverIntegrationHelper) CreateMigrationScript(schema *Schema, targetDriver string) (string, error) {
-	// 将来的な機能: 既存のコメントを拡張コメント形式に移行するスクリプト生成
-	// 現在はプレースホルダー実装
-	return fmt.Sprintf("-- Migration script for %s\n-- TODO: Implement migration logic\n", targetDriver), nil
+	return CommentMigrationScript(schema, targetDriver, "", "|", false)
 }
 
 // ValidateDriverCompatibility ドライバー互換性検証
@@ -301,9 +343,7 @@ func (helper *DriverIntegrationHelper) ValidateDriverCompatibility(driverName st
 	return issues
 }
 
-// ConvertCommentFormat コメント形式変換
+// ConvertCommentFormat コメント形式変換（legacy, json, yaml の相互変換。fromFormatには auto も指定可能）
 func (helper *DriverIntegrationHelper) ConvertCommentFormat(comment string, fromFormat, toFormat string) (string, error) {
-	// プレースホルダー実装
-	// 実際の実装では、Legacy -> JSON -> YAML の相互変換を行う
-	return comment, fmt.Errorf("comment format conversion not yet implemented")
-}
\ No newline at end of file
+	return ConvertComment(comment, fromFormat, toFormat, "|")
+}
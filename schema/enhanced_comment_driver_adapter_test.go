@@ -1,7 +1,12 @@
 package schema
 
 import (
+	"errors"
+	"fmt"
+	"strings"
 	"testing"
+
+	"github.com/google/go-cmp/cmp"
 )
 
 // TestEnhancedCommentDriverAdapter ドライバーアダプターのテスト
@@ -146,6 +151,88 @@ func TestProcessSchemaComments(t *testing.T) {
 	})
 }
 
+// TestProcessSchemaCommentsBatch バッチ処理の結果とエラーの順序が逐次処理と一致することを確認
+func TestProcessSchemaCommentsBatch(t *testing.T) {
+	ms, err := NewMetadataSchema(map[ObjectType][]*MetadataField{
+		ObjectTypeColumn: {{Key: "pii", Type: MetadataTypeBool}},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	process := func(batch bool) (*Schema, error) {
+		schema := createLargeSchema(20, 10)
+		for _, table := range schema.Tables {
+			table.Columns[3].Comment = `{"name": "メール", "pii": "maybe"}`
+		}
+		config := DefaultProcessingConfig()
+		config.StrictMode = true
+		config.EnableBatchProcessing = batch
+		config.MaxWorkers = 4
+		adapter := NewEnhancedCommentDriverAdapter(config)
+		adapter.processor.(*EnhancedCommentProcessor).SetMetadataSchema(ms)
+		return schema, adapter.ProcessSchemaComments(schema)
+	}
+
+	want, wantErr := process(false)
+	if !errors.Is(wantErr, ErrMetadataSchemaViolation) {
+		t.Fatalf("got %v, want ErrMetadataSchemaViolation", wantErr)
+	}
+	for range 5 {
+		got, gotErr := process(true)
+		if gotErr == nil || gotErr.Error() != wantErr.Error() {
+			t.Fatalf("got %v\nwant %v", gotErr, wantErr)
+		}
+		for i, table := range got.Tables {
+			if diff := cmp.Diff(want.Tables[i].EnhancedCommentData, table.EnhancedCommentData); diff != "" {
+				t.Error(diff)
+			}
+			for j, column := range table.Columns {
+				if diff := cmp.Diff(want.Tables[i].Columns[j].EnhancedCommentData, column.EnhancedCommentData); diff != "" {
+					t.Error(diff)
+				}
+			}
+		}
+	}
+	if !strings.HasPrefix(wantErr.Error(), "column table_0.col_0_3 comment processing failed: ") {
+		t.Errorf("got %v", wantErr)
+	}
+}
+
+// TestProcessSchemaCommentsReportsErrors 非厳密モードでは失敗したコメントをエラーレポーターに報告することを確認
+func TestProcessSchemaCommentsReportsErrors(t *testing.T) {
+	ms, err := NewMetadataSchema(map[ObjectType][]*MetadataField{
+		ObjectTypeColumn: {{Key: "pii", Type: MetadataTypeBool}},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	schema := createLargeSchema(2, 4)
+	for _, table := range schema.Tables {
+		table.Columns[1].Comment = `{"name": "メール", "pii": "maybe"}`
+	}
+	config := DefaultProcessingConfig()
+	config.EnableBatchProcessing = true
+	config.MaxWorkers = 2
+	adapter := NewEnhancedCommentDriverAdapter(config)
+	adapter.processor.(*EnhancedCommentProcessor).SetMetadataSchema(ms)
+	reporter := NewDefaultErrorReporter()
+	adapter.SetErrorReporter(reporter)
+	if err := adapter.ProcessSchemaComments(schema); err != nil {
+		t.Fatal(err)
+	}
+	got := []string{}
+	for _, e := range reporter.Errors() {
+		got = append(got, fmt.Sprintf("%s %s %s %s", e.Severity, e.ObjectType, e.ObjectName, e.ErrorCode))
+	}
+	want := []string{
+		"WARNING column table_0.col_0_1 " + ErrorCodeMetadataSchemaViolation,
+		"WARNING column table_1.col_1_1 " + ErrorCodeMetadataSchemaViolation,
+	}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Error(diff)
+	}
+}
+
 // TestProcessingStatistics 処理統計のテスト
 func TestProcessingStatistics(t *testing.T) {
 	adapter := NewEnhancedCommentDriverAdapter(nil)
@@ -316,11 +403,14 @@ func TestDriverIntegrationHelper(t *testing.T) {
 
 	t.Run("コメント形式変換", func(t *testing.T) {
 		originalComment := `{"name": "テスト", "description": "テスト用"}`
-		
-		// 現在はプレースホルダー実装のため、エラーが返される
-		_, err := helper.ConvertCommentFormat(originalComment, "json", "yaml")
-		if err == nil {
-			t.Error("Comment format conversion should return error (not implemented)")
+
+		converted, err := helper.ConvertCommentFormat(originalComment, "json", "yaml")
+		if err != nil {
+			t.Fatalf("Comment format conversion failed: %v", err)
+		}
+		want := "name: テスト\ndescription: テスト用"
+		if converted != want {
+			t.Errorf("got %q, want %q", converted, want)
 		}
 	})
 }
@@ -31,6 +31,21 @@ func (s ErrorSeverity) String() string {
 	}
 }
 
+// MarshalText 重要度を文字列としてエンコード
+func (s ErrorSeverity) MarshalText() ([]byte, error) {
+	return []byte(s.String()), nil
+}
+
+// ParseErrorSeverity 文字列（info, warning, error, critical）から重要度を取得
+func ParseErrorSeverity(s string) (ErrorSeverity, error) {
+	for _, sv := range []ErrorSeverity{ErrorSeverityInfo, ErrorSeverityWarning, ErrorSeverityError, ErrorSeverityCritical} {
+		if strings.EqualFold(s, sv.String()) {
+			return sv, nil
+		}
+	}
+	return ErrorSeverityInfo, fmt.Errorf("unsupported severity '%s'", s)
+}
+
 // ErrorCategory エラーのカテゴリ
 type ErrorCategory int
 
@@ -65,6 +80,11 @@ func (c ErrorCategory) String() string {
 	}
 }
 
+// MarshalText カテゴリを文字列としてエンコード
+func (c ErrorCategory) MarshalText() ([]byte, error) {
+	return []byte(c.String()), nil
+}
+
 // EnhancedCommentError 拡張されたエラー情報
 type EnhancedCommentError struct {
 	Message      string                 `json:"message"`
@@ -197,7 +217,7 @@ type FallbackParsingStrategy struct{}
 
 func (s *FallbackParsingStrategy) CanRecover(err *EnhancedCommentError) bool {
 	return err.Category == ErrorCategoryParsing && 
-		   (err.ErrorCode == "E_JSON_PARSE_FAILED" || err.ErrorCode == "E_YAML_PARSE_FAILED")
+		   (err.ErrorCode == "E_JSON_PARSE_FAILED" || err.ErrorCode == "E_YAML_PARSE_FAILED" || err.ErrorCode == ErrorCodeAnnotationParseFailed)
 }
 
 func (s *FallbackParsingStrategy) Recover(err *EnhancedCommentError, context interface{}) (interface{}, error) {
@@ -210,7 +230,7 @@ func (s *FallbackParsingStrategy) Recover(err *EnhancedCommentError, context int
 }
 
 func (s *FallbackParsingStrategy) GetDescription() string {
-	return "フォールバック解析戦略: JSON/YAML解析失敗時にLegacy形式で再試行"
+	return "フォールバック解析戦略: JSON/YAML/アノテーション解析失敗時にLegacy形式で再試行"
 }
 
 // CommentSanitizationStrategy コメントサニタイゼーション戦略
@@ -327,7 +347,7 @@ func (r *DefaultErrorReporter) ReportRecovery(originalErr *EnhancedCommentError,
 		WithMessage(fmt.Sprintf("Recovered from error: %s", originalErr.Message)).
 		WithSeverity(ErrorSeverityInfo).
 		WithCategory(ErrorCategoryProcessing).
-		WithErrorCode("E_RECOVERY_SUCCESS").
+		WithErrorCode(ErrorCodeRecoverySuccess).
 		WithObjectInfo(originalErr.ObjectType, originalErr.ObjectName).
 		WithContext("original_error", originalErr.ErrorCode).
 		WithContext("recovered_data_type", fmt.Sprintf("%T", recoveredData)).
@@ -336,6 +356,11 @@ func (r *DefaultErrorReporter) ReportRecovery(originalErr *EnhancedCommentError,
 	r.errors = append(r.errors, recoveryErr)
 }
 
+// Errors 報告された全てのエラー（回復を含む）を報告順に取得
+func (r *DefaultErrorReporter) Errors() []*EnhancedCommentError {
+	return r.errors
+}
+
 // GetErrorSummary エラー概要を取得
 func (r *DefaultErrorReporter) GetErrorSummary() *ErrorSummary {
 	summary := &ErrorSummary{
@@ -399,16 +424,19 @@ const (
 	ErrorCodeJSONParseFailed   = "E_JSON_PARSE_FAILED"
 	ErrorCodeYAMLParseFailed   = "E_YAML_PARSE_FAILED"
 	ErrorCodeLegacyParseFailed = "E_LEGACY_PARSE_FAILED"
+	ErrorCodeAnnotationParseFailed = "E_ANNOTATION_PARSE_FAILED"
 	
 	// バリデーションエラー
 	ErrorCodeValidationFailed = "E_VALIDATION_FAILED"
 	ErrorCodeUnsafeContent    = "E_UNSAFE_CONTENT"
 	ErrorCodeInvalidFormat    = "E_INVALID_FORMAT"
+	ErrorCodeMetadataSchemaViolation = "E_METADATA_SCHEMA_VIOLATION"
 	
 	// 処理エラー
 	ErrorCodeProcessingTimeout = "E_PROCESSING_TIMEOUT"
 	ErrorCodeMemoryLimit       = "E_MEMORY_LIMIT"
 	ErrorCodeInternalError     = "E_INTERNAL_ERROR"
+	ErrorCodeRecoverySuccess   = "E_RECOVERY_SUCCESS"
 	
 	// 設定エラー
 	ErrorCodeInvalidConfig     = "E_INVALID_CONFIG"
@@ -429,6 +457,8 @@ func NewParsingError(parserName, comment string, innerErr error) *EnhancedCommen
 		errorCode = ErrorCodeYAMLParseFailed
 	case "legacy":
 		errorCode = ErrorCodeLegacyParseFailed
+	case "annotation":
+		errorCode = ErrorCodeAnnotationParseFailed
 	default:
 		errorCode = "E_UNKNOWN_PARSER_FAILED"
 	}
@@ -1,6 +1,7 @@
 package schema
 
 import (
+	"errors"
 	"fmt"
 	"runtime"
 	"testing"
@@ -135,6 +136,30 @@ func BenchmarkParserComparison(b *testing.B) {
 	})
 }
 
+// BenchmarkBatchProcessing 10万件のコメントの逐次処理とバッチ処理（ワーカープール）の比較
+func BenchmarkBatchProcessing(b *testing.B) {
+	// 1000テーブル × (テーブル1 + カラム94 + インデックス3 + 制約2) = 100,000件
+	schema := createLargeSchema(1000, 94)
+
+	for _, batch := range []bool{false, true} {
+		name := "逐次処理"
+		if batch {
+			name = fmt.Sprintf("バッチ処理(%dワーカー)", runtime.GOMAXPROCS(0))
+		}
+		b.Run(name, func(b *testing.B) {
+			config := DefaultProcessingConfig()
+			config.EnableBatchProcessing = batch
+			adapter := NewEnhancedCommentDriverAdapter(config)
+			b.ResetTimer()
+			for i := 0; i < b.N; i++ {
+				if err := adapter.ProcessSchemaComments(schema); err != nil {
+					b.Fatal(err)
+				}
+			}
+		})
+	}
+}
+
 // TestMemoryUsage メモリ使用量テスト
 func TestMemoryUsage(t *testing.T) {
 	// メモリ使用量測定のヘルパー関数
@@ -325,6 +350,59 @@ func TestProcessingTimeout(t *testing.T) {
 	}
 }
 
+// slowParser 処理に時間がかかるパーサー（タイムアウトのテスト用）
+type slowParser struct {
+	delay time.Duration
+}
+
+func (p *slowParser) ParseComment(comment string, delimiter string) (*CommentData, error) {
+	time.Sleep(p.delay)
+	return &CommentData{LogicalName: "slow"}, nil
+}
+
+func (p *slowParser) CanParse(comment string) bool { return true }
+func (p *slowParser) Priority() int                { return 0 }
+func (p *slowParser) Name() string                 { return "slow" }
+
+// TestProcessingTimeoutFallback タイムアウト時にLegacyParserで解析することを確認
+func TestProcessingTimeoutFallback(t *testing.T) {
+	for _, fallback := range []bool{true, false} {
+		t.Run(fmt.Sprintf("fallback=%v", fallback), func(t *testing.T) {
+			config := DefaultProcessingConfig()
+			config.ProcessingTimeout = 10
+			config.FallbackToLegacy = fallback
+			processor := NewEnhancedCommentProcessorWithConfig(config)
+			processor.RegisterParser(&slowParser{delay: time.Second})
+
+			got, err := processor.ProcessComment("ユーザー|ユーザー情報", "|", ObjectTypeTable)
+			if !fallback {
+				if !errors.Is(err, ErrProcessingTimeout) {
+					t.Errorf("got %v, want ErrProcessingTimeout", err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got.LogicalName != "ユーザー" || got.Description != "ユーザー情報" {
+				t.Errorf("got %q %q, want legacy result", got.LogicalName, got.Description)
+			}
+		})
+	}
+
+	config := DefaultProcessingConfig()
+	config.ProcessingTimeout = 0
+	processor := NewEnhancedCommentProcessorWithConfig(config)
+	processor.RegisterParser(&slowParser{delay: 20 * time.Millisecond})
+	got, err := processor.ProcessComment("ユーザー|ユーザー情報", "|", ObjectTypeTable)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got.LogicalName != "slow" {
+		t.Errorf("got %q, want no timeout when ProcessingTimeout is 0", got.LogicalName)
+	}
+}
+
 // TestLargeCommentProcessing 大きなコメント処理テスト
 func TestLargeCommentProcessing(t *testing.T) {
 	processor := NewEnhancedCommentProcessor()
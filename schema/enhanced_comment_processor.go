@@ -1,6 +1,7 @@
 package schema
 
 import (
+	"errors"
 	"fmt"
 	"time"
 )
@@ -31,10 +32,17 @@ type ProcessingConfig struct {
 	FallbackToLegacy bool
 	// StrictMode 厳格モード（エラー時に失敗）
 	StrictMode bool
-	// ProcessingTimeout 処理タイムアウト（ミリ秒）
+	// ProcessingTimeout コメント1件あたりの処理タイムアウト（ミリ秒、0以下の場合は無制限）
 	ProcessingTimeout int
+	// EnableBatchProcessing スキーマ全体のコメントを並列に処理するか
+	EnableBatchProcessing bool
+	// MaxWorkers 並列処理のワーカー数（0以下の場合はGOMAXPROCS）
+	MaxWorkers int
 }
 
+// ErrProcessingTimeout コメント処理がタイムアウトした
+var ErrProcessingTimeout = errors.New("comment processing timeout")
+
 // DefaultProcessingConfig デフォルトの処理設定
 func DefaultProcessingConfig() *ProcessingConfig {
 	return &ProcessingConfig{
@@ -49,9 +57,11 @@ func DefaultProcessingConfig() *ProcessingConfig {
 
 // EnhancedCommentProcessor 拡張されたコメントプロセッサーの実装
 type EnhancedCommentProcessor struct {
-	registry  ParserRegistry
-	validator CommentValidator
-	config    *ProcessingConfig
+	registry       ParserRegistry
+	validator      CommentValidator
+	config         *ProcessingConfig
+	metadataSchema *MetadataSchema
+	lang           string
 }
 
 // NewEnhancedCommentProcessor 新しいEnhancedCommentProcessorを作成
@@ -157,6 +167,21 @@ func (p *EnhancedCommentProcessor) registerParsersFromConfig(config EnhancedComm
 			jsonParser.SetPriority(10) // 通常優先度
 			p.registry.RegisterParser(jsonParser)
 		}
+	case "annotation":
+		// アノテーション優先の場合、アノテーションの優先度を最高に設定
+		annotationParser := NewAnnotationParser()
+		annotationParser.SetPriority(5) // 最高優先度
+		p.registry.RegisterParser(annotationParser)
+		if config.IsEnhancedCommentJSONEnabled() {
+			jsonParser := NewJSONParser()
+			jsonParser.SetPriority(10) // 通常優先度
+			p.registry.RegisterParser(jsonParser)
+		}
+		if config.IsEnhancedCommentYAMLEnabled() {
+			yamlParser := NewYAMLParser()
+			yamlParser.SetPriority(15) // 通常優先度
+			p.registry.RegisterParser(yamlParser)
+		}
 	case "legacy":
 		// legacyパーサーのみ
 		legacyParser := NewLegacyParser()
@@ -164,7 +189,7 @@ func (p *EnhancedCommentProcessor) registerParsersFromConfig(config EnhancedComm
 		p.registry.RegisterParser(legacyParser)
 		return
 	default: // "auto" または未知の値
-		// JSON -> YAML -> Legacyの順で登録（優先度順）
+		// JSON -> YAML -> Annotation -> Legacyの順で登録（優先度順）
 		if config.IsEnhancedCommentJSONEnabled() {
 			jsonParser := NewJSONParser()
 			jsonParser.SetPriority(10) // 高優先度
@@ -175,6 +200,9 @@ func (p *EnhancedCommentProcessor) registerParsersFromConfig(config EnhancedComm
 			yamlParser.SetPriority(15) // 中優先度
 			p.registry.RegisterParser(yamlParser)
 		}
+		annotationParser := NewAnnotationParser()
+		annotationParser.SetPriority(18) // 低優先度
+		p.registry.RegisterParser(annotationParser)
 	}
 
 	// 常にlegacyパーサーをフォールバックとして登録
@@ -216,6 +244,9 @@ func (p *EnhancedCommentProcessor) registerDefaultParsers() {
 	// YAMLParserを登録（中優先度）
 	p.registry.RegisterParser(NewYAMLParser())
 
+	// AnnotationParserを登録（低優先度）
+	p.registry.RegisterParser(NewAnnotationParser())
+
 	// LegacyParserを登録（低優先度、フォールバック用）
 	p.registry.RegisterParser(NewLegacyParser())
 }
@@ -231,21 +262,41 @@ func (p *EnhancedCommentProcessor) ProcessComment(comment string, delimiter stri
 		delimiter = p.config.DefaultDelimiter
 	}
 
-	// タイムアウト処理（基本的な実装）
+	if p.config.ProcessingTimeout <= 0 {
+		return p.processCommentInternal(comment, delimiter, objectType)
+	}
+
+	// タイムアウト処理
 	done := make(chan *ProcessingResult, 1)
 	go func() {
 		result, err := p.processCommentInternal(comment, delimiter, objectType)
 		done <- &ProcessingResult{Data: result, Error: err}
 	}()
 
+	timer := time.NewTimer(time.Duration(p.config.ProcessingTimeout) * time.Millisecond)
+	defer timer.Stop()
 	select {
 	case result := <-done:
 		return result.Data, result.Error
-	case <-time.After(time.Duration(p.config.ProcessingTimeout) * time.Millisecond):
-		return nil, fmt.Errorf("comment processing timeout after %d ms", p.config.ProcessingTimeout)
+	case <-timer.C:
+		if p.config.FallbackToLegacy {
+			// タイムアウトした場合は従来形式として解析
+			return p.processLegacyComment(comment, delimiter, objectType)
+		}
+		return nil, fmt.Errorf("%w after %d ms", ErrProcessingTimeout, p.config.ProcessingTimeout)
 	}
 }
 
+// processLegacyComment LegacyParserのみでコメントを処理
+func (p *EnhancedCommentProcessor) processLegacyComment(comment string, delimiter string, objectType ObjectType) (*CommentData, error) {
+	result, err := NewLegacyParser().ParseComment(comment, delimiter)
+	if err != nil {
+		return nil, err
+	}
+	result.SetMetadata(MetadataKeyObjectType, string(objectType))
+	return result, nil
+}
+
 // ProcessingResult 処理結果
 type ProcessingResult struct {
 	Data  *CommentData
@@ -264,6 +315,9 @@ func (p *EnhancedCommentProcessor) processCommentInternal(comment string, delimi
 		return &CommentData{Source: comment}, nil
 	}
 
+	// ロケールごとの論理名・説明から表示するロケールの値を選択
+	result.Localize(p.lang)
+
 	// オブジェクトタイプをメタデータに追加
 	if result.Metadata == nil {
 		result.Metadata = make(map[string]string)
@@ -307,6 +361,11 @@ func (p *EnhancedCommentProcessor) ProcessCommentWithValidation(comment string,
 		result = p.validator.Sanitize(result)
 	}
 
+	// メタデータ定義の検証（厳格モードに関わらず違反を返す）
+	if err := p.metadataSchema.Validate(objectType, result); err != nil {
+		return nil, err
+	}
+
 	return result, nil
 }
 
@@ -320,6 +379,16 @@ func (p *EnhancedCommentProcessor) SetValidator(validator CommentValidator) {
 	p.validator = validator
 }
 
+// SetMetadataSchema メタデータ定義を設定
+func (p *EnhancedCommentProcessor) SetMetadataSchema(ms *MetadataSchema) {
+	p.metadataSchema = ms
+}
+
+// SetLang 論理名・説明を表示するロケールを設定
+func (p *EnhancedCommentProcessor) SetLang(lang string) {
+	p.lang = lang
+}
+
 // GetSupportedFormats サポートされているフォーマット一覧を取得
 func (p *EnhancedCommentProcessor) GetSupportedFormats() []string {
 	parsers := p.registry.GetParsers()
@@ -162,9 +162,9 @@ func TestRegisterParsersFromConfigAutoFormat(t *testing.T) {
 
 	processor := NewEnhancedCommentProcessorFromConfig(config)
 
-	// autoの場合はJSON、YAML、Legacyの順で登録される
+	// autoの場合はJSON、YAML、Annotation、Legacyの順で登録される
 	formats := processor.GetSupportedFormats()
-	expectedFormats := []string{"json", "yaml", "legacy"}
+	expectedFormats := []string{"json", "yaml", "annotation", "legacy"}
 
 	if len(formats) != len(expectedFormats) {
 		t.Errorf("expected %d formats, got %d", len(expectedFormats), len(formats))
@@ -215,6 +215,33 @@ func TestRegisterParsersFromConfigYAMLPreferred(t *testing.T) {
 	}
 }
 
+func TestRegisterParsersFromConfigAnnotationPreferred(t *testing.T) {
+	config := NewMockEnhancedCommentConfigurator()
+	config.preferredFormat = "annotation"
+	config.jsonEnabled = true
+
+	processor := NewEnhancedCommentProcessorFromConfig(config)
+
+	// アノテーションが優先される場合の順序確認
+	formats := processor.GetSupportedFormats()
+	if len(formats) < 3 {
+		t.Fatal("expected at least 3 formats")
+	}
+
+	// アノテーションが最初に来るべき
+	if formats[0] != "annotation" {
+		t.Errorf("expected first format to be annotation, got %s", formats[0])
+	}
+
+	result, err := processor.ProcessComment("User email @pii @owner identity-team @deprecated use contact_email", "|", ObjectTypeColumn)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if result.LogicalName != "User email" || !result.Deprecated || result.Metadata["owner"] != "identity-team" {
+		t.Errorf("unexpected result: %+v", result)
+	}
+}
+
 func TestRegisterParsersFromConfigLegacyOnly(t *testing.T) {
 	config := NewMockEnhancedCommentConfigurator()
 	config.preferredFormat = "legacy"
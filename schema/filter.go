@@ -13,7 +13,13 @@ type FilterOption struct {
 	Include       []string
 	Exclude       []string
 	IncludeLabels []string
-	Distance      int
+	// IncludeTags is tags of enhanced comments to be included. Tables are matched by their own tags or their column tags.
+	IncludeTags []string
+	// ExcludeTags is tags of enhanced comments to be excluded. Tables are matched by their own tags.
+	ExcludeTags []string
+	// ExcludeColumnTags is tags of enhanced comments to exclude columns.
+	ExcludeColumnTags []string
+	Distance          int
 }
 
 func (s *Schema) Filter(opt *FilterOption) (err error) {
@@ -42,6 +48,20 @@ func (s *Schema) Filter(opt *FilterOption) (err error) {
 		}
 	}
 
+	if len(opt.ExcludeColumnTags) > 0 {
+		for _, t := range s.Tables {
+			excludes := []string{}
+			for _, c := range t.Columns {
+				if matchTags(opt.ExcludeColumnTags, c.GetTags()) {
+					excludes = append(excludes, c.Name)
+				}
+			}
+			for _, name := range excludes {
+				excludeColumnFromSchema(t, name, s)
+			}
+		}
+	}
+
 	return nil
 }
 
@@ -57,7 +77,11 @@ func (s *Schema) SeparateTablesThatAreIncludedOrNot(opt *FilterOption) (_ []*Tab
 	for _, t := range s.Tables {
 		li, mi := matchLength(i, t.Name)
 		le, me := matchLength(e, t.Name)
-		ml := matchTableOrColumnLabels(opt.IncludeLabels, t)
+		ml := matchTableOrColumnLabels(opt.IncludeLabels, t) || matchTableOrColumnTags(opt.IncludeTags, t)
+		if matchTags(opt.ExcludeTags, t.GetTags()) {
+			excludes = append(excludes, t)
+			continue
+		}
 		switch {
 		case mi:
 			if me && li < le {
@@ -71,7 +95,7 @@ func (s *Schema) SeparateTablesThatAreIncludedOrNot(opt *FilterOption) (_ []*Tab
 				continue
 			}
 			includes = append(includes, t)
-		case len(opt.Include) == 0 && len(opt.IncludeLabels) == 0:
+		case len(opt.Include) == 0 && len(opt.IncludeLabels) == 0 && len(opt.IncludeTags) == 0:
 			if me {
 				excludes = append(excludes, t)
 				continue
@@ -155,10 +179,69 @@ func excludeTableFromSchema(name string, s *Schema) error {
 		}
 	}
 	s.Relations = relations
+	s.invalidateIndexes()
 
 	return nil
 }
 
+// excludeColumnFromSchema removes the column and the relations, indexes and constraints that use it.
+func excludeColumnFromSchema(t *Table, name string, s *Schema) {
+	columns := []*Column{}
+	for _, c := range t.Columns {
+		if c.Name != name {
+			columns = append(columns, c)
+		}
+	}
+	t.Columns = columns
+	t.invalidateIndexes()
+
+	useColumn := func(r *Relation) bool {
+		if r.Table == t && lo.ContainsBy(r.Columns, func(c *Column) bool { return c.Name == name }) {
+			return true
+		}
+		return r.ParentTable == t && lo.ContainsBy(r.ParentColumns, func(c *Column) bool { return c.Name == name })
+	}
+	for _, tt := range s.Tables {
+		for _, c := range tt.Columns {
+			c.ChildRelations = lo.Reject(c.ChildRelations, func(r *Relation, _ int) bool { return useColumn(r) })
+			c.ParentRelations = lo.Reject(c.ParentRelations, func(r *Relation, _ int) bool { return useColumn(r) })
+		}
+		if tt != t {
+			tt.Constraints = lo.Reject(tt.Constraints, func(c *Constraint, _ int) bool {
+				return c.ReferencedTable != nil && *c.ReferencedTable == t.Name && lo.Contains(c.ReferencedColumns, name)
+			})
+		}
+	}
+	s.Relations = lo.Reject(s.Relations, func(r *Relation, _ int) bool { return useColumn(r) })
+	s.invalidateIndexes()
+
+	t.Indexes = lo.Reject(t.Indexes, func(i *Index, _ int) bool { return lo.Contains(i.Columns, name) })
+	t.Constraints = lo.Reject(t.Constraints, func(c *Constraint, _ int) bool { return lo.Contains(c.Columns, name) })
+}
+
+func matchTableOrColumnTags(it []string, t *Table) bool {
+	if matchTags(it, t.GetTags()) {
+		return true
+	}
+	for _, c := range t.Columns {
+		if matchTags(it, c.GetTags()) {
+			return true
+		}
+	}
+	return false
+}
+
+func matchTags(it []string, tags []string) bool {
+	for _, tag := range tags {
+		for _, itt := range it {
+			if wildcard.MatchSimple(itt, tag) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
 func matchTableOrColumnLabels(il []string, t *Table) bool {
 	if matchLabels(il, t.Labels) {
 		return true
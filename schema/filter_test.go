@@ -1,6 +1,7 @@
 package schema
 
 import (
+	"encoding/json"
 	"testing"
 
 	"github.com/google/go-cmp/cmp"
@@ -116,3 +117,153 @@ func getFunctionNames(functions []*Function) []string {
 	}
 	return names
 }
+
+func newTagTestSchema() *Schema {
+	usersID := &Column{Name: "id"}
+	users := &Table{
+		Name:                "users",
+		Columns:             []*Column{usersID},
+		EnhancedCommentData: &CommentData{Tags: []string{"public"}},
+	}
+	secrets := &Table{
+		Name:                "secrets",
+		Columns:             []*Column{{Name: "id"}},
+		EnhancedCommentData: &CommentData{Tags: []string{"internal"}},
+	}
+	postsUserID := &Column{Name: "user_id", EnhancedCommentData: &CommentData{Tags: []string{"internal"}}}
+	posts := &Table{
+		Name: "posts",
+		Columns: []*Column{
+			{Name: "id"},
+			postsUserID,
+			{Name: "title", EnhancedCommentData: &CommentData{Tags: []string{"public"}}},
+		},
+		Indexes: []*Index{
+			{Name: "posts_user_id_idx", Columns: []string{"user_id"}},
+			{Name: "posts_title_idx", Columns: []string{"title"}},
+		},
+	}
+	r := &Relation{Table: posts, Columns: []*Column{postsUserID}, ParentTable: users, ParentColumns: []*Column{usersID}}
+	postsUserID.ParentRelations = []*Relation{r}
+	usersID.ChildRelations = []*Relation{r}
+	return &Schema{
+		Tables:    []*Table{users, secrets, posts},
+		Relations: []*Relation{r},
+	}
+}
+
+func TestSchema_SeparateTablesThatAreIncludedOrNotByTags(t *testing.T) {
+	tests := []struct {
+		name         string
+		opt          *FilterOption
+		wantIncludes []string
+		wantExcludes []string
+	}{
+		{
+			name:         "include tags",
+			opt:          &FilterOption{IncludeTags: []string{"internal"}},
+			wantIncludes: []string{"secrets", "posts"},
+			wantExcludes: []string{"users"},
+		},
+		{
+			name:         "include tags with distance",
+			opt:          &FilterOption{IncludeTags: []string{"internal"}, Distance: 1},
+			wantIncludes: []string{"secrets", "posts", "users"},
+			wantExcludes: []string{},
+		},
+		{
+			name:         "exclude tags",
+			opt:          &FilterOption{ExcludeTags: []string{"intern*"}},
+			wantIncludes: []string{"users", "posts"},
+			wantExcludes: []string{"secrets"},
+		},
+		{
+			name:         "include and exclude tags",
+			opt:          &FilterOption{IncludeTags: []string{"public"}, ExcludeTags: []string{"internal"}},
+			wantIncludes: []string{"users", "posts"},
+			wantExcludes: []string{"secrets"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := newTagTestSchema()
+			includes, excludes, err := s.SeparateTablesThatAreIncludedOrNot(tt.opt)
+			if err != nil {
+				t.Fatal(err)
+			}
+			gotIncludes := []string{}
+			for _, t := range includes {
+				gotIncludes = append(gotIncludes, t.Name)
+			}
+			gotExcludes := []string{}
+			for _, t := range excludes {
+				gotExcludes = append(gotExcludes, t.Name)
+			}
+			if diff := cmp.Diff(tt.wantIncludes, gotIncludes); diff != "" {
+				t.Errorf("includes: %s", diff)
+			}
+			if diff := cmp.Diff(tt.wantExcludes, gotExcludes); diff != "" {
+				t.Errorf("excludes: %s", diff)
+			}
+		})
+	}
+}
+
+func TestSchema_SeparateTablesThatAreIncludedOrNotByTagsFromJSON(t *testing.T) {
+	b, err := json.Marshal(newTagTestSchema())
+	if err != nil {
+		t.Fatal(err)
+	}
+	s := &Schema{}
+	if err := json.Unmarshal(b, s); err != nil {
+		t.Fatal(err)
+	}
+	if err := s.Repair(); err != nil {
+		t.Fatal(err)
+	}
+	includes, _, err := s.SeparateTablesThatAreIncludedOrNot(&FilterOption{IncludeTags: []string{"internal"}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	got := []string{}
+	for _, t := range includes {
+		got = append(got, t.Name)
+	}
+	if diff := cmp.Diff([]string{"secrets", "posts"}, got); diff != "" {
+		t.Error(diff)
+	}
+}
+
+func TestSchema_FilterExcludeColumnTags(t *testing.T) {
+	s := newTagTestSchema()
+	if err := s.Filter(&FilterOption{ExcludeColumnTags: []string{"internal"}}); err != nil {
+		t.Fatal(err)
+	}
+	posts, err := s.FindTableByName("posts")
+	if err != nil {
+		t.Fatal(err)
+	}
+	gotColumns := []string{}
+	for _, c := range posts.Columns {
+		gotColumns = append(gotColumns, c.Name)
+	}
+	if diff := cmp.Diff([]string{"id", "title"}, gotColumns); diff != "" {
+		t.Error(diff)
+	}
+	if _, err := posts.FindColumnByName("user_id"); err == nil {
+		t.Error("want error")
+	}
+	if len(posts.Indexes) != 1 || posts.Indexes[0].Name != "posts_title_idx" {
+		t.Errorf("got %v", posts.Indexes)
+	}
+	if len(s.Relations) != 0 {
+		t.Errorf("got %d relations", len(s.Relations))
+	}
+	users, err := s.FindTableByName("users")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(users.Columns[0].ChildRelations) != 0 {
+		t.Errorf("got %d child relations", len(users.Columns[0].ChildRelations))
+	}
+}
@@ -0,0 +1,243 @@
+package schema
+
+import (
+	"sort"
+	"sync"
+)
+
+// tableIndex is the index of tables by normalized table name.
+type tableIndex struct {
+	// tables is the slice that the index was built from
+	tables        []*Table
+	currentSchema string
+	// byName is the positions of tables in tables by normalized name
+	byName map[string]int
+}
+
+// columnIndex is the index of columns by column name.
+type columnIndex struct {
+	// columns is the slice that the index was built from
+	columns []*Column
+	// byName is the positions of columns in columns by name
+	byName map[string]int
+}
+
+// relationIndex is the index of relations by their columns.
+type relationIndex struct {
+	// relations is the slice that the index was built from
+	relations []*Relation
+	// byColumn is the positions of relations in relations by the first column of the relation
+	byColumn map[*Column][]int
+}
+
+// maxIndexCacheEntries is the maximum number of indexes kept in the cache.
+const maxIndexCacheEntries = 1 << 16
+
+// indexCache keeps the lookup indexes outside of Schema and Table, so that copying, comparing and cloning them are not affected.
+// Indexes are built lazily and rebuilt when the slices are replaced.
+type indexCache struct {
+	mu      sync.Mutex
+	entries map[any]any
+}
+
+type tableIndexKey struct{ s *Schema }
+
+type relationIndexKey struct{ s *Schema }
+
+type columnIndexKey struct{ t *Table }
+
+var indexes = &indexCache{entries: map[any]any{}}
+
+func (c *indexCache) load(key any) any {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.entries[key]
+}
+
+func (c *indexCache) store(key, idx any) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if _, ok := c.entries[key]; !ok && len(c.entries) >= maxIndexCacheEntries {
+		// drop all indexes instead of tracking their usage. they are rebuilt on the next lookup
+		clear(c.entries)
+	}
+	c.entries[key] = idx
+}
+
+func (c *indexCache) delete(key any) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	delete(c.entries, key)
+}
+
+// sameSlice reports whether a and b are the same slice ( same backing array and length ).
+func sameSlice[T any](a, b []T) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	return len(a) == 0 || &a[0] == &b[0]
+}
+
+// appendedSlice reports whether b is made by appending elements to a without reallocation.
+func appendedSlice[T any](a, b []T) bool {
+	if len(a) == 0 || len(a) >= len(b) {
+		return false
+	}
+	return &a[0] == &b[0]
+}
+
+func (s *Schema) currentSchema() string {
+	if s.Driver != nil && s.Driver.Meta != nil {
+		return s.Driver.Meta.CurrentSchema
+	}
+	return ""
+}
+
+// tableByName returns the table of the normalized name using the index.
+// Tables renamed in place are not found by the new name until the index is rebuilt ( ex. Filter, Sort ).
+func (s *Schema) tableByName(name string) (*Table, bool) {
+	n := s.NormalizeTableName(name)
+	idx, _ := indexes.load(tableIndexKey{s}).(*tableIndex)
+	if idx == nil || !sameSlice(idx.tables, s.Tables) || idx.currentSchema != s.currentSchema() {
+		idx = s.buildTableIndex()
+	}
+	i, ok := idx.byName[n]
+	if !ok {
+		return nil, false
+	}
+	if s.NormalizeTableName(s.Tables[i].Name) != n {
+		// the table is renamed or replaced in place after the index was built
+		idx = s.buildTableIndex()
+		if i, ok = idx.byName[n]; !ok {
+			return nil, false
+		}
+	}
+	return s.Tables[i], true
+}
+
+func (s *Schema) buildTableIndex() *tableIndex {
+	idx := &tableIndex{
+		tables:        s.Tables,
+		currentSchema: s.currentSchema(),
+		byName:        make(map[string]int, len(s.Tables)),
+	}
+	for i, t := range s.Tables {
+		n := s.NormalizeTableName(t.Name)
+		if _, ok := idx.byName[n]; ok {
+			// first one wins as with linear search
+			continue
+		}
+		idx.byName[n] = i
+	}
+	indexes.store(tableIndexKey{s}, idx)
+	return idx
+}
+
+// relationCandidates returns the relations whose first column is one of cs, in the order of s.Relations.
+func (s *Schema) relationCandidates(cs []*Column) []*Relation {
+	idx, _ := indexes.load(relationIndexKey{s}).(*relationIndex)
+	switch {
+	case idx != nil && sameSlice(idx.relations, s.Relations):
+	case idx != nil && appendedSlice(idx.relations, s.Relations):
+		// index only appended relations
+		next := &relationIndex{
+			relations: s.Relations,
+			byColumn:  make(map[*Column][]int, len(idx.byColumn)),
+		}
+		for c, ps := range idx.byColumn {
+			next.byColumn[c] = ps
+		}
+		for i := len(idx.relations); i < len(s.Relations); i++ {
+			next.add(i, s.Relations[i])
+		}
+		idx = next
+		indexes.store(relationIndexKey{s}, idx)
+	default:
+		idx = &relationIndex{
+			relations: s.Relations,
+			byColumn:  map[*Column][]int{},
+		}
+		for i, r := range s.Relations {
+			idx.add(i, r)
+		}
+		indexes.store(relationIndexKey{s}, idx)
+	}
+	if len(cs) == 0 {
+		return s.Relations
+	}
+	positions := []int{}
+	seen := map[*Column]struct{}{}
+	for _, c := range cs {
+		if _, ok := seen[c]; ok {
+			continue
+		}
+		seen[c] = struct{}{}
+		positions = append(positions, idx.byColumn[c]...)
+	}
+	sort.Ints(positions)
+	candidates := make([]*Relation, 0, len(positions))
+	for _, p := range positions {
+		candidates = append(candidates, s.Relations[p])
+	}
+	return candidates
+}
+
+func (idx *relationIndex) add(i int, r *Relation) {
+	if len(r.Columns) == 0 {
+		return
+	}
+	c := r.Columns[0]
+	ps := idx.byColumn[c]
+	// copy on write to keep the previous index immutable
+	idx.byColumn[c] = append(ps[:len(ps):len(ps)], i)
+}
+
+// invalidateIndexes drops the lookup indexes of the schema and its tables.
+func (s *Schema) invalidateIndexes() {
+	indexes.delete(tableIndexKey{s})
+	indexes.delete(relationIndexKey{s})
+	for _, t := range s.Tables {
+		t.invalidateIndexes()
+	}
+}
+
+// columnByName returns the column of the name using the index.
+// Columns renamed in place are not found by the new name until the index is rebuilt ( ex. Filter ).
+func (t *Table) columnByName(name string) (*Column, bool) {
+	idx, _ := indexes.load(columnIndexKey{t}).(*columnIndex)
+	if idx == nil || !sameSlice(idx.columns, t.Columns) {
+		idx = t.buildColumnIndex()
+	}
+	i, ok := idx.byName[name]
+	if !ok {
+		return nil, false
+	}
+	if t.Columns[i].Name != name {
+		// the column is renamed or replaced in place after the index was built
+		idx = t.buildColumnIndex()
+		if i, ok = idx.byName[name]; !ok {
+			return nil, false
+		}
+	}
+	return t.Columns[i], true
+}
+
+func (t *Table) buildColumnIndex() *columnIndex {
+	idx := &columnIndex{
+		columns: t.Columns,
+		byName:  make(map[string]int, len(t.Columns)),
+	}
+	for i, c := range t.Columns {
+		if _, ok := idx.byName[c.Name]; ok {
+			continue
+		}
+		idx.byName[c.Name] = i
+	}
+	indexes.store(columnIndexKey{t}, idx)
+	return idx
+}
+
+// invalidateIndexes drops the lookup indexes of the table.
+func (t *Table) invalidateIndexes() {
+	indexes.delete(columnIndexKey{t})
+}
@@ -0,0 +1,212 @@
+package schema
+
+import (
+	"fmt"
+	"testing"
+)
+
+func TestFindTableByNameWithIndex(t *testing.T) {
+	s := newLargeSchema(3, 2)
+	if _, err := s.FindTableByName("table1"); err != nil {
+		t.Fatal(err)
+	}
+
+	// append
+	s.Tables = append(s.Tables, &Table{Name: "added"})
+	if _, err := s.FindTableByName("added"); err != nil {
+		t.Error(err)
+	}
+
+	// filter
+	if err := s.Filter(&FilterOption{Exclude: []string{"table1"}}); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := s.FindTableByName("table1"); err == nil {
+		t.Error("want error")
+	}
+	if _, err := s.FindTableByName("table2"); err != nil {
+		t.Error(err)
+	}
+
+	// sort
+	if err := s.Sort(); err != nil {
+		t.Fatal(err)
+	}
+	for _, tt := range s.Tables {
+		got, err := s.FindTableByName(tt.Name)
+		if err != nil {
+			t.Error(err)
+			continue
+		}
+		if got != tt {
+			t.Errorf("got %v\nwant %v", got.Name, tt.Name)
+		}
+	}
+
+	// rename in place: the index is rebuilt when the old name is looked up
+	renamed := s.Tables[0]
+	oldName := renamed.Name
+	renamed.Name = "renamed"
+	if _, err := s.FindTableByName(oldName); err == nil {
+		t.Error("want error")
+	}
+	if got, err := s.FindTableByName("renamed"); err != nil || got != renamed {
+		t.Errorf("got %v, %v", got, err)
+	}
+
+	// replace in place
+	replaced := &Table{Name: s.Tables[1].Name}
+	s.Tables[1] = replaced
+	if got, err := s.FindTableByName(replaced.Name); err != nil || got != replaced {
+		t.Errorf("got %v, %v", got, err)
+	}
+
+	// normalized name
+	s.Driver = &Driver{Name: "postgres", Meta: &DriverMeta{CurrentSchema: "public"}}
+	s.Tables = append(s.Tables, &Table{Name: "public.users"})
+	if _, err := s.FindTableByName("users"); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestFindColumnByNameWithIndex(t *testing.T) {
+	tbl := &Table{Name: "t", Columns: []*Column{{Name: "a"}, {Name: "b"}}}
+	if _, err := tbl.FindColumnByName("a"); err != nil {
+		t.Fatal(err)
+	}
+	tbl.Columns = append(tbl.Columns, &Column{Name: "c"})
+	if _, err := tbl.FindColumnByName("c"); err != nil {
+		t.Error(err)
+	}
+	tbl.Columns = tbl.Columns[1:]
+	if _, err := tbl.FindColumnByName("a"); err == nil {
+		t.Error("want error")
+	}
+	// rename and replace in place
+	tbl.Columns[0].Name = "renamed"
+	if _, err := tbl.FindColumnByName("b"); err == nil {
+		t.Error("want error")
+	}
+	if got, err := tbl.FindColumnByName("renamed"); err != nil || got != tbl.Columns[0] {
+		t.Errorf("got %v, %v", got, err)
+	}
+	replaced := &Column{Name: "c"}
+	tbl.Columns[1] = replaced
+	if got, err := tbl.FindColumnByName("c"); err != nil || got != replaced {
+		t.Errorf("got %v, %v", got, err)
+	}
+}
+
+func TestFindRelationWithIndex(t *testing.T) {
+	s := newLargeSchema(3, 2)
+	t0, t1, t2 := s.Tables[0], s.Tables[1], s.Tables[2]
+	r, err := s.FindRelation([]*Column{t1.Columns[1]}, []*Column{t0.Columns[0]})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if r != s.Relations[0] {
+		t.Errorf("got %v\nwant %v", r, s.Relations[0])
+	}
+	if _, err := s.FindRelation([]*Column{t2.Columns[0]}, []*Column{t0.Columns[0]}); err == nil {
+		t.Error("want error")
+	}
+
+	added := &Relation{Table: t2, Columns: []*Column{t2.Columns[0]}, ParentTable: t0, ParentColumns: []*Column{t0.Columns[0]}}
+	s.Relations = append(s.Relations, added)
+	r, err = s.FindRelation([]*Column{t2.Columns[0]}, []*Column{t0.Columns[0]})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if r != added {
+		t.Errorf("got %v\nwant %v", r, added)
+	}
+}
+
+// newLargeSchema returns the schema that each table has a relation to the previous table.
+func newLargeSchema(tableCount, columnCount int) *Schema {
+	s := &Schema{Name: "large"}
+	for i := 0; i < tableCount; i++ {
+		tbl := &Table{Name: fmt.Sprintf("table%d", i)}
+		for j := 0; j < columnCount; j++ {
+			tbl.Columns = append(tbl.Columns, &Column{Name: fmt.Sprintf("column%d", j), Type: "int"})
+		}
+		s.Tables = append(s.Tables, tbl)
+		if i == 0 {
+			continue
+		}
+		pt := s.Tables[i-1]
+		r := &Relation{
+			Table:         tbl,
+			Columns:       []*Column{tbl.Columns[columnCount-1]},
+			ParentTable:   pt,
+			ParentColumns: []*Column{pt.Columns[0]},
+		}
+		tbl.Columns[columnCount-1].ParentRelations = []*Relation{r}
+		pt.Columns[0].ChildRelations = append(pt.Columns[0].ChildRelations, r)
+		s.Relations = append(s.Relations, r)
+	}
+	return s
+}
+
+func linearFindTableByName(s *Schema, name string) *Table {
+	for _, t := range s.Tables {
+		if s.NormalizeTableName(t.Name) == s.NormalizeTableName(name) {
+			return t
+		}
+	}
+	return nil
+}
+
+func BenchmarkFindTableByName(b *testing.B) {
+	s := newLargeSchema(10000, 5)
+	b.Run("linear", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			if linearFindTableByName(s, fmt.Sprintf("table%d", i%10000)) == nil {
+				b.Fatal("not found")
+			}
+		}
+	})
+	b.Run("index", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			if _, err := s.FindTableByName(fmt.Sprintf("table%d", i%10000)); err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+}
+
+func BenchmarkFindTableByNameNotFound(b *testing.B) {
+	s := newLargeSchema(10000, 5)
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		if _, err := s.FindTableByName(fmt.Sprintf("missing%d", i%10000)); err == nil {
+			b.Fatal("want error")
+		}
+	}
+}
+
+func BenchmarkFindRelation(b *testing.B) {
+	s := newLargeSchema(10000, 5)
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		r := s.Relations[i%len(s.Relations)]
+		if _, err := s.FindRelation(r.Columns, r.ParentColumns); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func BenchmarkRepair(b *testing.B) {
+	for _, n := range []int{1000, 10000} {
+		b.Run(fmt.Sprintf("tables=%d", n), func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				b.StopTimer()
+				s := newLargeSchema(n, 5)
+				b.StartTimer()
+				if err := s.Repair(); err != nil {
+					b.Fatal(err)
+				}
+			}
+		})
+	}
+}
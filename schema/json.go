@@ -19,28 +19,32 @@ type SchemaJSON struct { // nolint: revive
 
 // TableJSON is a JSON representation of schema.Table.
 type TableJSON struct {
-	Name             string        `json:"name"`
-	Type             string        `json:"type"`
-	Comment          string        `json:"comment,omitempty"`
-	Columns          []*ColumnJSON `json:"columns"`
-	Indexes          []*Index      `json:"indexes,omitempty"`
-	Constraints      []*Constraint `json:"constraints,omitempty"`
-	Triggers         []*Trigger    `json:"triggers,omitempty"`
-	Def              string        `json:"def,omitempty"`
-	Labels           Labels        `json:"labels,omitempty"`
-	ReferencedTables []string      `json:"referenced_tables,omitempty"`
+	Name                string        `json:"name"`
+	Type                string        `json:"type"`
+	Comment             string        `json:"comment,omitempty"`
+	Columns             []*ColumnJSON `json:"columns"`
+	Indexes             []*Index      `json:"indexes,omitempty"`
+	Constraints         []*Constraint `json:"constraints,omitempty"`
+	Triggers            []*Trigger    `json:"triggers,omitempty"`
+	Def                 string        `json:"def,omitempty"`
+	Labels              Labels        `json:"labels,omitempty"`
+	ReferencedTables    []string      `json:"referenced_tables,omitempty"`
+	EnhancedCommentData *CommentData  `json:"enhanced_comment_data,omitempty"`
 }
 
 // ColumnJSON is a JSON representation of schema.Column.
 type ColumnJSON struct {
-	Name        string  `json:"name"`
-	Type        string  `json:"type"`
-	Nullable    bool    `json:"nullable"`
-	Default     *string `json:"default,omitempty" jsonschema:"anyof_type=string;null"`
-	ExtraDef    string  `json:"extra_def,omitempty"`
-	Labels      Labels  `json:"labels,omitempty"`
-	Comment     string  `json:"comment,omitempty"`
-	LogicalName string  `json:"logical_name,omitempty"`
+	Name                string            `json:"name"`
+	Type                string            `json:"type"`
+	Nullable            bool              `json:"nullable"`
+	Default             *string           `json:"default,omitempty" jsonschema:"anyof_type=string;null"`
+	ExtraDef            string            `json:"extra_def,omitempty"`
+	Labels              Labels            `json:"labels,omitempty"`
+	Comment             string            `json:"comment,omitempty"`
+	LogicalName         string            `json:"logical_name,omitempty"`
+	Classification      *Classification   `json:"classification,omitempty"`
+	Inherited           *InheritedComment `json:"inherited,omitempty"`
+	EnhancedCommentData *CommentData      `json:"enhanced_comment_data,omitempty"`
 }
 
 // RelationJSON is a JSON representation of schema.Relation.
@@ -103,16 +107,17 @@ func (t Table) ToJSONObject() TableJSON {
 		columns = append(columns, &cc)
 	}
 	return TableJSON{
-		Name:             t.Name,
-		Type:             t.Type,
-		Comment:          t.Comment,
-		Columns:          columns,
-		Indexes:          t.Indexes,
-		Constraints:      t.Constraints,
-		Triggers:         t.Triggers,
-		Def:              t.Def,
-		Labels:           t.Labels,
-		ReferencedTables: referencedTables,
+		Name:                t.Name,
+		Type:                t.Type,
+		Comment:             t.Comment,
+		Columns:             columns,
+		Indexes:             t.Indexes,
+		Constraints:         t.Constraints,
+		Triggers:            t.Triggers,
+		Def:                 t.Def,
+		Labels:              t.Labels,
+		ReferencedTables:    referencedTables,
+		EnhancedCommentData: t.EnhancedCommentData,
 	}
 }
 
@@ -122,14 +127,17 @@ func (c Column) ToJSONObject() ColumnJSON {
 		defaultVal = &c.Default.String
 	}
 	return ColumnJSON{
-		Name:        c.Name,
-		Type:        c.Type,
-		Nullable:    c.Nullable,
-		Default:     defaultVal,
-		Comment:     c.Comment,
-		ExtraDef:    c.ExtraDef,
-		Labels:      c.Labels,
-		LogicalName: c.LogicalName,
+		Name:                c.Name,
+		Type:                c.Type,
+		Nullable:            c.Nullable,
+		Default:             defaultVal,
+		Comment:             c.Comment,
+		ExtraDef:            c.ExtraDef,
+		Labels:              c.Labels,
+		LogicalName:         c.LogicalName,
+		Classification:      c.Classification,
+		Inherited:           c.Inherited,
+		EnhancedCommentData: c.EnhancedCommentData,
 	}
 }
 
@@ -206,16 +214,17 @@ func (r Relation) MarshalJSON() ([]byte, error) {
 // UnmarshalJSON unmarshal JSON to schema.Table.
 func (t *Table) UnmarshalJSON(data []byte) error {
 	s := struct {
-		Name             string        `json:"name"`
-		Type             string        `json:"type"`
-		Comment          string        `json:"comment,omitempty"`
-		Columns          []*Column     `json:"columns"`
-		Indexes          []*Index      `json:"indexes,omitempty"`
-		Constraints      []*Constraint `json:"constraints,omitempty"`
-		Triggers         []*Trigger    `json:"triggers,omitempty"`
-		Def              string        `json:"def,omitempty"`
-		Labels           Labels        `json:"labels,omitempty"`
-		ReferencedTables []string      `json:"referenced_tables,omitempty"`
+		Name                string        `json:"name"`
+		Type                string        `json:"type"`
+		Comment             string        `json:"comment,omitempty"`
+		Columns             []*Column     `json:"columns"`
+		Indexes             []*Index      `json:"indexes,omitempty"`
+		Constraints         []*Constraint `json:"constraints,omitempty"`
+		Triggers            []*Trigger    `json:"triggers,omitempty"`
+		Def                 string        `json:"def,omitempty"`
+		Labels              Labels        `json:"labels,omitempty"`
+		ReferencedTables    []string      `json:"referenced_tables,omitempty"`
+		EnhancedCommentData *CommentData  `json:"enhanced_comment_data,omitempty"`
 	}{}
 	err := json.Unmarshal(data, &s)
 	if err != nil {
@@ -230,6 +239,7 @@ func (t *Table) UnmarshalJSON(data []byte) error {
 	t.Triggers = s.Triggers
 	t.Def = s.Def
 	t.Labels = s.Labels
+	t.EnhancedCommentData = s.EnhancedCommentData
 	for _, rt := range s.ReferencedTables {
 		t.ReferencedTables = append(t.ReferencedTables, &Table{
 			Name: rt,
@@ -241,14 +251,17 @@ func (t *Table) UnmarshalJSON(data []byte) error {
 // UnmarshalJSON unmarshal JSON to schema.Column.
 func (c *Column) UnmarshalJSON(data []byte) error {
 	s := struct {
-		Name        string  `json:"name"`
-		Type        string  `json:"type"`
-		Nullable    bool    `json:"nullable"`
-		Default     *string `json:"default,omitempty"`
-		Comment     string  `json:"comment,omitempty"`
-		ExtraDef    string  `json:"extra_def,omitempty"`
-		Labels      Labels  `json:"labels,omitempty"`
-		LogicalName string  `json:"logical_name,omitempty"`
+		Name                string            `json:"name"`
+		Type                string            `json:"type"`
+		Nullable            bool              `json:"nullable"`
+		Default             *string           `json:"default,omitempty"`
+		Comment             string            `json:"comment,omitempty"`
+		ExtraDef            string            `json:"extra_def,omitempty"`
+		Labels              Labels            `json:"labels,omitempty"`
+		LogicalName         string            `json:"logical_name,omitempty"`
+		Classification      *Classification   `json:"classification,omitempty"`
+		Inherited           *InheritedComment `json:"inherited,omitempty"`
+		EnhancedCommentData *CommentData      `json:"enhanced_comment_data,omitempty"`
 	}{}
 	err := json.Unmarshal(data, &s)
 	if err != nil {
@@ -268,6 +281,9 @@ func (c *Column) UnmarshalJSON(data []byte) error {
 	c.Labels = s.Labels
 	c.Comment = s.Comment
 	c.LogicalName = s.LogicalName
+	c.Classification = s.Classification
+	c.Inherited = s.Inherited
+	c.EnhancedCommentData = s.EnhancedCommentData
 	return nil
 }
 
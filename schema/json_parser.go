@@ -148,6 +148,12 @@ func (p *JSONParser) convertMapToCommentData(data map[string]interface{}) (*Comm
 				result.LogicalName = str
 				break
 			}
+			// ロケールごとの論理名
+			if names, ok := localizedStrings(value); ok {
+				result.LogicalNames = names
+				result.LogicalName = LocalizedValue(names, "")
+				break
+			}
 		}
 	}
 	
@@ -159,6 +165,12 @@ func (p *JSONParser) convertMapToCommentData(data map[string]interface{}) (*Comm
 				result.Description = str
 				break
 			}
+			// ロケールごとの説明
+			if descriptions, ok := localizedStrings(value); ok {
+				result.Descriptions = descriptions
+				result.Description = LocalizedValue(descriptions, "")
+				break
+			}
 		}
 	}
 	
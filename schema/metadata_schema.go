@@ -0,0 +1,154 @@
+package schema
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// メタデータ値の型
+const (
+	MetadataTypeString = "string"
+	MetadataTypeInt    = "int"
+	MetadataTypeBool   = "bool"
+	MetadataTypeDate   = "date"
+)
+
+// MetadataDateLayout date型のメタデータ値の形式
+const MetadataDateLayout = "2006-01-02"
+
+// ErrMetadataSchemaViolation メタデータ定義に違反している
+var ErrMetadataSchemaViolation = errors.New("metadata schema violation")
+
+// MetadataField メタデータキーの定義
+type MetadataField struct {
+	// Key メタデータキー
+	Key string
+	// Required 必須キーかどうか
+	Required bool
+	// Type 値の型（string, int, bool, date）
+	Type string
+	// Enum 許可される値
+	Enum []string
+	// Pattern 値が一致すべき正規表現
+	Pattern string
+
+	pattern *regexp.Regexp
+}
+
+// MetadataSchema オブジェクトタイプごとのメタデータ定義
+type MetadataSchema struct {
+	fields map[ObjectType][]*MetadataField
+}
+
+// NewMetadataSchema メタデータ定義を作成（型と正規表現を検証）
+func NewMetadataSchema(fields map[ObjectType][]*MetadataField) (*MetadataSchema, error) {
+	ms := &MetadataSchema{fields: map[ObjectType][]*MetadataField{}}
+	for objectType, fs := range fields {
+		for _, f := range fs {
+			if f.Key == "" {
+				return nil, fmt.Errorf("metadata schema of %s: key is empty", objectType)
+			}
+			switch f.Type {
+			case "", MetadataTypeString, MetadataTypeInt, MetadataTypeBool, MetadataTypeDate:
+			default:
+				return nil, fmt.Errorf("metadata schema of %s: unsupported type of '%s': %s", objectType, f.Key, f.Type)
+			}
+			if f.Pattern != "" {
+				re, err := regexp.Compile(f.Pattern)
+				if err != nil {
+					return nil, fmt.Errorf("metadata schema of %s: invalid pattern of '%s': %w", objectType, f.Key, err)
+				}
+				f.pattern = re
+			}
+		}
+		ms.fields[objectType] = fs
+	}
+	return ms, nil
+}
+
+// MetadataViolation メタデータ定義への違反
+type MetadataViolation struct {
+	// Key メタデータキー
+	Key string
+	// Value メタデータ値
+	Value string
+	// Reason 違反の理由
+	Reason string
+}
+
+// MetadataSchemaError メタデータ定義への違反をまとめたエラー
+type MetadataSchemaError struct {
+	ObjectType ObjectType
+	Violations []*MetadataViolation
+}
+
+func (e *MetadataSchemaError) Error() string {
+	msgs := make([]string, 0, len(e.Violations))
+	for _, v := range e.Violations {
+		msgs = append(msgs, fmt.Sprintf("key '%s' %s", v.Key, v.Reason))
+	}
+	return fmt.Sprintf("%s: %s", ErrMetadataSchemaViolation, strings.Join(msgs, ", "))
+}
+
+func (e *MetadataSchemaError) Unwrap() error {
+	return ErrMetadataSchemaViolation
+}
+
+// Validate オブジェクトタイプのメタデータ定義でコメントデータを検証し、違反があればMetadataSchemaErrorを返す
+func (ms *MetadataSchema) Validate(objectType ObjectType, data *CommentData) error {
+	if ms == nil {
+		return nil
+	}
+	fields, ok := ms.fields[objectType]
+	if !ok {
+		return nil
+	}
+	metadata := data.UserMetadata()
+	violations := []*MetadataViolation{}
+	for _, f := range fields {
+		v, ok := metadata[f.Key]
+		if !ok {
+			if f.Required {
+				violations = append(violations, &MetadataViolation{Key: f.Key, Reason: "is required"})
+			}
+			continue
+		}
+		if reason := f.check(v); reason != "" {
+			violations = append(violations, &MetadataViolation{Key: f.Key, Value: v, Reason: reason})
+		}
+	}
+	if len(violations) == 0 {
+		return nil
+	}
+	return &MetadataSchemaError{ObjectType: objectType, Violations: violations}
+}
+
+// check 値を検証し、違反の理由を返す（違反がなければ空文字）
+func (f *MetadataField) check(v string) string {
+	switch f.Type {
+	case MetadataTypeInt:
+		if _, err := strconv.Atoi(v); err != nil {
+			return fmt.Sprintf("must be int (value: %q)", v)
+		}
+	case MetadataTypeBool:
+		if v != "true" && v != "false" {
+			return fmt.Sprintf("must be bool (value: %q)", v)
+		}
+	case MetadataTypeDate:
+		if _, err := time.Parse(MetadataDateLayout, v); err != nil {
+			return fmt.Sprintf("must be date (%s) (value: %q)", MetadataDateLayout, v)
+		}
+	}
+	if len(f.Enum) > 0 && !slices.Contains(f.Enum, v) {
+		return fmt.Sprintf("must be one of [%s] (value: %q)", strings.Join(f.Enum, ", "), v)
+	}
+	if f.pattern != nil && !f.pattern.MatchString(v) {
+		return fmt.Sprintf("must match %s (value: %q)", f.Pattern, v)
+	}
+	return ""
+}
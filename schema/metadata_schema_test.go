@@ -0,0 +1,131 @@
+package schema
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestMetadataSchemaValidate(t *testing.T) {
+	ms, err := NewMetadataSchema(map[ObjectType][]*MetadataField{
+		ObjectTypeTable: {
+			{Key: "owner", Required: true, Pattern: `^[a-z-]+$`},
+			{Key: "since", Type: MetadataTypeDate},
+		},
+		ObjectTypeColumn: {
+			{Key: "pii", Type: MetadataTypeBool},
+			{Key: "level", Type: MetadataTypeInt, Enum: []string{"1", "2", "3"}},
+		},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	tests := []struct {
+		name       string
+		objectType ObjectType
+		metadata   map[string]string
+		want       []*MetadataViolation
+	}{
+		{
+			name:       "valid",
+			objectType: ObjectTypeTable,
+			metadata:   map[string]string{"owner": "auth-team", "since": "2024-04-01"},
+		},
+		{
+			name:       "required",
+			objectType: ObjectTypeTable,
+			metadata:   map[string]string{MetadataKeyObjectType: "table"},
+			want:       []*MetadataViolation{{Key: "owner", Reason: "is required"}},
+		},
+		{
+			name:       "pattern and date",
+			objectType: ObjectTypeTable,
+			metadata:   map[string]string{"owner": "Auth Team", "since": "2024/04/01"},
+			want: []*MetadataViolation{
+				{Key: "owner", Value: "Auth Team", Reason: `must match ^[a-z-]+$ (value: "Auth Team")`},
+				{Key: "since", Value: "2024/04/01", Reason: `must be date (2006-01-02) (value: "2024/04/01")`},
+			},
+		},
+		{
+			name:       "bool and enum",
+			objectType: ObjectTypeColumn,
+			metadata:   map[string]string{"pii": "maybe", "level": "4"},
+			want: []*MetadataViolation{
+				{Key: "pii", Value: "maybe", Reason: `must be bool (value: "maybe")`},
+				{Key: "level", Value: "4", Reason: `must be one of [1, 2, 3] (value: "4")`},
+			},
+		},
+		{
+			name:       "int",
+			objectType: ObjectTypeColumn,
+			metadata:   map[string]string{"level": "high"},
+			want:       []*MetadataViolation{{Key: "level", Value: "high", Reason: `must be int (value: "high")`}},
+		},
+		{
+			name:       "no schema for object type",
+			objectType: ObjectTypeIndex,
+			metadata:   map[string]string{"pii": "maybe"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := ms.Validate(tt.objectType, &CommentData{Metadata: tt.metadata})
+			if tt.want == nil {
+				if err != nil {
+					t.Errorf("got %v", err)
+				}
+				return
+			}
+			var serr *MetadataSchemaError
+			if !errors.As(err, &serr) {
+				t.Fatalf("got %v, want MetadataSchemaError", err)
+			}
+			if !errors.Is(err, ErrMetadataSchemaViolation) {
+				t.Errorf("got %v, want ErrMetadataSchemaViolation", err)
+			}
+			if diff := cmp.Diff(tt.want, serr.Violations); diff != "" {
+				t.Error(diff)
+			}
+		})
+	}
+}
+
+func TestNewMetadataSchemaError(t *testing.T) {
+	tests := []struct {
+		name  string
+		field *MetadataField
+	}{
+		{"empty key", &MetadataField{}},
+		{"unsupported type", &MetadataField{Key: "owner", Type: "uuid"}},
+		{"invalid pattern", &MetadataField{Key: "owner", Pattern: "["}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := NewMetadataSchema(map[ObjectType][]*MetadataField{ObjectTypeTable: {tt.field}}); err == nil {
+				t.Error("want error")
+			}
+		})
+	}
+}
+
+func TestProcessCommentWithValidationMetadataSchema(t *testing.T) {
+	ms, err := NewMetadataSchema(map[ObjectType][]*MetadataField{
+		ObjectTypeColumn: {{Key: "pii", Type: MetadataTypeBool}},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	p := NewEnhancedCommentProcessor()
+	p.SetMetadataSchema(ms)
+	if _, err := p.ProcessCommentWithValidation(`{"name": "メール", "pii": "maybe"}`, "|", ObjectTypeColumn); !errors.Is(err, ErrMetadataSchemaViolation) {
+		t.Errorf("got %v, want ErrMetadataSchemaViolation", err)
+	}
+	got, err := p.ProcessCommentWithValidation(`{"name": "メール", "pii": true}`, "|", ObjectTypeColumn)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got.Metadata["pii"] != "true" {
+		t.Errorf("got %v", got.Metadata)
+	}
+}
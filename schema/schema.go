@@ -25,10 +25,12 @@ const (
 	ColumnParents     = "Parents"
 	ColumnComment     = "Comment"
 	ColumnLabels      = "Labels"
+	ColumnTags        = "Tags"
+	ColumnDeprecated  = "Deprecated"
 )
 
-var DefaultHideColumns = []string{ColumnExtraDef, ColumnOccurrences, ColumnPercents, ColumnLabels}
-var HideableColumns = []string{ColumnExtraDef, ColumnOccurrences, ColumnPercents, ColumnChildren, ColumnParents, ColumnComment, ColumnLabels}
+var DefaultHideColumns = []string{ColumnExtraDef, ColumnOccurrences, ColumnPercents, ColumnLabels, ColumnTags, ColumnDeprecated}
+var HideableColumns = []string{ColumnExtraDef, ColumnOccurrences, ColumnPercents, ColumnChildren, ColumnParents, ColumnComment, ColumnLabels, ColumnTags, ColumnDeprecated}
 
 type Label struct {
 	Name    string `json:"name"`
@@ -139,10 +141,30 @@ type Column struct {
 	HideForER       bool
 	// EnhancedCommentData 拡張コメント処理結果
 	EnhancedCommentData *CommentData `json:"enhancedCommentData,omitempty" yaml:"enhancedCommentData,omitempty"`
+	// Inherited 外部キーの親カラムから継承したコメントの項目
+	Inherited *InheritedComment `json:"inherited,omitempty" yaml:"inherited,omitempty"`
+	// Classification データ分類
+	Classification *Classification `json:"classification,omitempty" yaml:"classification,omitempty"`
 }
 
 // SetLogicalNameFromComment コメントから論理名を抽出してLogicalNameフィールドに設定します
 func (c *Column) SetLogicalNameFromComment(delimiter string, fallbackToName bool) {
+	// 構造化コメント（JSON/YAML/アノテーション）は拡張コメント処理で解析するため分割しない
+	if IsStructuredComment(c.Comment) {
+		return
+	}
+	c.splitLogicalName(delimiter, fallbackToName)
+}
+
+// SetLogicalNameFromStructuredComment 拡張コメント処理を行わない場合に、ドライバーが分割しなかった構造化コメントを従来形式として分割します
+func (c *Column) SetLogicalNameFromStructuredComment(delimiter string, fallbackToName bool) {
+	if !IsStructuredComment(c.Comment) {
+		return
+	}
+	c.splitLogicalName(delimiter, fallbackToName)
+}
+
+func (c *Column) splitLogicalName(delimiter string, fallbackToName bool) {
 	logicalName := ExtractLogicalName(c.Comment, delimiter, c.Name, fallbackToName)
 	c.LogicalName = logicalName
 	
@@ -181,12 +203,14 @@ func (c *Column) ProcessEnhancedComment(processor CommentProcessor, delimiter st
 				c.Comment = ExtractCleanComment(c.Comment, delimiter)
 			}
 		}
-	} else {
+	} else if !IsStructuredComment(c.Comment) {
 		// 拡張コメントに論理名がない場合は既存処理を使用
 		logicalName := ExtractLogicalName(c.Comment, delimiter, c.Name, fallbackToName)
 		c.LogicalName = logicalName
 		// 従来形式の場合のみコメントをクリーンアップ
 		c.Comment = ExtractCleanComment(c.Comment, delimiter)
+	} else if fallbackToName {
+		c.LogicalName = c.Name
 	}
 
 	return nil
@@ -202,16 +226,22 @@ func (c *Column) HasEnhancedComment() bool {
 	return c.EnhancedCommentData != nil
 }
 
-// GetDescription 説明を取得（拡張コメント優先）
+// GetDescription 説明を取得（拡張コメント優先、説明がない場合は親カラムから継承した説明）
 func (c *Column) GetDescription() string {
 	if c.EnhancedCommentData != nil && c.EnhancedCommentData.Description != "" {
 		return c.EnhancedCommentData.Description
 	}
+	if c.Inherited != nil && c.Inherited.Description != "" {
+		return c.Inherited.Description
+	}
 	return c.Comment
 }
 
-// GetTags タグ一覧を取得
+// GetTags タグ一覧を取得（タグがない場合は親カラムから継承したタグ）
 func (c *Column) GetTags() []string {
+	if c.Inherited != nil && len(c.Inherited.Tags) > 0 && len(c.ownTags()) == 0 {
+		return c.Inherited.Tags
+	}
 	if c.EnhancedCommentData != nil {
 		return c.EnhancedCommentData.Tags
 	}
@@ -248,6 +278,11 @@ func (c *Column) GetEnhancedLogicalNameOrFallback(fallbackToName bool) string {
 	if c.EnhancedCommentData != nil && c.EnhancedCommentData.LogicalName != "" {
 		return c.EnhancedCommentData.LogicalName
 	}
+
+	// 親カラムから継承した論理名を確認
+	if c.Inherited != nil && c.Inherited.LogicalName != "" {
+		return c.Inherited.LogicalName
+	}
 	
 	// 既存のLogicalNameフィールドを確認
 	if c.LogicalName != "" {
@@ -264,6 +299,10 @@ func (c *Column) GetEnhancedLogicalNameOrFallback(fallbackToName bool) string {
 
 // GetLogicalNameOrFallback 論理名を取得し、空の場合はフォールバック処理を行います
 func (c Column) GetLogicalNameOrFallback(fallbackToName bool) string {
+	if c.Inherited != nil && c.Inherited.LogicalName != "" {
+		return c.Inherited.LogicalName
+	}
+
 	if c.LogicalName != "" {
 		return c.LogicalName
 	}
@@ -300,6 +339,7 @@ type Table struct {
 	Labels           Labels
 	ReferencedTables []*Table
 	External         bool
+	HideForER        bool
 	LogicalName      string `json:"logicalName,omitempty"`
 	// 拡張コメントデータ
 	EnhancedCommentData *CommentData `json:"enhancedCommentData,omitempty"`
@@ -336,12 +376,14 @@ func (t *Table) ProcessEnhancedComment(processor CommentProcessor, delimiter str
 				t.Comment = ExtractCleanComment(t.Comment, delimiter)
 			}
 		}
-	} else {
+	} else if !IsStructuredComment(t.Comment) {
 		// 拡張コメントに論理名がない場合は既存処理を使用
 		logicalName := ExtractLogicalName(t.Comment, delimiter, t.Name, fallbackToName)
 		t.LogicalName = logicalName
 		// 従来形式の場合のみコメントをクリーンアップ
 		t.Comment = ExtractCleanComment(t.Comment, delimiter)
+	} else if fallbackToName {
+		t.LogicalName = t.Name
 	}
 	
 	return nil
@@ -722,10 +764,8 @@ func (s *Schema) FindTableByName(name string) (_ *Table, err error) {
 	defer func() {
 		err = errors.WithStack(err)
 	}()
-	for _, t := range s.Tables {
-		if s.NormalizeTableName(t.Name) == s.NormalizeTableName(name) {
-			return t, nil
-		}
+	if t, ok := s.tableByName(name); ok {
+		return t, nil
 	}
 	return nil, fmt.Errorf("not found table '%s'", name)
 }
@@ -753,7 +793,7 @@ func (s *Schema) FindRelation(cs, pcs []*Column) (_ *Relation, err error) {
 		err = errors.WithStack(err)
 	}()
 L:
-	for _, r := range s.Relations {
+	for _, r := range s.relationCandidates(cs) {
 		if len(r.Columns) != len(cs) || len(r.ParentColumns) != len(pcs) {
 			continue
 		}
@@ -832,6 +872,7 @@ func (s *Schema) Sort() error {
 	sort.SliceStable(s.Viewpoints, func(i, j int) bool {
 		return s.Viewpoints[i].Name < s.Viewpoints[j].Name
 	})
+	s.invalidateIndexes()
 	return nil
 }
 
@@ -963,6 +1004,8 @@ func (s *Schema) repairWithoutViewpoints() (err error) {
 	if len(s.Functions) == 0 {
 		s.Functions = nil
 	}
+	// columns of relations are replaced
+	indexes.delete(relationIndexKey{s})
 
 	return nil
 }
@@ -972,10 +1015,8 @@ func (t *Table) FindColumnByName(name string) (_ *Column, err error) {
 	defer func() {
 		err = errors.WithStack(err)
 	}()
-	for _, c := range t.Columns {
-		if c.Name == name {
-			return c, nil
-		}
+	if c, ok := t.columnByName(name); ok {
+		return c, nil
 	}
 	return nil, fmt.Errorf("not found column '%s' on table '%s'", name, t.Name)
 }
@@ -1063,6 +1104,14 @@ func (t *Table) hasColumnWithValues(name string) bool {
 			if len(c.Labels) > 0 {
 				return true
 			}
+		case ColumnTags:
+			if len(c.GetTags()) > 0 {
+				return true
+			}
+		case ColumnDeprecated:
+			if c.IsDeprecated() {
+				return true
+			}
 		}
 	}
 	return false
@@ -1141,11 +1190,26 @@ func (t *Table) SetLogicalNameFromComment(delimiter string, fallbackToName bool)
 		}
 		return
 	}
+	// 構造化コメント（JSON/YAML/アノテーション）は拡張コメント処理で解析するため分割しない
+	if IsStructuredComment(t.Comment) {
+		return
+	}
+	t.splitLogicalName(delimiter, fallbackToName)
+}
+
+// SetLogicalNameFromStructuredComment 拡張コメント処理を行わない場合に、ドライバーが分割しなかった構造化コメントを従来形式として分割
+func (t *Table) SetLogicalNameFromStructuredComment(delimiter string, fallbackToName bool) {
+	if !IsStructuredComment(t.Comment) {
+		return
+	}
+	t.splitLogicalName(delimiter, fallbackToName)
+}
 
-	parts := strings.SplitN(t.Comment, delimiter, 2)
-	if len(parts) == 2 {
-		t.LogicalName = strings.TrimSpace(parts[0])
-		t.Comment = strings.TrimSpace(parts[1])
+func (t *Table) splitLogicalName(delimiter string, fallbackToName bool) {
+	if delimiter != "" && indexUnescaped(t.Comment, delimiter) >= 0 {
+		parts := SplitComment(t.Comment, delimiter)
+		t.LogicalName = parts.LogicalName
+		t.Comment = parts.Comment
 	} else {
 		if fallbackToName {
 			t.LogicalName = t.Name
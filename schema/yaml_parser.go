@@ -179,6 +179,12 @@ func (p *YAMLParser) convertMapToCommentData(data map[string]interface{}) (*Comm
 				result.LogicalName = str
 				break
 			}
+			// ロケールごとの論理名
+			if names, ok := localizedStrings(value); ok {
+				result.LogicalNames = names
+				result.LogicalName = LocalizedValue(names, "")
+				break
+			}
 		}
 	}
 	
@@ -190,6 +196,12 @@ func (p *YAMLParser) convertMapToCommentData(data map[string]interface{}) (*Comm
 				result.Description = str
 				break
 			}
+			// ロケールごとの説明
+			if descriptions, ok := localizedStrings(value); ok {
+				result.Descriptions = descriptions
+				result.Description = LocalizedValue(descriptions, "")
+				break
+			}
 		}
 	}
 	
@@ -0,0 +1,107 @@
+package snapshot
+
+import (
+	"strings"
+	"time"
+
+	"github.com/k1LoW/tbls/schema"
+)
+
+// Event is a change of a table or column between snapshots.
+type Event struct {
+	Time time.Time `json:"time"`
+	Hash string    `json:"hash"`
+	// Initial is true if the object already exists in the first snapshot.
+	Initial bool           `json:"initial,omitempty"`
+	Change  *schema.Change `json:"change"`
+}
+
+// History returns events of the target ( `table` or `table.column` ) across snapshots, from oldest to newest.
+// Renames of the target are followed back to the old names.
+func History(snapshots []*Snapshot, target string, opt *schema.DiffOption) ([]*Event, error) {
+	ss := []*schema.Schema{}
+	for _, sn := range snapshots {
+		s, err := sn.Load()
+		if err != nil {
+			return nil, err
+		}
+		ss = append(ss, s)
+	}
+	table, column := splitTarget(ss, target)
+
+	events := []*Event{}
+	for i := len(ss) - 1; i > 0; i-- {
+		d := schema.DiffWithOption(ss[i-1], ss[i], opt)
+		renamedTable, renamedColumn := table, column
+		for _, c := range d.Changes {
+			if c.Table != table {
+				continue
+			}
+			isTable := c.ObjectType == schema.ObjectTypeTable
+			isColumn := c.ObjectType == schema.ObjectTypeColumn && c.Name == column
+			if column != "" && !isColumn && !(isTable && c.Type != schema.DiffModified) {
+				// for a column, only changes of the column and the table's existence or name
+				continue
+			}
+			events = append(events, &Event{
+				Time:   snapshots[i].Time,
+				Hash:   snapshots[i].Hash,
+				Change: c,
+			})
+			if c.Type != schema.DiffRenamed {
+				continue
+			}
+			switch {
+			case isTable:
+				renamedTable = c.From
+			case column != "" && isColumn:
+				renamedColumn = c.From
+			}
+		}
+		table, column = renamedTable, renamedColumn
+	}
+	if len(ss) > 0 {
+		if c, ok := initialChange(ss[0], table, column); ok {
+			events = append(events, &Event{
+				Time:    snapshots[0].Time,
+				Hash:    snapshots[0].Hash,
+				Initial: true,
+				Change:  c,
+			})
+		}
+	}
+
+	// oldest to newest
+	for i, j := 0, len(events)-1; i < j; i, j = i+1, j-1 {
+		events[i], events[j] = events[j], events[i]
+	}
+	return events, nil
+}
+
+// splitTarget splits target into table and column. Table names containing dots ( ex. public.users ) take precedence.
+func splitTarget(ss []*schema.Schema, target string) (string, string) {
+	for _, s := range ss {
+		if _, err := s.FindTableByName(target); err == nil {
+			return target, ""
+		}
+	}
+	i := strings.LastIndex(target, ".")
+	if i < 0 {
+		return target, ""
+	}
+	return target[:i], target[i+1:]
+}
+
+func initialChange(s *schema.Schema, table, column string) (*schema.Change, bool) {
+	t, err := s.FindTableByName(table)
+	if err != nil {
+		return nil, false
+	}
+	if column == "" {
+		return &schema.Change{Type: schema.DiffAdded, ObjectType: schema.ObjectTypeTable, Table: table, Name: table}, true
+	}
+	if _, err := t.FindColumnByName(column); err != nil {
+		return nil, false
+	}
+	return &schema.Change{Type: schema.DiffAdded, ObjectType: schema.ObjectTypeColumn, Table: table, Name: column}, true
+}
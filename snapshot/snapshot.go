@@ -0,0 +1,157 @@
+package snapshot
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/k1LoW/errors"
+	"github.com/k1LoW/tbls/schema"
+)
+
+// timeFormat is the time format of snapshot file names.
+// Nanoseconds keep snapshots saved within the same second in order.
+const timeFormat = "20060102T150405.000000000Z"
+
+// legacyTimeFormat is the time format of snapshot file names saved without nanoseconds.
+const legacyTimeFormat = "20060102T150405Z"
+
+// hashLen is the length of the content hash in snapshot file names.
+const hashLen = 12
+
+// Store is a local directory of schema snapshots.
+type Store struct {
+	dir string
+}
+
+// Snapshot is a saved schema.
+type Snapshot struct {
+	Path string    `json:"path"`
+	Time time.Time `json:"time"`
+	Hash string    `json:"hash"`
+}
+
+// NewStore return Store.
+func NewStore(dir string) *Store {
+	return &Store{
+		dir: dir,
+	}
+}
+
+// Save saves the schema as a snapshot named `<timestamp>-<sha256>.json`.
+// If the content is the same as the latest snapshot, it returns the latest snapshot and false.
+func (st *Store) Save(s *schema.Schema, now time.Time) (_ *Snapshot, _ bool, err error) {
+	defer func() {
+		err = errors.WithStack(err)
+	}()
+	b, err := json.MarshalIndent(s, "", "  ")
+	if err != nil {
+		return nil, false, err
+	}
+	sum := sha256.Sum256(b)
+	hash := hex.EncodeToString(sum[:])
+	snapshots, err := st.List()
+	if err != nil {
+		return nil, false, err
+	}
+	now = now.UTC()
+	if len(snapshots) > 0 {
+		latest := snapshots[len(snapshots)-1]
+		if strings.HasPrefix(hash, latest.Hash) {
+			return latest, false, nil
+		}
+		// keep the new snapshot after the latest one even if the clock goes back
+		if !now.After(latest.Time) {
+			now = latest.Time.Add(time.Nanosecond)
+		}
+	}
+	if err := os.MkdirAll(st.dir, 0755); err != nil { // #nosec
+		return nil, false, err
+	}
+	sn := &Snapshot{
+		Path: filepath.Join(st.dir, fmt.Sprintf("%s-%s.json", now.Format(timeFormat), hash[:hashLen])),
+		Time: now,
+		Hash: hash[:hashLen],
+	}
+	if err := os.WriteFile(sn.Path, append(b, '\n'), 0644); err != nil { // #nosec
+		return nil, false, err
+	}
+	return sn, true, nil
+}
+
+// List returns snapshots sorted from oldest to newest.
+func (st *Store) List() (_ []*Snapshot, err error) {
+	defer func() {
+		err = errors.WithStack(err)
+	}()
+	snapshots := []*Snapshot{}
+	entries, err := os.ReadDir(st.dir)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return snapshots, nil
+		}
+		return nil, err
+	}
+	for _, e := range entries {
+		if e.IsDir() {
+			continue
+		}
+		sn, ok := parseFileName(e.Name())
+		if !ok {
+			continue
+		}
+		sn.Path = filepath.Join(st.dir, e.Name())
+		snapshots = append(snapshots, sn)
+	}
+	sort.SliceStable(snapshots, func(i, j int) bool {
+		return snapshots[i].Time.Before(snapshots[j].Time)
+	})
+	return snapshots, nil
+}
+
+// Load loads the schema of the snapshot.
+func (sn *Snapshot) Load() (_ *schema.Schema, err error) {
+	defer func() {
+		err = errors.WithStack(err)
+	}()
+	b, err := os.ReadFile(filepath.Clean(sn.Path))
+	if err != nil {
+		return nil, err
+	}
+	s := &schema.Schema{}
+	if err := json.NewDecoder(bytes.NewReader(b)).Decode(s); err != nil {
+		return nil, fmt.Errorf("failed to load snapshot %s: %w", sn.Path, err)
+	}
+	if err := s.Repair(); err != nil {
+		return nil, err
+	}
+	return s, nil
+}
+
+func parseFileName(name string) (*Snapshot, bool) {
+	if filepath.Ext(name) != ".json" {
+		return nil, false
+	}
+	splitted := strings.SplitN(strings.TrimSuffix(name, ".json"), "-", 2)
+	if len(splitted) != 2 || len(splitted[1]) != hashLen {
+		return nil, false
+	}
+	t, err := time.Parse(timeFormat, splitted[0])
+	if err != nil {
+		t, err = time.Parse(legacyTimeFormat, splitted[0])
+		if err != nil {
+			return nil, false
+		}
+	}
+	return &Snapshot{
+		Time: t,
+		Hash: splitted[1],
+	}, true
+}
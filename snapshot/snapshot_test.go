@@ -0,0 +1,173 @@
+package snapshot
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/k1LoW/tbls/schema"
+	"github.com/k1LoW/tbls/testutil"
+)
+
+func TestSave(t *testing.T) {
+	st := NewStore(t.TempDir())
+	s := testutil.NewSchema(t)
+	now := time.Date(2024, 4, 1, 0, 0, 0, 0, time.UTC)
+
+	sn, saved, err := st.Save(s, now)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !saved {
+		t.Error("want saved")
+	}
+	// same content
+	sn2, saved, err := st.Save(s, now.Add(time.Hour))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if saved {
+		t.Error("want not saved")
+	}
+	if sn2.Path != sn.Path {
+		t.Errorf("got %v\nwant %v", sn2.Path, sn.Path)
+	}
+
+	s.Tables = s.Tables[:len(s.Tables)-1]
+	if _, saved, err := st.Save(s, now.Add(2*time.Hour)); err != nil || !saved {
+		t.Fatalf("saved: %v, err: %v", saved, err)
+	}
+
+	snapshots, err := st.List()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(snapshots) != 2 {
+		t.Fatalf("got %v\nwant %v", len(snapshots), 2)
+	}
+	if !snapshots[0].Time.Equal(now) || snapshots[0].Hash != sn.Hash {
+		t.Errorf("got %v %v\nwant %v %v", snapshots[0].Time, snapshots[0].Hash, now, sn.Hash)
+	}
+	got, err := snapshots[1].Load()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(got.Tables) != len(s.Tables) {
+		t.Errorf("got %v\nwant %v", len(got.Tables), len(s.Tables))
+	}
+}
+
+func TestSaveWithinSameSecond(t *testing.T) {
+	st := NewStore(t.TempDir())
+	s := testutil.NewSchema(t)
+	now := time.Date(2024, 4, 1, 0, 0, 0, 0, time.UTC)
+	tables := s.Tables
+
+	hashes := []string{}
+	for i, tt := range [][]*schema.Table{tables, tables[:len(tables)-1], tables} {
+		s.Tables = tt
+		sn, saved, err := st.Save(s, now.Add(time.Duration(i)*time.Millisecond))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !saved {
+			t.Fatalf("want saved: %d", i)
+		}
+		hashes = append(hashes, sn.Hash)
+	}
+	// the clock goes back
+	s.Tables = tables[:1]
+	sn, saved, err := st.Save(s, now)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !saved {
+		t.Fatal("want saved")
+	}
+	hashes = append(hashes, sn.Hash)
+
+	snapshots, err := st.List()
+	if err != nil {
+		t.Fatal(err)
+	}
+	got := []string{}
+	for _, sn := range snapshots {
+		got = append(got, sn.Hash)
+	}
+	if diff := cmp.Diff(got, hashes); diff != "" {
+		t.Error(diff)
+	}
+}
+
+func TestHistory(t *testing.T) {
+	st := NewStore(t.TempDir())
+	now := time.Date(2024, 4, 1, 0, 0, 0, 0, time.UTC)
+
+	// 1: initial
+	s := testutil.NewSchema(t)
+	if _, _, err := st.Save(s, now); err != nil {
+		t.Fatal(err)
+	}
+	// 2: add column a.added
+	ta, err := s.FindTableByName("a")
+	if err != nil {
+		t.Fatal(err)
+	}
+	ta.Columns = append(ta.Columns, &schema.Column{Name: "added", Type: "text", Nullable: true})
+	if _, _, err := st.Save(s, now.Add(time.Hour)); err != nil {
+		t.Fatal(err)
+	}
+	// 3: rename column a.added to a.renamed and make it not null
+	ca, err := ta.FindColumnByName("added")
+	if err != nil {
+		t.Fatal(err)
+	}
+	ca.Name = "renamed"
+	ca.Comment = "renamed column"
+	if _, _, err := st.Save(s, now.Add(2*time.Hour)); err != nil {
+		t.Fatal(err)
+	}
+	snapshots, err := st.List()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		target string
+		want   []string
+	}{
+		{"a.renamed", []string{
+			"2024-04-01T01:00:00Z added column a.added",
+			"2024-04-01T02:00:00Z renamed column a.renamed",
+		}},
+		{"a", []string{
+			"2024-04-01T00:00:00Z added table a (initial)",
+			"2024-04-01T01:00:00Z added column a.added",
+			"2024-04-01T02:00:00Z renamed column a.renamed",
+		}},
+		{"a.a", []string{
+			"2024-04-01T00:00:00Z added column a.a (initial)",
+		}},
+		{"unknown", []string{}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.target, func(t *testing.T) {
+			events, err := History(snapshots, tt.target, &schema.DiffOption{DetectRenames: true, RenameThreshold: 0.5})
+			if err != nil {
+				t.Fatal(err)
+			}
+			got := []string{}
+			for _, e := range events {
+				s := fmt.Sprintf("%s %s %s %s", e.Time.Format(time.RFC3339), e.Change.Type, e.Change.ObjectType, e.Change.Path())
+				if e.Initial {
+					s += " (initial)"
+				}
+				got = append(got, s)
+			}
+			if diff := cmp.Diff(got, tt.want); diff != "" {
+				t.Error(diff)
+			}
+		})
+	}
+}